@@ -0,0 +1,137 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package alt
+
+import (
+	"github.com/ohler55/ojg/gen"
+)
+
+// MergePatch applies a JSON Merge Patch (RFC 7396) to a copy of target and
+// returns the result leaving target unchanged. Members of a patch object
+// replace those in the target, objects are merged recursively, and a null
+// member removes the member from the target. A patch that is not an object
+// replaces the target.
+func MergePatch(target, patch any) any {
+	return mergePatch(patchCopy(target), patch)
+}
+
+// AlterMergePatch is the same as MergePatch except target objects are
+// modified in place.
+func AlterMergePatch(target, patch any) any {
+	return mergePatch(target, patch)
+}
+
+// GenMergePatch applies a JSON Merge Patch (RFC 7396) to a copy of a
+// gen.Node target and returns the result leaving target unchanged.
+func GenMergePatch(target, patch gen.Node) gen.Node {
+	if target != nil {
+		target = target.Dup()
+	}
+	return genMergePatch(target, patch)
+}
+
+// GenAlterMergePatch is the same as GenMergePatch except target objects are
+// modified in place.
+func GenAlterMergePatch(target, patch gen.Node) gen.Node {
+	return genMergePatch(target, patch)
+}
+
+// CreateMergePatch returns a JSON Merge Patch (RFC 7396) that transforms v0
+// into v1 when applied with MergePatch. Since a null in a merge patch
+// removes a member, null values in v1 objects can not be represented and
+// are treated as removals.
+func CreateMergePatch(v0, v1 any) any {
+	o0, ok0 := v0.(map[string]any)
+	o1, ok1 := v1.(map[string]any)
+	if !ok0 || !ok1 {
+		return patchCopy(v1)
+	}
+	patch := map[string]any{}
+	for k := range o0 {
+		if _, has := o1[k]; !has {
+			patch[k] = nil
+		}
+	}
+	for k, m1 := range o1 {
+		m0, has := o0[k]
+		switch {
+		case !has:
+			patch[k] = patchCopy(m1)
+		case !patchEqual(m0, m1):
+			patch[k] = CreateMergePatch(m0, m1)
+		}
+	}
+	return patch
+}
+
+// GenCreateMergePatch is the gen.Node equivalent of CreateMergePatch.
+func GenCreateMergePatch(v0, v1 gen.Node) gen.Node {
+	o0, ok0 := v0.(gen.Object)
+	o1, ok1 := v1.(gen.Object)
+	if !ok0 || !ok1 {
+		if v1 == nil {
+			return nil
+		}
+		return v1.Dup()
+	}
+	patch := gen.Object{}
+	for k := range o0 {
+		if _, has := o1[k]; !has {
+			patch[k] = nil
+		}
+	}
+	for k, m1 := range o1 {
+		m0, has := o0[k]
+		switch {
+		case !has:
+			if m1 != nil {
+				m1 = m1.Dup()
+			}
+			patch[k] = m1
+		case !patchEqual(m0, m1):
+			patch[k] = GenCreateMergePatch(m0, m1)
+		}
+	}
+	return patch
+}
+
+func mergePatch(target, patch any) any {
+	pm, ok := patch.(map[string]any)
+	if !ok {
+		return patchCopy(patch)
+	}
+	tm, ok := target.(map[string]any)
+	if !ok {
+		tm = make(map[string]any, len(pm))
+	}
+	for k, v := range pm {
+		if v == nil {
+			delete(tm, k)
+		} else {
+			tm[k] = mergePatch(tm[k], v)
+		}
+	}
+	return tm
+}
+
+func genMergePatch(target, patch gen.Node) gen.Node {
+	pm, ok := patch.(gen.Object)
+	if !ok {
+		if patch == nil {
+			return nil
+		}
+		return patch.Dup()
+	}
+	tm, ok := target.(gen.Object)
+	if !ok {
+		tm = make(gen.Object, len(pm))
+	}
+	for k, v := range pm {
+		if v == nil {
+			delete(tm, k)
+		} else {
+			tm[k] = genMergePatch(tm[k], v)
+		}
+	}
+	return tm
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package alt_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/alt"
+	"github.com/ohler55/ojg/gen"
+	"github.com/ohler55/ojg/oj"
+	"github.com/ohler55/ojg/tt"
+)
+
+// Examples from RFC 7396 appendix A.
+var mergePatchExamples = []struct {
+	target string
+	patch  string
+	expect string
+}{
+	{target: `{"a":"b"}`, patch: `{"a":"c"}`, expect: `{"a":"c"}`},
+	{target: `{"a":"b"}`, patch: `{"b":"c"}`, expect: `{"a":"b","b":"c"}`},
+	{target: `{"a":"b"}`, patch: `{"a":null}`, expect: `{}`},
+	{target: `{"a":"b","b":"c"}`, patch: `{"a":null}`, expect: `{"b":"c"}`},
+	{target: `{"a":["b"]}`, patch: `{"a":"c"}`, expect: `{"a":"c"}`},
+	{target: `{"a":"c"}`, patch: `{"a":["b"]}`, expect: `{"a":["b"]}`},
+	{target: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, expect: `{"a":{"b":"d"}}`},
+	{target: `{"a":[{"b":"c"}]}`, patch: `{"a":[1]}`, expect: `{"a":[1]}`},
+	{target: `["a","b"]`, patch: `["c","d"]`, expect: `["c","d"]`},
+	{target: `{"a":"b"}`, patch: `["c"]`, expect: `["c"]`},
+	{target: `{"a":"foo"}`, patch: `null`, expect: `null`},
+	{target: `{"a":"foo"}`, patch: `"bar"`, expect: `"bar"`},
+	{target: `{"e":null}`, patch: `{"a":1}`, expect: `{"a":1,"e":null}`},
+	{target: `[1,2]`, patch: `{"a":"b","c":null}`, expect: `{"a":"b"}`},
+	{target: `{}`, patch: `{"a":{"bb":{"ccc":null}}}`, expect: `{"a":{"bb":{}}}`},
+}
+
+func TestMergePatch(t *testing.T) {
+	opt := &oj.Options{Sort: true}
+	for _, d := range mergePatchExamples {
+		target := oj.MustParseString(d.target)
+		patch := oj.MustParseString(d.patch)
+		result := alt.MergePatch(target, patch)
+		tt.Equal(t, d.expect, oj.JSON(result, opt), "%s + %s", d.target, d.patch)
+		tt.Equal(t, d.target, oj.JSON(target, opt), "%s + %s", d.target, d.patch)
+
+		result = alt.AlterMergePatch(target, patch)
+		tt.Equal(t, d.expect, oj.JSON(result, opt), "%s + %s", d.target, d.patch)
+	}
+	target := map[string]any{"a": 1, "b": map[string]any{"c": 2}}
+	alt.AlterMergePatch(target, map[string]any{"b": map[string]any{"c": nil, "d": 3}})
+	tt.Equal(t, map[string]any{"a": 1, "b": map[string]any{"d": 3}}, target)
+}
+
+func TestGenMergePatch(t *testing.T) {
+	var p gen.Parser
+	opt := &oj.Options{Sort: true}
+	for _, d := range mergePatchExamples {
+		target, err := p.Parse([]byte(d.target))
+		tt.Nil(t, err)
+		patch, err := p.Parse([]byte(d.patch))
+		tt.Nil(t, err)
+		result := alt.GenMergePatch(target, patch)
+		tt.Equal(t, d.expect, oj.JSON(result, opt), "%s + %s", d.target, d.patch)
+		tt.Equal(t, d.target, oj.JSON(target, opt), "%s + %s", d.target, d.patch)
+
+		result = alt.GenAlterMergePatch(target, patch)
+		tt.Equal(t, d.expect, oj.JSON(result, opt), "%s + %s", d.target, d.patch)
+	}
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	opt := &oj.Options{Sort: true}
+	v0 := oj.MustParseString(`{"title":"Goodbye!","author":{"givenName":"John","familyName":"Doe"},"tags":["example","sample"],"content":"This will be unchanged"}`)
+	v1 := oj.MustParseString(`{"title":"Hello!","author":{"givenName":"John"},"tags":["example"],"content":"This will be unchanged","phoneNumber":"+01-123-456-7890"}`)
+	patch := alt.CreateMergePatch(v0, v1)
+	tt.Equal(t, `{"author":{"familyName":null},"phoneNumber":"+01-123-456-7890","tags":["example"],"title":"Hello!"}`, oj.JSON(patch, opt))
+	tt.Equal(t, oj.JSON(v1, opt), oj.JSON(alt.MergePatch(v0, patch), opt))
+
+	tt.Equal(t, `{}`, oj.JSON(alt.CreateMergePatch(v0, v0)))
+	tt.Equal(t, `[1]`, oj.JSON(alt.CreateMergePatch(v0, []any{1})))
+
+	g0 := alt.Generify(v0, &alt.Options{})
+	g1 := alt.Generify(v1, &alt.Options{})
+	gp := alt.GenCreateMergePatch(g0, g1)
+	tt.Equal(t, oj.JSON(patch, opt), oj.JSON(gp, opt))
+	tt.Equal(t, oj.JSON(v1, opt), oj.JSON(alt.GenMergePatch(g0, gp), opt))
+	tt.Nil(t, alt.GenCreateMergePatch(g0, nil))
+}
@@ -0,0 +1,690 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package alt
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ohler55/ojg/gen"
+)
+
+// Patch applies JSON Patch (RFC 6902) operations to doc. The operations can
+// be a []any of map[string]any such as those returned by DiffPatch() or a
+// gen.Array of gen.Object. The add, remove, replace, move, copy, and test
+// operations are supported.
+//
+// The operations are applied atomically and doc is never modified. Objects
+// and arrays along the path of each change are copied before they are
+// changed so the returned value shares unchanged values with doc and with
+// the operation values. If any operation fails the original doc is returned
+// along with an error.
+func Patch(doc any, ops any) (any, error) {
+	var list []any
+	switch tops := ops.(type) {
+	case []any:
+		list = tops
+	case gen.Array:
+		list = make([]any, len(tops))
+		for i, op := range tops {
+			list[i] = op
+		}
+	default:
+		return doc, fmt.Errorf("patch operations must be a list, not a %T", ops)
+	}
+	p := patcher{owned: map[uintptr]bool{}}
+	_, p.isGen = doc.(gen.Node)
+	result := doc
+	for i, op := range list {
+		var err error
+		if result, err = p.apply(result, op); err != nil {
+			return doc, fmt.Errorf("patch operation %d: %w", i, err)
+		}
+	}
+	return result, nil
+}
+
+// maxPatchPairs is the largest number of element pairs compared when
+// looking for the fewest changes between two arrays in DiffPatch().
+const maxPatchPairs = 1 << 20
+
+// DiffPatch returns the JSON Patch (RFC 6902) operations that transform v0
+// into v1 as a []any of map[string]any. Differences are detected with the
+// same rules as Diff(). Objects are compared member by member. Arrays are
+// compared with the fewest element additions, removals, and in place
+// changes so that elements inserted or removed anywhere in an array are
+// reported as add and remove operations. If the differing part of two
+// arrays has more than about a million element pairs the elements are
+// compared by index instead. Values in the operations are not copied so
+// gen.Node values stay gen.Node values.
+func DiffPatch(v0, v1 any) []any {
+	return diffPatch([]any{}, "", v0, v1)
+}
+
+func diffPatch(ops []any, path string, v0, v1 any) []any {
+	if o0, ok := patchObject(v0); ok {
+		if o1, ok := patchObject(v1); ok {
+			keys := make([]string, 0, len(o0)+len(o1))
+			for k := range o0 {
+				keys = append(keys, k)
+			}
+			for k := range o1 {
+				if _, has := o0[k]; !has {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				kp := path + "/" + escapePointer(k)
+				m0, has0 := o0[k]
+				m1, has1 := o1[k]
+				switch {
+				case !has1:
+					ops = append(ops, map[string]any{"op": "remove", "path": kp})
+				case !has0:
+					ops = append(ops, map[string]any{"op": "add", "path": kp, "value": m1})
+				default:
+					ops = diffPatch(ops, kp, m0, m1)
+				}
+			}
+			return ops
+		}
+	}
+	if a0, ok := patchArray(v0); ok {
+		if a1, ok := patchArray(v1); ok {
+			return diffArray(ops, path, a0, a1)
+		}
+	}
+	if !patchEqual(v0, v1) {
+		ops = append(ops, map[string]any{"op": "replace", "path": path, "value": v1})
+	}
+	return ops
+}
+
+func diffArray(ops []any, path string, a0, a1 []any) []any {
+	start := 0
+	for start < len(a0) && start < len(a1) && patchEqual(a0[start], a1[start]) {
+		start++
+	}
+	e0 := len(a0)
+	e1 := len(a1)
+	for start < e0 && start < e1 && patchEqual(a0[e0-1], a1[e1-1]) {
+		e0--
+		e1--
+	}
+	m0 := a0[start:e0]
+	m1 := a1[start:e1]
+	if maxPatchPairs < len(m0)*len(m1) {
+		return diffRun(ops, path, start, m0, m1)
+	}
+	// cost[i][j] is the fewest changes that transform m0[i:] into m1[j:]
+	// where each change removes, adds, or changes in place one element.
+	cost := make([][]int, len(m0)+1)
+	for i := range cost {
+		cost[i] = make([]int, len(m1)+1)
+	}
+	for i := len(m0); 0 <= i; i-- {
+		for j := len(m1); 0 <= j; j-- {
+			switch {
+			case i == len(m0):
+				cost[i][j] = len(m1) - j
+			case j == len(m1):
+				cost[i][j] = len(m0) - i
+			case patchEqual(m0[i], m1[j]):
+				cost[i][j] = cost[i+1][j+1]
+			default:
+				cost[i][j] = 1 + min3(cost[i+1][j+1], cost[i+1][j], cost[i][j+1])
+			}
+		}
+	}
+	pos := start
+	i := 0
+	j := 0
+	for i < len(m0) || j < len(m1) {
+		switch {
+		case i < len(m0) && j < len(m1) && cost[i][j] == cost[i+1][j+1] && patchEqual(m0[i], m1[j]):
+			i++
+			j++
+			pos++
+		case i < len(m0) && (j == len(m1) || cost[i][j] == cost[i+1][j]+1):
+			ops = append(ops, map[string]any{"op": "remove", "path": path + "/" + strconv.Itoa(pos)})
+			i++
+		case j < len(m1) && (i == len(m0) || cost[i][j] == cost[i][j+1]+1):
+			ops = append(ops, map[string]any{"op": "add", "path": path + "/" + strconv.Itoa(pos), "value": m1[j]})
+			j++
+			pos++
+		default:
+			ops = diffPatch(ops, path+"/"+strconv.Itoa(pos), m0[i], m1[j])
+			i++
+			j++
+			pos++
+		}
+	}
+	return ops
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// diffRun appends the operations that transform removed into added starting
+// at pos by comparing elements with the same index.
+func diffRun(ops []any, path string, pos int, removed, added []any) []any {
+	i := 0
+	for ; i < len(removed) && i < len(added); i++ {
+		ops = diffPatch(ops, path+"/"+strconv.Itoa(pos), removed[i], added[i])
+		pos++
+	}
+	for ; i < len(removed); i++ {
+		ops = append(ops, map[string]any{"op": "remove", "path": path + "/" + strconv.Itoa(pos)})
+	}
+	for ; i < len(added); i++ {
+		ops = append(ops, map[string]any{"op": "add", "path": path + "/" + strconv.Itoa(pos), "value": added[i]})
+		pos++
+	}
+	return ops
+}
+
+// patcher applies patch operations. Objects and arrays created or copied
+// while patching are owned by the patcher and can be changed in place. All
+// others are copied before they are changed.
+type patcher struct {
+	owned map[uintptr]bool
+	isGen bool
+}
+
+func (p *patcher) apply(doc, op any) (any, error) {
+	name, err := patchString(op, "op")
+	if err != nil {
+		return nil, err
+	}
+	var path string
+	if path, err = patchString(op, "path"); err != nil {
+		return nil, err
+	}
+	var tokens []string
+	if tokens, err = parsePointer(path); err != nil {
+		return nil, err
+	}
+	switch name {
+	case "add":
+		var value any
+		if value, err = p.value(op); err != nil {
+			return nil, err
+		}
+		return p.add(doc, tokens, value)
+	case "remove":
+		doc, _, err = p.remove(doc, tokens)
+		return doc, err
+	case "replace":
+		var value any
+		if value, err = p.value(op); err != nil {
+			return nil, err
+		}
+		if doc, _, err = p.remove(doc, tokens); err != nil {
+			return nil, err
+		}
+		return p.add(doc, tokens, value)
+	case "move", "copy":
+		var from string
+		if from, err = patchString(op, "from"); err != nil {
+			return nil, err
+		}
+		var fromTokens []string
+		if fromTokens, err = parsePointer(from); err != nil {
+			return nil, err
+		}
+		var value any
+		if name == "move" {
+			if strings.HasPrefix(path, from+"/") {
+				return nil, fmt.Errorf("can not move %q into itself", from)
+			}
+			if doc, value, err = p.remove(doc, fromTokens); err != nil {
+				return nil, err
+			}
+		} else {
+			if value, err = patchGet(doc, fromTokens); err != nil {
+				return nil, err
+			}
+			// The copy must not share owned objects or arrays that could
+			// be changed in place by a later operation.
+			value = patchCopy(value)
+		}
+		return p.add(doc, tokens, value)
+	case "test":
+		var value, target any
+		if value, err = p.value(op); err != nil {
+			return nil, err
+		}
+		if target, err = patchGet(doc, tokens); err != nil {
+			return nil, err
+		}
+		if !patchEqual(target, value) {
+			return nil, fmt.Errorf("test failed for %q", path)
+		}
+		return doc, nil
+	}
+	return nil, fmt.Errorf("%q is not a valid patch operation", name)
+}
+
+// own returns v if it is owned by the patcher or a shallow copy of v that
+// is then owned by the patcher.
+func (p *patcher) own(v any) any {
+	switch tv := v.(type) {
+	case map[string]any:
+		if p.isOwned(tv) {
+			return tv
+		}
+		nm := make(map[string]any, len(tv))
+		for k, m := range tv {
+			nm[k] = m
+		}
+		return p.mark(nm)
+	case gen.Object:
+		if p.isOwned(tv) {
+			return tv
+		}
+		no := make(gen.Object, len(tv))
+		for k, m := range tv {
+			no[k] = m
+		}
+		return p.mark(no)
+	case []any:
+		if p.isOwned(tv) {
+			return tv
+		}
+		return p.mark(append([]any{}, tv...))
+	case gen.Array:
+		if p.isOwned(tv) {
+			return tv
+		}
+		return p.mark(append(gen.Array{}, tv...))
+	}
+	return v
+}
+
+func (p *patcher) isOwned(v any) bool {
+	rv := reflect.ValueOf(v)
+	return (rv.Kind() == reflect.Map || 0 < rv.Cap()) && p.owned[rv.Pointer()]
+}
+
+// mark an object or array as owned by the patcher.
+func (p *patcher) mark(v any) any {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Map || 0 < rv.Cap() {
+		p.owned[rv.Pointer()] = true
+	}
+	return v
+}
+
+// add adds value at the location identified by tokens and returns the
+// possibly replaced doc.
+func (p *patcher) add(doc any, tokens []string, value any) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return p.parent(doc, tokens, func(parent any, key string) (any, error) {
+		switch tp := parent.(type) {
+		case map[string]any:
+			tp = p.own(tp).(map[string]any)
+			tp[key] = value
+			return tp, nil
+		case gen.Object:
+			tp = p.own(tp).(gen.Object)
+			tp[key], _ = value.(gen.Node)
+			return tp, nil
+		case []any:
+			i, err := arrayIndex(key, len(tp), true)
+			if err != nil {
+				return nil, err
+			}
+			na := make([]any, 0, len(tp)+1)
+			na = append(na, tp[:i]...)
+			na = append(na, value)
+			return p.mark(append(na, tp[i:]...)), nil
+		case gen.Array:
+			i, err := arrayIndex(key, len(tp), true)
+			if err != nil {
+				return nil, err
+			}
+			na := make(gen.Array, 0, len(tp)+1)
+			na = append(na, tp[:i]...)
+			n, _ := value.(gen.Node)
+			na = append(na, n)
+			return p.mark(append(na, tp[i:]...)), nil
+		}
+		return nil, fmt.Errorf("can not add %q to a %T", key, parent)
+	})
+}
+
+// remove removes the value at the location identified by tokens and returns
+// the possibly replaced doc along with the removed value.
+func (p *patcher) remove(doc any, tokens []string) (any, any, error) {
+	if len(tokens) == 0 {
+		return nil, doc, nil
+	}
+	var removed any
+	doc, err := p.parent(doc, tokens, func(parent any, key string) (any, error) {
+		switch tp := parent.(type) {
+		case map[string]any:
+			var has bool
+			if removed, has = tp[key]; !has {
+				return nil, fmt.Errorf("%q not found", key)
+			}
+			tp = p.own(tp).(map[string]any)
+			delete(tp, key)
+			return tp, nil
+		case gen.Object:
+			var has bool
+			if removed, has = tp[key]; !has {
+				return nil, fmt.Errorf("%q not found", key)
+			}
+			tp = p.own(tp).(gen.Object)
+			delete(tp, key)
+			return tp, nil
+		case []any:
+			i, err := arrayIndex(key, len(tp), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = tp[i]
+			na := make([]any, 0, len(tp)-1)
+			na = append(na, tp[:i]...)
+			return p.mark(append(na, tp[i+1:]...)), nil
+		case gen.Array:
+			i, err := arrayIndex(key, len(tp), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = tp[i]
+			na := make(gen.Array, 0, len(tp)-1)
+			na = append(na, tp[:i]...)
+			return p.mark(append(na, tp[i+1:]...)), nil
+		}
+		return nil, fmt.Errorf("can not remove %q from a %T", key, parent)
+	})
+	return doc, removed, err
+}
+
+// parent calls fn with the parent of the location identified by tokens and
+// replaces the parent with the value returned by fn. Each object or array
+// along the way is owned by the patcher before the replacement is made.
+func (p *patcher) parent(v any, tokens []string, fn func(parent any, key string) (any, error)) (any, error) {
+	if len(tokens) == 1 {
+		return fn(v, tokens[0])
+	}
+	switch tv := v.(type) {
+	case map[string]any:
+		child, has := tv[tokens[0]]
+		if !has {
+			return nil, fmt.Errorf("%q not found", tokens[0])
+		}
+		nc, err := p.parent(child, tokens[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		tv = p.own(tv).(map[string]any)
+		tv[tokens[0]] = nc
+		return tv, nil
+	case gen.Object:
+		child, has := tv[tokens[0]]
+		if !has {
+			return nil, fmt.Errorf("%q not found", tokens[0])
+		}
+		nc, err := p.parent(child, tokens[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		tv = p.own(tv).(gen.Object)
+		tv[tokens[0]], _ = nc.(gen.Node)
+		return tv, nil
+	case []any:
+		i, err := arrayIndex(tokens[0], len(tv), false)
+		if err != nil {
+			return nil, err
+		}
+		var nc any
+		if nc, err = p.parent(tv[i], tokens[1:], fn); err != nil {
+			return nil, err
+		}
+		tv = p.own(tv).([]any)
+		tv[i] = nc
+		return tv, nil
+	case gen.Array:
+		i, err := arrayIndex(tokens[0], len(tv), false)
+		if err != nil {
+			return nil, err
+		}
+		var nc any
+		if nc, err = p.parent(tv[i], tokens[1:], fn); err != nil {
+			return nil, err
+		}
+		tv = p.own(tv).(gen.Array)
+		tv[i], _ = nc.(gen.Node)
+		return tv, nil
+	}
+	return nil, fmt.Errorf("%q not found", tokens[0])
+}
+
+// value returns the value of an operation converted to match the doc.
+func (p *patcher) value(op any) (any, error) {
+	v, has := patchField(op, "value")
+	if !has {
+		return nil, fmt.Errorf(`missing "value"`)
+	}
+	if n, ok := v.(gen.Node); ok {
+		if p.isGen {
+			return n, nil
+		}
+		return n.Simplify(), nil
+	}
+	if p.isGen {
+		return Generify(v, &Options{}), nil
+	}
+	return v, nil
+}
+
+func patchGet(v any, tokens []string) (any, error) {
+	for _, t := range tokens {
+		switch tv := v.(type) {
+		case map[string]any:
+			var has bool
+			if v, has = tv[t]; !has {
+				return nil, fmt.Errorf("%q not found", t)
+			}
+		case gen.Object:
+			var has bool
+			if v, has = tv[t]; !has {
+				return nil, fmt.Errorf("%q not found", t)
+			}
+		case []any:
+			i, err := arrayIndex(t, len(tv), false)
+			if err != nil {
+				return nil, err
+			}
+			v = tv[i]
+		case gen.Array:
+			i, err := arrayIndex(t, len(tv), false)
+			if err != nil {
+				return nil, err
+			}
+			v = tv[i]
+		default:
+			return nil, fmt.Errorf("%q not found", t)
+		}
+	}
+	return v, nil
+}
+
+// arrayIndex converts a JSON pointer reference token to an array index. If
+// adding then '-' and the length of the array are valid.
+func arrayIndex(token string, size int, adding bool) (int, error) {
+	if adding && token == "-" {
+		return size, nil
+	}
+	// RFC 6901 allows only 0 or digits without a leading zero.
+	valid := 0 < len(token) && (len(token) == 1 || token[0] != '0')
+	for _, b := range []byte(token) {
+		if b < '0' || '9' < b {
+			valid = false
+		}
+	}
+	i, err := strconv.Atoi(token)
+	if !valid || err != nil {
+		return 0, fmt.Errorf("%q is not a valid array index", token)
+	}
+	if size < i || (i == size && !adding) {
+		return 0, fmt.Errorf("index %d out of bounds", i)
+	}
+	return i, nil
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into unescaped reference
+// tokens.
+func parsePointer(s string) ([]string, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	if s[0] != '/' {
+		return nil, fmt.Errorf("%q is not a valid JSON pointer", s)
+	}
+	tokens := strings.Split(s[1:], "/")
+	for i, t := range tokens {
+		if strings.IndexByte(t, '~') < 0 {
+			continue
+		}
+		for j := 0; j < len(t); j++ {
+			if t[j] == '~' && (len(t) <= j+1 || (t[j+1] != '0' && t[j+1] != '1')) {
+				return nil, fmt.Errorf("%q is not a valid JSON pointer", s)
+			}
+		}
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func escapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+func patchString(op any, key string) (string, error) {
+	v, has := patchField(op, key)
+	switch tv := v.(type) {
+	case string:
+		return tv, nil
+	case gen.String:
+		return string(tv), nil
+	}
+	if !has {
+		return "", fmt.Errorf("missing %q", key)
+	}
+	return "", fmt.Errorf("%q must be a string", key)
+}
+
+func patchField(op any, key string) (v any, has bool) {
+	switch top := op.(type) {
+	case map[string]any:
+		v, has = top[key]
+	case gen.Object:
+		v, has = top[key]
+	}
+	return
+}
+
+func patchCopy(v any) any {
+	switch tv := v.(type) {
+	case []any:
+		na := make([]any, len(tv))
+		for i, m := range tv {
+			na[i] = patchCopy(m)
+		}
+		return na
+	case map[string]any:
+		nm := make(map[string]any, len(tv))
+		for k, m := range tv {
+			nm[k] = patchCopy(m)
+		}
+		return nm
+	case gen.Node:
+		return tv.Dup()
+	}
+	return v
+}
+
+// patchObject returns a shallow map[string]any for either a map[string]any
+// or a gen.Object.
+func patchObject(v any) (map[string]any, bool) {
+	switch tv := v.(type) {
+	case map[string]any:
+		return tv, true
+	case gen.Object:
+		m := make(map[string]any, len(tv))
+		for k, n := range tv {
+			m[k] = n
+		}
+		return m, true
+	}
+	return nil, false
+}
+
+// patchArray returns a shallow []any for either a []any or a gen.Array.
+func patchArray(v any) ([]any, bool) {
+	switch tv := v.(type) {
+	case []any:
+		return tv, true
+	case gen.Array:
+		a := make([]any, len(tv))
+		for i, n := range tv {
+			a[i] = n
+		}
+		return a, true
+	}
+	return nil, false
+}
+
+// patchEqual returns true if the values are equal according to RFC 6902
+// where object members must match exactly and numbers are compared by
+// value.
+func patchEqual(v0, v1 any) bool {
+	if o0, ok := patchObject(v0); ok {
+		o1, ok := patchObject(v1)
+		if !ok || len(o0) != len(o1) {
+			return false
+		}
+		for k, m0 := range o0 {
+			m1, has := o1[k]
+			if !has || !patchEqual(m0, m1) {
+				return false
+			}
+		}
+		return true
+	}
+	if a0, ok := patchArray(v0); ok {
+		a1, ok := patchArray(v1)
+		if !ok || len(a0) != len(a1) {
+			return false
+		}
+		for i, m0 := range a0 {
+			if !patchEqual(m0, a1[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if n, ok := v0.(gen.Node); ok {
+		v0 = n.Simplify()
+	}
+	if n, ok := v1.(gen.Node); ok {
+		v1 = n.Simplify()
+	}
+	return len(diff(v0, v1, true)) == 0
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package alt_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/alt"
+	"github.com/ohler55/ojg/gen"
+	"github.com/ohler55/ojg/oj"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestPatch(t *testing.T) {
+	for _, d := range []struct {
+		doc    string
+		ops    string
+		expect string
+		err    string
+	}{
+		// Examples from RFC 6902 appendix A.
+		{doc: `{"foo":"bar"}`, ops: `[{"op":"add","path":"/baz","value":"qux"}]`, expect: `{"baz":"qux","foo":"bar"}`},
+		{doc: `{"foo":["bar","baz"]}`, ops: `[{"op":"add","path":"/foo/1","value":"qux"}]`, expect: `{"foo":["bar","qux","baz"]}`},
+		{doc: `{"baz":"qux","foo":"bar"}`, ops: `[{"op":"remove","path":"/baz"}]`, expect: `{"foo":"bar"}`},
+		{doc: `{"foo":["bar","qux","baz"]}`, ops: `[{"op":"remove","path":"/foo/1"}]`, expect: `{"foo":["bar","baz"]}`},
+		{doc: `{"baz":"qux","foo":"bar"}`, ops: `[{"op":"replace","path":"/baz","value":"boo"}]`, expect: `{"baz":"boo","foo":"bar"}`},
+		{
+			doc:    `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			ops:    `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			expect: `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{doc: `{"foo":["all","grass","cows","eat"]}`, ops: `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, expect: `{"foo":["all","cows","eat","grass"]}`},
+		{
+			doc:    `{"baz":"qux","foo":["a",2,"c"]}`,
+			ops:    `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`,
+			expect: `{"baz":"qux","foo":["a",2,"c"]}`,
+		},
+		{doc: `{"baz":"qux"}`, ops: `[{"op":"test","path":"/baz","value":"bar"}]`, err: `patch operation 0: test failed for "/baz"`},
+		{doc: `{"foo":"bar"}`, ops: `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, expect: `{"child":{"grandchild":{}},"foo":"bar"}`},
+		{doc: `{"foo":"bar"}`, ops: `[{"op":"add","path":"/baz/bat","value":"qux"}]`, err: `patch operation 0: "baz" not found`},
+		{doc: `{"/":9,"~1":10}`, ops: `[{"op":"test","path":"/~01","value":10}]`, expect: `{"/":9,"~1":10}`},
+		{doc: `{"foo":["bar"]}`, ops: `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, expect: `{"foo":["bar",["abc","def"]]}`},
+		{doc: `{"foo":null}`, ops: `[{"op":"test","path":"/foo","value":null}]`, expect: `{"foo":null}`},
+		{doc: `{"foo":1}`, ops: `[{"op":"test","path":"/foo","value":1.0}]`, expect: `{"foo":1}`},
+		{doc: `{"foo":{"a":1}}`, ops: `[{"op":"test","path":"/foo","value":{"a":1,"b":null}}]`, err: `patch operation 0: test failed for "/foo"`},
+
+		{doc: `{"a":{"b":1}}`, ops: `[{"op":"copy","from":"/a","path":"/c"}]`, expect: `{"a":{"b":1},"c":{"b":1}}`},
+		{doc: `{"a":1}`, ops: `[{"op":"replace","path":"","value":[1]}]`, expect: `[1]`},
+		{doc: `[1,2]`, ops: `[{"op":"add","path":"/3","value":4}]`, err: `patch operation 0: index 3 out of bounds`},
+		{doc: `[1,2]`, ops: `[{"op":"add","path":"/01","value":4}]`, err: `patch operation 0: "01" is not a valid array index`},
+		{doc: `[1,2]`, ops: `[{"op":"add","path":"/-0","value":4}]`, err: `patch operation 0: "-0" is not a valid array index`},
+		{doc: `[1,2]`, ops: `[{"op":"replace","path":"/+1","value":4}]`, err: `patch operation 0: "+1" is not a valid array index`},
+		{doc: `[1,2]`, ops: `[{"op":"remove","path":"/"}]`, err: `patch operation 0: "" is not a valid array index`},
+		{doc: `{"a":{"b":1}}`, ops: `[{"op":"move","from":"/a","path":"/a/c"}]`, err: `patch operation 0: can not move "/a" into itself`},
+		{doc: `{"a":1}`, ops: `[{"op":"bad","path":"/a"}]`, err: `patch operation 0: "bad" is not a valid patch operation`},
+		{doc: `{"a":1}`, ops: `[{"op":"add","path":"a","value":1}]`, err: `patch operation 0: "a" is not a valid JSON pointer`},
+		{doc: `{"a":1}`, ops: `[{"op":"add","path":"/~2","value":1}]`, err: `patch operation 0: "/~2" is not a valid JSON pointer`},
+		{doc: `{"a":1}`, ops: `[{"op":"add","path":"/b"}]`, err: `patch operation 0: missing "value"`},
+	} {
+		doc := oj.MustParseString(d.doc)
+		result, err := alt.Patch(doc, oj.MustParseString(d.ops))
+		if 0 < len(d.err) {
+			tt.NotNil(t, err, d.ops)
+			tt.Equal(t, d.err, err.Error(), d.ops)
+			tt.Equal(t, d.doc, oj.JSON(result, &oj.Options{Sort: true}), d.ops)
+			continue
+		}
+		tt.Nil(t, err, d.ops)
+		tt.Equal(t, d.expect, oj.JSON(result, &oj.Options{Sort: true}), d.ops)
+		// The original must not be modified.
+		tt.Equal(t, d.doc, oj.JSON(doc, &oj.Options{Sort: true}), d.ops)
+	}
+}
+
+func TestPatchAtomic(t *testing.T) {
+	doc := map[string]any{"a": []any{1, 2}, "b": true}
+	ops := []any{
+		map[string]any{"op": "remove", "path": "/a/0"},
+		map[string]any{"op": "add", "path": "/c", "value": 3},
+		map[string]any{"op": "test", "path": "/b", "value": false},
+	}
+	result, err := alt.Patch(doc, ops)
+	tt.NotNil(t, err)
+	tt.Equal(t, map[string]any{"a": []any{1, 2}, "b": true}, result)
+	tt.Equal(t, map[string]any{"a": []any{1, 2}, "b": true}, doc)
+
+	_, err = alt.Patch(doc, "bad")
+	tt.NotNil(t, err)
+}
+
+func TestPatchGen(t *testing.T) {
+	var p gen.Parser
+	doc, err := p.Parse([]byte(`{"a":[1,2,3],"b":{"c":"x"}}`))
+	tt.Nil(t, err)
+	ops, err := p.Parse([]byte(`[
+  {"op":"remove","path":"/a/1"},
+  {"op":"add","path":"/b/d","value":{"e":null}},
+  {"op":"move","from":"/b/c","path":"/c"},
+  {"op":"test","path":"/a","value":[1,3]}
+]`))
+	tt.Nil(t, err)
+	result, err := alt.Patch(doc, ops)
+	tt.Nil(t, err)
+	tt.Equal(t, gen.Object{
+		"a": gen.Array{gen.Int(1), gen.Int(3)},
+		"b": gen.Object{"d": gen.Object{"e": nil}},
+		"c": gen.String("x"),
+	}, result)
+
+	// Simple operations on a gen.Node doc.
+	result, err = alt.Patch(doc, []any{map[string]any{"op": "add", "path": "/a/-", "value": []any{true}}})
+	tt.Nil(t, err)
+	tt.Equal(t, gen.Array{gen.Int(1), gen.Int(2), gen.Int(3), gen.Array{gen.True}}, result.(gen.Object)["a"])
+}
+
+func TestPatchCopyOnWrite(t *testing.T) {
+	shared := map[string]any{"x": 1}
+	doc := map[string]any{"a": []any{map[string]any{"b": 1}, 2}, "c": shared}
+	ops := sen.MustParse([]byte(`[
+  {op: replace path: "/a/0/b" value: 2}
+  {op: add path: "/a/0/d" value: 3}
+  {op: copy from: "/a/0" path: "/e"}
+  {op: add path: "/e/f" value: 4}
+  {op: add path: "/a/-" value: 5}
+]`))
+	result, err := alt.Patch(doc, ops)
+	tt.Nil(t, err)
+	tt.Equal(t, `{"a":[{"b":2,"d":3},2,5],"c":{"x":1},"e":{"b":2,"d":3,"f":4}}`, oj.JSON(result, &oj.Options{Sort: true}))
+	tt.Equal(t, `{"a":[{"b":1},2],"c":{"x":1},"e":null}`,
+		oj.JSON(map[string]any{"a": doc["a"], "c": doc["c"], "e": doc["e"]}, &oj.Options{Sort: true}))
+	// Unchanged values are shared with the original.
+	shared["y"] = 2
+	tt.Equal(t, 2, result.(map[string]any)["c"].(map[string]any)["y"])
+}
+
+func TestDiffPatchArray(t *testing.T) {
+	for _, d := range []struct {
+		v0     string
+		v1     string
+		expect string
+	}{
+		{v0: `[1,2,3]`, v1: `[1,9,2,3]`, expect: `[{"op":"add","path":"/1","value":9}]`},
+		{v0: `[1,2,3,4]`, v1: `[1,3,4]`, expect: `[{"op":"remove","path":"/1"}]`},
+		{v0: `[1,2,3]`, v1: `[0,1,2,3,4]`, expect: `[{"op":"add","path":"/0","value":0},{"op":"add","path":"/4","value":4}]`},
+		{
+			v0:     `[1,{"a":1},3,4]`,
+			v1:     `[{"a":2},3,5,4]`,
+			expect: `[{"op":"remove","path":"/0"},{"op":"replace","path":"/0/a","value":2},{"op":"add","path":"/2","value":5}]`,
+		},
+		{v0: `[1,2,3]`, v1: `[3,2,1]`, expect: `[{"op":"replace","path":"/0","value":3},{"op":"replace","path":"/2","value":1}]`},
+	} {
+		v0 := oj.MustParseString(d.v0)
+		v1 := oj.MustParseString(d.v1)
+		ops := alt.DiffPatch(v0, v1)
+		tt.Equal(t, d.expect, oj.JSON(ops, &oj.Options{Sort: true}), "%s to %s", d.v0, d.v1)
+		result, err := alt.Patch(v0, ops)
+		tt.Nil(t, err)
+		tt.Equal(t, d.v1, oj.JSON(result), "%s to %s", d.v0, d.v1)
+	}
+}
+
+func TestDiffPatch(t *testing.T) {
+	v0 := sen.MustParse([]byte(`{a: 1 b: [1 2 3] c: {d: x e: [true]} "f/g": 2}`))
+	v1 := sen.MustParse([]byte(`{a: 1.0 b: [1 4] c: {d: y f: null e: [true false]} h: 3}`))
+	ops := alt.DiffPatch(v0, v1)
+	tt.Equal(t,
+		`[{"op":"remove","path":"/b/1"},`+
+			`{"op":"replace","path":"/b/1","value":4},`+
+			`{"op":"replace","path":"/c/d","value":"y"},`+
+			`{"op":"add","path":"/c/e/1","value":false},`+
+			`{"op":"add","path":"/c/f","value":null},`+
+			`{"op":"remove","path":"/f~1g"},`+
+			`{"op":"add","path":"/h","value":3}]`,
+		oj.JSON(ops, &oj.Options{Sort: true}))
+
+	result, err := alt.Patch(v0, ops)
+	tt.Nil(t, err)
+	tt.Equal(t, 0, len(alt.DiffPatch(result, v1)))
+
+	tt.Equal(t, `[{"op":"replace","path":"","value":[1]}]`, oj.JSON(alt.DiffPatch(1, []any{1}), &oj.Options{Sort: true}))
+	tt.Equal(t, 0, len(alt.DiffPatch(nil, nil)))
+
+	g0 := alt.Generify(v0, &alt.Options{})
+	g1 := alt.Generify(v1, &alt.Options{})
+	ops = alt.DiffPatch(g0, g1)
+	tt.Equal(t, 7, len(ops))
+	gr, err := alt.Patch(g0, ops)
+	tt.Nil(t, err)
+	tt.Equal(t, 0, len(alt.DiffPatch(gr, g1)))
+	_, isGen := gr.(gen.Object)
+	tt.Equal(t, true, isGen)
+}
@@ -8,8 +8,9 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "and",
-		Eval: and,
+		Name:  "and",
+		Eval:  and,
+		Kinds: []ArgKind{BoolArg},
 		Desc: `Returns true if all argument evaluate to true. Any arguments
 that do not evaluate to a boolean or null (false) raise an error.`,
 	})
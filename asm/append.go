@@ -8,8 +8,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "append",
-		Eval: appendEval,
+		Name:    "append",
+		Eval:    appendEval,
+		MinArgs: 2,
+		MaxArgs: 2,
+		Kinds:   []ArgKind{ArrayArg, AnyArg},
 		Desc: `Appends the second argument to the first argument which must be
 an array.`,
 	})
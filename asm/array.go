@@ -8,8 +8,10 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "array?",
-		Eval: arrayEval,
+		Name:    "array?",
+		Eval:    arrayEval,
+		MinArgs: 1,
+		MaxArgs: 1,
 		Desc: `Returns true if the single required argumement is an array
 otherwise false is returned.`,
 	})
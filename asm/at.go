@@ -10,8 +10,9 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "at",
-		Eval: at,
+		Name:  "at",
+		Eval:  at,
+		Kinds: []ArgKind{StringArg},
 		Desc: `Forms a path starting with @. The remaining string arguments are
 joined with a '.' and parsed to form a jp.Expr.`,
 	})
@@ -0,0 +1,46 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+import (
+	"fmt"
+)
+
+func init() {
+	Define(&Fn{
+		Name:    "avg",
+		Eval:    avg,
+		MinArgs: 1,
+		MaxArgs: -1,
+		Desc: `Returns the average of the arguments as a float. If there is only
+one argument and it is an array or an object (map) then the
+average of the elements or values is returned. Values must be
+numbers. Null values are ignored and null is returned if there
+are no values.`,
+	})
+}
+
+func avg(root map[string]any, at any, args ...any) any {
+	if len(args) < 1 {
+		panic(fmt.Errorf("avg expects at least one argument. %d given", len(args)))
+	}
+	var (
+		total float64
+		cnt   int
+	)
+	for _, v := range aggregateValues(root, at, args) {
+		if v == nil {
+			continue
+		}
+		f, ok := asFloat(v)
+		if !ok {
+			panic(fmt.Errorf("avg expects only number values, not a %T", v))
+		}
+		total += f
+		cnt++
+	}
+	if cnt == 0 {
+		return nil
+	}
+	return total / float64(cnt)
+}
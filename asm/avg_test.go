@@ -0,0 +1,40 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestAvg(t *testing.T) {
+	root := testPlan(t,
+		`[
+           [set $.asm.a [avg [1 2 3 null 4]]]
+           [set $.asm.b [avg 1 2]]
+           [set $.asm.c [avg {x: 2 y: 4}]]
+           [set $.asm.d [avg []]]
+         ]`,
+		"{src: []}",
+	)
+	tt.Equal(t, `{a:2.5 b:1.5 c:3 d:null}`, sen.String(root["asm"], &sopt))
+}
+
+func TestAvgArgCount(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"avg"},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
+
+func TestAvgArgType(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"avg", []any{"list", 1, "a"}},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
@@ -8,8 +8,10 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "bool?",
-		Eval: boolEval,
+		Name:    "bool?",
+		Eval:    boolEval,
+		MinArgs: 1,
+		MaxArgs: 1,
 		Desc: `Returns true if the single required argumement is a boolean
 otherwise false is returned.`,
 	})
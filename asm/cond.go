@@ -10,8 +10,10 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "cond",
-		Eval: cond,
+		Name:    "cond",
+		Eval:    cond,
+		Compile: compileCond,
+		Kinds:   []ArgKind{ArrayArg},
 		Desc: `A conditional construct modeled after the LISP cond. All
 arguments must be array of two elements. The first element must
 evaluate to a boolean and the second can be any value. The value
@@ -36,6 +38,23 @@ func cond(root map[string]any, at any, args ...any) any {
 	return nil
 }
 
+// compileCond compiles the elements of each condition pair so functions
+// and variables from the enclosing scope can be used in conditions.
+func compileCond(f *Fn) {
+	sc := &scope{parent: f.scope}
+	for i, arg := range f.Args {
+		if list, ok := arg.([]any); ok {
+			// Compile a copy so the plan description is not modified.
+			list = append([]any{}, list...)
+			loc := f.argLoc(i)
+			for j, v := range list {
+				list[j] = sc.compileArg(v, append(loc[:len(loc):len(loc)], jp.Nth(j)))
+			}
+			f.Args[i] = list
+		}
+	}
+}
+
 func evalValue(root map[string]any, at any, value any) (result any) {
 top:
 	switch tv := value.(type) {
@@ -45,7 +64,9 @@ top:
 		if 0 < len(tv) {
 			if name, _ := tv[0].(string); 0 < len(name) {
 				if af := NewFn(name); af != nil {
-					af.Args = tv[1:]
+					// The arguments are copied since compiling replaces
+					// them and the list may be shared.
+					af.Args = append([]any{}, tv[1:]...)
 					af.compile()
 					value = af
 					goto top
@@ -69,7 +90,7 @@ top:
 		}
 		result = tv
 	default:
-		result = value
+		result = literal(value)
 	}
 	return
 }
@@ -0,0 +1,48 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+import (
+	"fmt"
+
+	"github.com/ohler55/ojg/jp"
+)
+
+func init() {
+	Define(&Fn{
+		Name:         "count",
+		Eval:         count,
+		MinArgs:      1,
+		MaxArgs:      2,
+		Kinds:        []ArgKind{ArrayArg | MapArg, PathArg | FnArg},
+		CallbackArgs: 1,
+		Desc: `Returns the number of elements in an array or values in an
+object (map). If a path is given as the optional second argument
+only elements that have a value at the path are counted. If a
+function is given only elements for which the function returns
+true are counted. The function is evaluated with @.src set to
+the element. A function defined with defn is called with the
+element as the first argument.`,
+	})
+}
+
+func count(root map[string]any, at any, args ...any) any {
+	if len(args) < 1 || 2 < len(args) {
+		panic(fmt.Errorf("count expects one or two arguments. %d given", len(args)))
+	}
+	list := members("count", evalArg(root, at, args[0]))
+	if len(args) == 1 {
+		return int64(len(list))
+	}
+	var cnt int64
+	for _, v := range list {
+		if x, ok := args[1].(jp.Expr); ok {
+			if x.Has(v) {
+				cnt++
+			}
+		} else if b, _ := elementValue(root, "count", args[1], v).(bool); b {
+			cnt++
+		}
+	}
+	return cnt
+}
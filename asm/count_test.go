@@ -0,0 +1,42 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestCount(t *testing.T) {
+	root := testPlan(t,
+		`[
+           [defn big [x] [gt $x 2]]
+           [set $.asm.a [count [1 2 3 4]]]
+           [set $.asm.b [count {a: 1 b: 2}]]
+           [set $.asm.c [count $.src @.x]]
+           [set $.asm.d [count [1 2 3 4] [big]]]
+           [set $.asm.e [count $.src [eq @.src.x 1]]]
+         ]`,
+		"{src: [{x: 1} {y: 2} {x: 3}]}",
+	)
+	tt.Equal(t, `{a:4 b:2 c:2 d:2 e:1}`, sen.String(root["asm"], &sopt))
+}
+
+func TestCountArgCount(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"count"},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
+
+func TestCountArgType(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"count", 1},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+import (
+	"fmt"
+)
+
+func init() {
+	Define(&Fn{
+		Name:    "defn",
+		Eval:    defn,
+		Compile: compileDefn,
+		MinArgs: 3,
+		MaxArgs: -1,
+		Kinds:   []ArgKind{StringArg, ArrayArg, AnyArg},
+		Desc: `Defines a function when the plan is compiled. The first
+argument is the function name, the second is an array of
+parameter names, and the remaining arguments are the body of
+the function. The function can be called by name in the rest of
+the enclosing function. When used with each the element is
+passed as the first argument. In the body parameters are
+referenced with a $ prefix such as $x or $x.a.
+The value of the last body argument is returned. Functions can
+call themselves but calls can not be nested more than the
+MaxCallDepth of the plan. The input is returned unchanged so defn can
+be used in an asm sequence.`,
+	})
+}
+
+func defn(root map[string]any, at any, args ...any) any {
+	return at
+}
+
+func compileDefn(f *Fn) {
+	if len(f.Args) < 3 {
+		panic(fmt.Errorf("defn expects at least three arguments. %d given", len(f.Args)))
+	}
+	name, _ := f.Args[0].(string)
+	if len(name) == 0 {
+		panic(fmt.Errorf("defn expects a function name as the first argument, not a %T", f.Args[0]))
+	}
+	if _, has := fnMap[name]; has {
+		panic(fmt.Errorf("defn can not redefine the %s function", name))
+	}
+	params, ok := f.Args[1].([]any)
+	if !ok {
+		panic(fmt.Errorf("defn expects an array of parameter names as the second argument, not a %T", f.Args[1]))
+	}
+	if f.scope == nil {
+		f.scope = &scope{}
+	}
+	if f.scope.fns == nil {
+		f.scope.fns = map[string]*userFn{}
+	}
+	uf := userFn{name: name, plan: f.scope.plan()}
+	// Register before compiling the body so the function can call itself.
+	f.scope.fns[name] = &uf
+	bs := &scope{parent: f.scope, boundary: true}
+	for _, p := range params {
+		pname, _ := p.(string)
+		if len(pname) == 0 {
+			panic(fmt.Errorf("defn parameter names must be strings, not a %T", p))
+		}
+		uf.params = append(uf.params, bs.declare(pname))
+	}
+	for i, b := range f.Args[2:] {
+		f.Args[i+2] = bs.compileArg(b, f.argLoc(i+2))
+	}
+	uf.body = f.Args[2:]
+}
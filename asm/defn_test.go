@@ -0,0 +1,127 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestDefn(t *testing.T) {
+	root := testPlan(t,
+		`[
+           [defn double [x] [sum $x $x]]
+           [defn fullname [p] [join [list $p.first $p.last] " "]]
+           [set $.asm.a [double 3]]
+           [set $.asm.b [double [double $.src.n]]]
+           [set $.asm.c [fullname $.src.person]]
+           [set $.asm.d [each $.src.list [double]]]
+         ]`,
+		"{src: {n: 2 person: {first: Ann last: Lee} list: [1 2 3]}}",
+	)
+	tt.Equal(t, "{a:6 b:8 c:\"Ann Lee\" d:[2 4 6]}", sen.String(root["asm"], &sopt))
+}
+
+func TestDefnRecursion(t *testing.T) {
+	root := testPlan(t,
+		`[
+           [defn fact [n] [cond [[lte $n 1] 1] [true [product $n [fact [dif $n 1]]]]]]
+           [set $.asm [fact 5]]
+         ]`,
+		"{src: []}",
+	)
+	tt.Equal(t, int64(120), root["asm"])
+
+	p := asm.NewPlan([]any{
+		[]any{"defn", "forever", []any{"n"}, []any{"forever", "$n"}},
+		[]any{"forever", 1},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+	var ae *asm.Error
+	tt.Equal(t, true, errors.As(err, &ae))
+	tt.Equal(t, "forever exceeded the maximum call depth of 100", ae.Err.Error())
+	tt.Equal(t, "[0][3]", ae.Loc.String())
+}
+
+func TestDefnMaxCallDepth(t *testing.T) {
+	desc := []any{
+		[]any{"defn", "down", []any{"n"}, []any{"cond", []any{[]any{"lte", "$n", 0}, int64(0)}, []any{true, []any{"down", []any{"dif", "$n", 1}}}}},
+		[]any{"set", "$.asm", []any{"down", "$.src.n"}},
+	}
+	shallow := asm.NewPlan(desc)
+	shallow.MaxCallDepth = 5
+	deep := asm.NewPlan(desc)
+	deep.MaxCallDepth = 500
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			err := shallow.Execute(map[string]any{"src": map[string]any{"n": 10}})
+			var ae *asm.Error
+			if !errors.As(err, &ae) || ae.Err.Error() != "down exceeded the maximum call depth of 5" {
+				t.Errorf("expected a depth error from the shallow plan, not %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			root := map[string]any{"src": map[string]any{"n": 200}}
+			if err := deep.Execute(root); err != nil || root["asm"] != int64(0) {
+				t.Errorf("expected the deep plan to succeed, not %v %v", err, root["asm"])
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Without a setting the default applies.
+	err := asm.NewPlan(desc).Execute(map[string]any{"src": map[string]any{"n": 200}})
+	tt.NotNil(t, err)
+	tt.Equal(t, true, strings.Contains(err.Error(), fmt.Sprintf("maximum call depth of %d", asm.DefaultMaxCallDepth)))
+}
+
+func TestDefnScope(t *testing.T) {
+	root := testPlan(t,
+		`[
+           [defn twice [x] [defn add [a b] [sum $a $b]] [add $x $x]]
+           [set $.asm.a [twice 4]]
+           [set $.asm.b [add 1 2]]
+           [set $.asm.c [each [1 2] [twice]]]
+         ]`,
+		"{src: []}",
+	)
+	// add is not visible outside of twice so the array is not a call.
+	tt.Equal(t, "{a:8 b:[add 1 2] c:[2 4]}", sen.String(root["asm"], &sopt))
+}
+
+func TestDefnErrors(t *testing.T) {
+	for _, plan := range [][]any{
+		{[]any{"defn", "x", []any{}}},
+		{[]any{"defn", 1, []any{}, 1}},
+		{[]any{"defn", "sum", []any{}, 1}},
+		{[]any{"defn", "x", "y", 1}},
+		{[]any{"defn", "x", []any{1}, 1}},
+	} {
+		tt.Panic(t, func() { _ = asm.NewPlan(plan) }, "%v", plan)
+	}
+	p := asm.NewPlan([]any{
+		[]any{"defn", "one", []any{"a"}, "$a"},
+		[]any{"one", 1, 2},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
+
+func TestDefnDocs(t *testing.T) {
+	docs := asm.FnDocs()
+	tt.NotNil(t, docs["defn"])
+	tt.NotNil(t, docs["let"])
+}
@@ -27,7 +27,7 @@ func TestDel(t *testing.T) {
 func TestDelExprError(t *testing.T) {
 	p := asm.NewPlan([]any{
 		map[string]any{},
-		[]any{"del", jp.D()},
+		[]any{"del", jp.R()},
 	})
 	err := p.Execute(map[string]any{})
 	tt.NotNil(t, err)
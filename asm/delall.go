@@ -10,8 +10,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "delall",
-		Eval: delall,
+		Name:    "delall",
+		Eval:    delall,
+		MinArgs: 1,
+		MaxArgs: 1,
+		Kinds:   []ArgKind{PathArg},
 		Desc: `Deletes the all matching values in either the root ($) or
 local (@) data. Exactly one argument is required and it must be
 a path. The jp.DelOne() function is used to delete the value.
@@ -27,7 +27,7 @@ func TestDelall(t *testing.T) {
 func TestDelallExprError(t *testing.T) {
 	p := asm.NewPlan([]any{
 		map[string]any{},
-		[]any{"delall", jp.D()},
+		[]any{"delall", jp.R()},
 	})
 	err := p.Execute(map[string]any{})
 	tt.NotNil(t, err)
@@ -8,15 +8,17 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "dif",
-		Eval: dif,
+		Name:  "dif",
+		Eval:  dif,
+		Kinds: []ArgKind{NumArg},
 		Desc: `Returns the difference of all arguments. All arguments must be
 numbers. If any of the arguments are not a number an error is
 raised.`,
 	})
 	Define(&Fn{
-		Name: "-",
-		Eval: dif,
+		Name:  "-",
+		Eval:  dif,
+		Kinds: []ArgKind{NumArg},
 		Desc: `Returns the difference of all arguments. All arguments must be
 numbers. If any of the arguments are not a number an error is
 raised.`,
@@ -0,0 +1,40 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+import (
+	"fmt"
+
+	"github.com/ohler55/ojg/sen"
+)
+
+func init() {
+	Define(&Fn{
+		Name:    "distinct",
+		Eval:    distinct,
+		MinArgs: 1,
+		MaxArgs: 1,
+		Kinds:   []ArgKind{ArrayArg | MapArg},
+		Desc: `Returns an array of the unique elements of an array or values of
+an object (map) in the order they are first encountered. Object
+values are visited in key order.`,
+	})
+}
+
+func distinct(root map[string]any, at any, args ...any) any {
+	if len(args) != 1 {
+		panic(fmt.Errorf("distinct expects exactly one argument. %d given", len(args)))
+	}
+	list := members("distinct", evalArg(root, at, args[0]))
+	opt := sen.Options{Sort: true}
+	seen := map[string]bool{}
+	unique := list[:0]
+	for _, v := range list {
+		key := sen.String(v, &opt)
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestDistinct(t *testing.T) {
+	root := testPlan(t,
+		`[
+           [set $.asm.a [distinct [3 1 3 a [1 2] a [1 2] {x: 1}]]]
+           [set $.asm.b [distinct {a: 1 b: 2 c: 1}]]
+         ]`,
+		"{src: []}",
+	)
+	tt.Equal(t, `{a:[3 1 a [1 2]{x:1}] b:[1 2]}`, sen.String(root["asm"], &sopt))
+}
+
+func TestDistinctArgCount(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"distinct", []any{}, 1},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
+
+func TestDistinctArgType(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"distinct", "abc"},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
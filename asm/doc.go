@@ -70,6 +70,12 @@ The functions available are:
 	      at: Forms a path starting with @. The remaining string arguments are
 	          joined with a '.' and parsed to form a jp.Expr.
 
+	     avg: Returns the average of the arguments as a float. If there is only
+	          one argument and it is an array or an object (map) then the
+	          average of the elements or values is returned. Values must be
+	          numbers. Null values are ignored and null is returned if there
+	          are no values.
+
 	   bool?: Returns true if the single required argumement is a boolean
 	          otherwise false is returned.
 
@@ -79,6 +85,26 @@ The functions available are:
 	          of the first true first argument is returned. If none match nil
 	          is returned.
 
+	   count: Returns the number of elements in an array or values in an
+	          object (map). If a path is given as the optional second argument
+	          only elements that have a value at the path are counted. If a
+	          function is given only elements for which the function returns
+	          true are counted. The function is evaluated with @.src set to
+	          the element. A function defined with defn is called with the
+	          element as the first argument.
+
+	    defn: Defines a function when the plan is compiled. The first
+	          argument is the function name, the second is an array of
+	          parameter names, and the remaining arguments are the body of
+	          the function. The function can be called by name in the rest of
+	          the enclosing function. When used with each the element is
+	          passed as the first argument. In the body parameters are
+	          referenced with a $ prefix such as $x or $x.a.
+	          The value of the last body argument is returned. Functions can
+	          call themselves but calls can not be nested more than the
+	          MaxCallDepth of the plan. The input is returned unchanged so defn can
+	          be used in an asm sequence.
+
 	     del: Deletes the first matching value in either the root ($) or
 	          local (@) data. Exactly one argument is required and it must be
 	          a path. The jp.DelOne() function is used to delete the value.
@@ -93,14 +119,28 @@ The functions available are:
 	          numbers. If any of the arguments are not a number an error is
 	          raised.
 
+	distinct: Returns an array of the unique elements of an array or values of
+	          an object (map) in the order they are first encountered. Object
+	          values are visited in key order.
+
 	    each: Each .
 
+	 entries: Returns an array of key and value pairs for an object (map)
+	          ordered by key. Each pair is an array of two elements. If the
+	          argument is an array the index of each element is used as the
+	          key.
+
 	      eq: Returns true if all the argument are equal. Aliases are eq, ==,
 	          and equal.
 
 	   equal: Returns true if all the argument are equal. Aliases are eq, ==,
 	          and equal.
 
+	 flatten: Returns an array with the elements of nested arrays moved up into
+	          the outer array. The optional second argument is the number of
+	          levels to flatten and defaults to 1. A negative depth flattens
+	          all levels.
+
 	   float: Converts a value into a float if possible. I no conversion is
 	          possible nil is returned.
 
@@ -116,6 +156,15 @@ The functions available are:
 	          data to apply the path to. The jp.Get() function is used to get
 	          the results
 
+	 groupby: Groups the elements of an array or the values of an object (map)
+	          by a key and returns an object of the keys and arrays of the
+	          elements with that key. The second argument is either a path
+	          that is applied to each element to get the key or a function
+	          that is evaluated with @.src set to the element. A function
+	          defined with defn is called with the element as the first
+	          argument. Keys that are not strings are converted to SEN
+	          strings.
+
 	      gt: Returns true if each argument is greater than any subsequent
 	          argument. An alias is >.
 
@@ -137,6 +186,15 @@ The functions available are:
 	          separator is not provided as the second argument then an empty
 	          string is used.
 
+	    keys: Returns the sorted keys of an object (map). If the argument is
+	          an array the indexes of the array are returned.
+
+	     let: Binds variables for use in the remaining arguments. The first
+	          argument must be a map of variable names to values. The values
+	          are evaluated before any are bound. The remaining arguments are
+	          evaluated in order with the variables referenced with a $ prefix
+	          such as $x or $x.a and the value of the last one is returned.
+
 	    list: Creates a list from all the argument and return that list.
 
 	      lt: Returns true if each argument is less than any subsequent
@@ -148,6 +206,18 @@ The functions available are:
 	    map?: Returns true if the single required argumement is a map
 	          otherwise false is returned.
 
+	     max: Returns the largest of the arguments. If there is only one
+	          argument and it is an array or an object (map) then the largest
+	          element or value is returned. Values must all be numbers, all be
+	          strings, or all be times. Null values are ignored and null is
+	          returned if there are no values.
+
+	     min: Returns the smallest of the arguments. If there is only one
+	          argument and it is an array or an object (map) then the smallest
+	          element or value is returned. Values must all be numbers, all be
+	          strings, or all be times. Null values are ignored and null is
+	          returned if there are no values.
+
 	     mod: Returns the remainer of a modulo operation on the first two
 	          argument. Both arguments must be integers and are both required.
 	          An error is raised if the wrong argument types are given.
@@ -189,6 +259,15 @@ The functions available are:
 	          raised. If an attempt is made to divide by zero and error will
 	          be raised.
 
+	  reduce: Folds the elements of an array or the values of an object (map)
+	          into a single value. The second argument is the initial value of
+	          the accumulator. The third argument is a function that is
+	          evaluated for each element with @.acc set to the accumulator and
+	          @.src set to the element. The result becomes the new accumulator
+	          value. A function defined with defn is called with the
+	          accumulator and the element as the first two arguments. The
+	          final accumulator value is returned.
+
 	 replace: Replace an occurrences the second argument with the third
 	          argument. All three arguments must be strings.
 
@@ -256,6 +335,14 @@ The functions available are:
 	    trim: Trim white space from both ends of a string unless a second
 	          argument provides an alternative cut set.
 
+	  values: Returns the values of an object (map) ordered by key. If the
+	          argument is an array a copy of the array is returned.
+
+	     zip: Returns an array of arrays where the first array contains the
+	          first element of each argument, the second contains the second
+	          element of each, and so on. All arguments must be arrays. The
+	          result is as long as the shortest argument.
+
 	    zone: Changes the timezone on a time to the location specified in the
 	          second argument. Raises an error if the first argument does not
 	          evaluate to a time or the location can not be determined.
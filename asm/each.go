@@ -8,9 +8,13 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "each",
-		Eval: each,
-		Desc: `Each .`,
+		Name:         "each",
+		Eval:         each,
+		MinArgs:      2,
+		MaxArgs:      3,
+		Kinds:        []ArgKind{ArrayArg, FnArg, StringArg},
+		CallbackArgs: 1,
+		Desc:         `Each .`,
 	})
 }
 
@@ -39,8 +43,25 @@ func each(root map[string]any, at any, args ...any) any {
 	var result []any
 	for _, src := range list {
 		at := map[string]any{"src": src}
+		if fn.user != nil {
+			// Functions defined with defn are called with the element as
+			// the first argument and the return value is collected.
+			result = append(result, callFn(root, at, fn, src))
+			continue
+		}
 		fn.Eval(root, at, fn.Args...)
 		result = append(result, at[key])
 	}
 	return result
 }
+
+// callFn calls a function given as an argument to another function such as
+// each. A function defined with defn is called with the params as the
+// leading arguments. Other functions are evaluated with at as the local
+// data.
+func callFn(root map[string]any, at any, fn *Fn, params ...any) any {
+	if fn.user != nil {
+		return fn.Eval(root, at, append(params, fn.Args...)...)
+	}
+	return fn.Eval(root, at, fn.Args...)
+}
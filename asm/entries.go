@@ -0,0 +1,44 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+import (
+	"fmt"
+)
+
+func init() {
+	Define(&Fn{
+		Name:    "entries",
+		Eval:    entries,
+		MinArgs: 1,
+		MaxArgs: 1,
+		Kinds:   []ArgKind{ArrayArg | MapArg},
+		Desc: `Returns an array of key and value pairs for an object (map)
+ordered by key. Each pair is an array of two elements. If the
+argument is an array the index of each element is used as the
+key.`,
+	})
+}
+
+func entries(root map[string]any, at any, args ...any) any {
+	if len(args) != 1 {
+		panic(fmt.Errorf("entries expects exactly one argument. %d given", len(args)))
+	}
+	switch v := evalArg(root, at, args[0]).(type) {
+	case []any:
+		list := make([]any, len(v))
+		for i, m := range v {
+			list[i] = []any{int64(i), m}
+		}
+		return list
+	case map[string]any:
+		ks := sortedKeys(v)
+		list := make([]any, len(ks))
+		for i, k := range ks {
+			list[i] = []any{k, v[k]}
+		}
+		return list
+	default:
+		panic(fmt.Errorf("entries expects an array or object argument, not a %T", v))
+	}
+}
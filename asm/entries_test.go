@@ -0,0 +1,38 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestEntries(t *testing.T) {
+	root := testPlan(t,
+		`[
+           [set $.asm.a [entries {b: 1 a: 2}]]
+           [set $.asm.b [entries [x y]]]
+         ]`,
+		"{src: []}",
+	)
+	tt.Equal(t, `{a:[[a 2][b 1]] b:[[0 x][1 y]]}`, sen.String(root["asm"], &sopt))
+}
+
+func TestEntriesArgCount(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"entries"},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
+
+func TestEntriesArgType(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"entries", 1},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/ohler55/ojg"
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/sen"
+)
+
+// Error is the error returned by Plan.Execute and Plan.Validate when a
+// function in the plan fails. It identifies the function by its location
+// in the plan array.
+type Error struct {
+	// Loc is the location of the function in the plan such as [3][2][1]
+	// for the function at index 1 of the array at index 2 of the array at
+	// index 3 of the plan. Values in a map such as the let bindings are
+	// identified by key.
+	Loc jp.Expr
+	// Name is the name of the function that failed.
+	Name string
+	// Args are the argument values with paths replaced by the values they
+	// select. Functions and the paths that the failed function does not
+	// evaluate are included as is.
+	Args []any
+	// Paths are the $ and @ data paths in the arguments.
+	Paths []string
+	// Err is the underlying error.
+	Err error
+
+	stack []byte
+}
+
+// Error returns a string representation of the instance. The stack is
+// included if ojg.ErrorWithStack is true.
+func (err *Error) Error() string {
+	b := []byte(err.Err.Error())
+	if 0 < len(err.Loc) {
+		b = append(b, " at "...)
+		b = append(b, err.Loc.String()...)
+	}
+	if 0 < len(err.Args) || 0 < len(err.Paths) {
+		b = append(b, " ("...)
+		if 0 < len(err.Args) {
+			b = append(b, "args: "...)
+			b = append(b, sen.String(err.Args, &sen.Options{Sort: true})...)
+			if 0 < len(err.Paths) {
+				b = append(b, ' ')
+			}
+		}
+		if 0 < len(err.Paths) {
+			b = append(b, "paths: "...)
+			b = append(b, sen.String(err.Paths)...)
+		}
+		b = append(b, ')')
+	}
+	if ojg.ErrorWithStack {
+		b = append(append(b, '\n'), err.stack...)
+	}
+	return string(b)
+}
+
+// Unwrap returns the underlying error.
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// Stack returns the stack at the time the error was created.
+func (err *Error) Stack() []byte {
+	return err.stack
+}
+
+// newError returns an *Error for the value recovered from a panic in the
+// evaluation of the function. The argument values are recorded when the
+// function fails. Functions are not evaluated again since that could
+// change the data.
+func (f *Fn) newError(r any, root map[string]any, at any, args []any) *Error {
+	if err, ok := r.(*Error); ok {
+		return err
+	}
+	e := Error{
+		Loc:   f.loc,
+		Name:  f.Name,
+		Args:  make([]any, len(args)),
+		Paths: argPaths(nil, args),
+		stack: debug.Stack(),
+	}
+	if err, ok := r.(error); ok {
+		e.Err = err
+	} else {
+		e.Err = fmt.Errorf("%v", r)
+	}
+	for i, a := range args {
+		e.Args[i] = f.argValue(i, a, root, at)
+	}
+	return &e
+}
+
+// argValue returns the value of an argument for an error. Paths are
+// replaced by the value they select and quoted values such as bound
+// variables by the value. Paths and functions that are not evaluated by
+// the function and other functions are returned in simplified form.
+func (f *Fn) argValue(i int, a any, root map[string]any, at any) any {
+	if k := f.argKind(i); k != AnyArg && k&^(PathArg|FnArg) == 0 {
+		return simplifyArg(a)
+	}
+	switch ta := a.(type) {
+	case jp.Expr:
+		return evalArg(root, at, ta)
+	case *Fn:
+		if ta.user == nil && ta.Name == "quote" && 0 < len(ta.Args) {
+			return ta.Args[0]
+		}
+	}
+	return simplifyArg(a)
+}
+
+// argPaths appends the unique data paths in the arguments to paths.
+func argPaths(paths []string, args []any) []string {
+	for _, a := range args {
+		switch ta := a.(type) {
+		case jp.Expr:
+			s := ta.String()
+			for _, p := range paths {
+				if p == s {
+					s = ""
+					break
+				}
+			}
+			if 0 < len(s) {
+				paths = append(paths, s)
+			}
+		case *Fn:
+			paths = argPaths(paths, ta.Args)
+		case []any:
+			paths = argPaths(paths, ta)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm_test
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ohler55/ojg"
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func executeError(t *testing.T, plan, root string) *asm.Error {
+	p := asm.NewPlan(sen.MustParse([]byte(plan)).([]any))
+	err := p.Execute(sen.MustParse([]byte(root)).(map[string]any))
+	tt.NotNil(t, err, "%s", plan)
+	var ae *asm.Error
+	tt.Equal(t, true, errors.As(err, &ae), "%s", plan)
+
+	return ae
+}
+
+func TestErrorLocation(t *testing.T) {
+	ae := executeError(t,
+		`[asm
+          [set $.asm.a 1]
+          [set $.asm.b 2]
+          [set $.asm.c [each $.src.x [set $.asm @.src]]]
+        ]`,
+		"{src: {x: abc}}",
+	)
+	tt.Equal(t, "[3][2]", ae.Loc.String())
+	tt.Equal(t, "each", ae.Name)
+	tt.Equal(t, "each expects an array argument, not a string", ae.Err.Error())
+	tt.Equal(t, `[abc [set $.asm @.src]]`, sen.String(ae.Args))
+	tt.Equal(t, []string{"$.src.x", "$.asm", "@.src"}, ae.Paths)
+	tt.Equal(t,
+		`each expects an array argument, not a string at [3][2] (args: [abc [set $.asm @.src]] paths: [$.src.x $.asm @.src])`,
+		ae.Error())
+
+	ae = executeError(t,
+		`[
+          [set $.asm [each [list 1 2] [set $.asm [tolower @.src]]]]
+        ]`,
+		"{src: {}}",
+	)
+	tt.Equal(t, "[0][2][2][2]", ae.Loc.String())
+	tt.Equal(t, "tolower", ae.Name)
+	tt.Equal(t, "[1]", sen.String(ae.Args))
+	tt.Equal(t, []string{"@.src"}, ae.Paths)
+
+	ae = executeError(t,
+		`[
+          [let {x: [tolower 3]} $x]
+        ]`,
+		"{}",
+	)
+	tt.Equal(t, "[0][1].x", ae.Loc.String())
+
+	ae = executeError(t,
+		`[
+          [cond [false 1] [true [int 1 2]]]
+        ]`,
+		"{}",
+	)
+	tt.Equal(t, "[0][2][1]", ae.Loc.String())
+}
+
+func TestErrorArgValues(t *testing.T) {
+	ae := executeError(t, "[[set $.asm [mod $.src.s [sum 1 2] [quote $.src.s]]]]", "{src: {s: hello}}")
+	tt.Equal(t, "[0][2]", ae.Loc.String())
+	tt.Equal(t, "mod", ae.Name)
+	tt.Equal(t, []any{"hello", []any{"sum", int64(1), int64(2)}, "$.src.s"}, ae.Args)
+	tt.Equal(t, []string{"$.src.s"}, ae.Paths)
+}
+
+func TestErrorVariables(t *testing.T) {
+	ae := executeError(t, "[[defn low [x] [tolower $x]] [low 3]]", "{}")
+	tt.Equal(t, "[0][3]", ae.Loc.String())
+	tt.Equal(t, "tolower", ae.Name)
+	tt.Equal(t, "[3]", sen.String(ae.Args))
+}
+
+func TestErrorConcurrent(t *testing.T) {
+	p := asm.NewPlan(sen.MustParse([]byte(`[[set $.asm [toupper $.src]] [set $.len [size $.asm]]]`)).([]any))
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			root := map[string]any{"src": "abc"}
+			if i%2 == 1 {
+				root["src"] = int64(i)
+			}
+			errs[i] = p.Execute(root)
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if i%2 == 0 {
+			tt.Nil(t, err, "%d", i)
+			continue
+		}
+		var ae *asm.Error
+		tt.Equal(t, true, errors.As(err, &ae), "%d", i)
+		tt.Equal(t, "[0][2]", ae.Loc.String(), "%d", i)
+		tt.Equal(t, "toupper", ae.Name, "%d", i)
+	}
+}
+
+func TestErrorNoSideEffects(t *testing.T) {
+	root := map[string]any{"src": map[string]any{"x": "abc"}}
+	p := asm.NewPlan([]any{
+		"asm",
+		[]any{"reverse", []any{"set", "$.count", []any{"sum", "$.count", 1}}},
+	})
+	root["count"] = int64(0)
+	err := p.Execute(root)
+	tt.NotNil(t, err)
+	var ae *asm.Error
+	tt.Equal(t, true, errors.As(err, &ae))
+	tt.Equal(t, "[1]", ae.Loc.String())
+	tt.Equal(t, int64(1), root["count"])
+}
+
+func TestErrorStack(t *testing.T) {
+	ae := executeError(t, "[[int]]", "{}")
+	tt.NotNil(t, ae.Stack())
+	tt.Equal(t, "int expects exactly one argument. 0 given at [0]", ae.Error())
+
+	ojg.ErrorWithStack = true
+	defer func() { ojg.ErrorWithStack = false }()
+	tt.Equal(t, true, strings.Contains(ae.Error(), "goroutine"))
+}
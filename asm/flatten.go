@@ -0,0 +1,54 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+import (
+	"fmt"
+)
+
+func init() {
+	Define(&Fn{
+		Name:    "flatten",
+		Eval:    flatten,
+		MinArgs: 1,
+		MaxArgs: 2,
+		Kinds:   []ArgKind{ArrayArg, IntArg},
+		Desc: `Returns an array with the elements of nested arrays moved up into
+the outer array. The optional second argument is the number of
+levels to flatten and defaults to 1. A negative depth flattens
+all levels.`,
+	})
+}
+
+func flatten(root map[string]any, at any, args ...any) any {
+	if len(args) < 1 || 2 < len(args) {
+		panic(fmt.Errorf("flatten expects one or two arguments. %d given", len(args)))
+	}
+	v := evalArg(root, at, args[0])
+	list, ok := v.([]any)
+	if !ok {
+		panic(fmt.Errorf("flatten expects an array argument, not a %T", v))
+	}
+	depth := int64(1)
+	if 1 < len(args) {
+		v = evalArg(root, at, args[1])
+		if depth, ok = asInt(v); !ok {
+			panic(fmt.Errorf("flatten expects an integer second argument, not a %T", v))
+		}
+	}
+	return flattenList(nil, list, depth)
+}
+
+func flattenList(flat []any, list []any, depth int64) []any {
+	if flat == nil {
+		flat = []any{}
+	}
+	for _, v := range list {
+		if sub, ok := v.([]any); ok && depth != 0 {
+			flat = flattenList(flat, sub, depth-1)
+		} else {
+			flat = append(flat, v)
+		}
+	}
+	return flat
+}
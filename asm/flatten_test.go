@@ -0,0 +1,48 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestFlatten(t *testing.T) {
+	root := testPlan(t,
+		`[
+           [set $.asm.a [flatten [1 [2 [3 [4]]] 5]]]
+           [set $.asm.b [flatten [1 [2 [3 [4]]] 5] 2]]
+           [set $.asm.c [flatten [1 [2 [3 [4]]] 5] -1]]
+           [set $.asm.d [flatten []]]
+         ]`,
+		"{src: []}",
+	)
+	tt.Equal(t, `{a:[1 2 [3 [4]]5] b:[1 2 3 [4]5] c:[1 2 3 4 5] d:[]}`, sen.String(root["asm"], &sopt))
+}
+
+func TestFlattenArgCount(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"flatten"},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
+
+func TestFlattenArgType(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"flatten", 1},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
+
+func TestFlattenDepthType(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"flatten", []any{"list"}, "x"},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
@@ -10,8 +10,10 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "float",
-		Eval: floatEval,
+		Name:    "float",
+		Eval:    floatEval,
+		MinArgs: 1,
+		MaxArgs: 1,
 		Desc: `Converts a value into a float if possible. I no conversion is
 possible nil is returned.`,
 	})
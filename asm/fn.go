@@ -14,12 +14,31 @@ var fnMap = map[string]Fn{}
 
 // Fn encapsulates the information about a formula function in the package.
 type Fn struct {
-	Name     string
-	Eval     func(root map[string]any, at any, args ...any) any
-	Args     []any
-	Desc     string
-	Compile  func(*Fn)
+	Name    string
+	Eval    func(root map[string]any, at any, args ...any) any
+	Args    []any
+	Desc    string
+	Compile func(*Fn)
+
+	// MinArgs and MaxArgs are the number of arguments allowed by
+	// Plan.Validate. If both are zero the number of arguments is not
+	// checked. A negative MaxArgs indicates there is no maximum.
+	MinArgs int
+	MaxArgs int
+	// Kinds are the kinds of literal arguments allowed by Plan.Validate
+	// in argument order. The last kind applies to any additional
+	// arguments.
+	Kinds []ArgKind
+	// CallbackArgs is the number of leading arguments a function argument
+	// defined with defn is called with, such as the element for each.
+	CallbackArgs int
+
 	compiled bool
+	unnamed  bool
+	loc      jp.Expr
+	tracer   *tracer
+	scope    *scope
+	user     *userFn
 }
 
 // Define a function for assembly use.
@@ -52,18 +71,21 @@ func (f *Fn) Simplify() any {
 	simple := make([]any, 0, len(f.Args)+1)
 	simple = append(simple, f.Name)
 	for _, a := range f.Args {
-		switch ta := a.(type) {
-		case alt.Simplifier:
-			simple = append(simple, ta.Simplify())
-		case fmt.Stringer:
-			simple = append(simple, ta.String())
-		default:
-			simple = append(simple, a)
-		}
+		simple = append(simple, simplifyArg(a))
 	}
 	return simple
 }
 
+func simplifyArg(a any) any {
+	switch ta := a.(type) {
+	case alt.Simplifier:
+		return ta.Simplify()
+	case fmt.Stringer:
+		return ta.String()
+	}
+	return a
+}
+
 // String return a string representation of the function.
 func (f *Fn) String() string {
 	return sen.String(f)
@@ -73,25 +95,52 @@ func (f *Fn) compile() {
 	if f.Compile != nil {
 		f.Compile(f)
 	} else {
+		sc := &scope{parent: f.scope}
 		for i, a := range f.Args {
-			if list, _ := a.([]any); 0 < len(list) {
-				if name, _ := list[0].(string); 0 < len(name) {
-					if af := NewFn(name); af != nil {
-						af.Args = list[1:]
-						af.compile()
-						f.Args[i] = af
-					}
-				}
-			} else if str, _ := a.(string); 0 < len(str) && (str[0] == '$' || str[0] == '@') {
-				if x, err := jp.Parse([]byte(str)); err == nil {
-					f.Args[i] = x
-				}
-			}
+			f.Args[i] = sc.compileArg(a, f.argLoc(i))
 		}
 	}
+	f.Eval = f.guard(f.Eval)
 	f.compiled = true
 }
 
+// argLoc returns the location in the plan of the argument at index i.
+func (f *Fn) argLoc(i int) jp.Expr {
+	if !f.unnamed {
+		i++
+	}
+	return append(f.loc[:len(f.loc):len(f.loc)], jp.Nth(i))
+}
+
+// argKind returns the kind of argument expected at index i.
+func (f *Fn) argKind(i int) ArgKind {
+	switch {
+	case len(f.Kinds) == 0:
+		return AnyArg
+	case i < len(f.Kinds):
+		return f.Kinds[i]
+	}
+	return f.Kinds[len(f.Kinds)-1]
+}
+
+// guard returns an evaluation function that converts a panic in eval to an
+// *Error that identifies the function. The *Error from the innermost
+// function that failed is passed on unchanged by the functions that called
+// it.
+func (f *Fn) guard(eval func(root map[string]any, at any, args ...any) any) func(root map[string]any, at any, args ...any) any {
+	return func(root map[string]any, at any, args ...any) any {
+		if tr := f.tracer; tr != nil && tr.fn != nil {
+			return tr.call(f, eval, root, at, args)
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				panic(f.newError(r, root, at, args))
+			}
+		}()
+		return eval(root, at, args...)
+	}
+}
+
 func evalArg(root map[string]any, at, arg any) (val any) {
 	switch ta := arg.(type) {
 	case *Fn:
@@ -105,7 +154,29 @@ func evalArg(root map[string]any, at, arg any) (val any) {
 			}
 		}
 	default:
-		val = arg
+		val = literal(arg)
 	}
 	return val
 }
+
+// literal returns a copy of an array or object literal in a plan so the
+// plan is not modified by changes to the data it assembles. Plans can then
+// be executed more than once and concurrently. Other values, including
+// those in arrays and objects, are returned as is.
+func literal(v any) any {
+	switch tv := v.(type) {
+	case []any:
+		dup := make([]any, len(tv))
+		for i, m := range tv {
+			dup[i] = literal(m)
+		}
+		return dup
+	case map[string]any:
+		dup := make(map[string]any, len(tv))
+		for k, m := range tv {
+			dup[k] = literal(m)
+		}
+		return dup
+	}
+	return v
+}
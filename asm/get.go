@@ -10,8 +10,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "get",
-		Eval: get,
+		Name:    "get",
+		Eval:    get,
+		MinArgs: 1,
+		MaxArgs: 2,
+		Kinds:   []ArgKind{PathArg | FnArg, AnyArg},
 		Desc: `Gets the first matching value in either the root ($), local (@),
 or if present, the second argument. The required first argument
 must be a path and the option second argument is the
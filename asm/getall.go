@@ -10,8 +10,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "getall",
-		Eval: getall,
+		Name:    "getall",
+		Eval:    getall,
+		MinArgs: 1,
+		MaxArgs: 2,
+		Kinds:   []ArgKind{PathArg | FnArg, AnyArg},
 		Desc: `Gets all matching values in either the root ($), or local (@),
 or if present, the second argument. The required first argument
 must be a path and the option second argument is the
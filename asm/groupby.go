@@ -0,0 +1,60 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+import (
+	"fmt"
+
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/sen"
+)
+
+func init() {
+	Define(&Fn{
+		Name:         "groupby",
+		Eval:         groupby,
+		MinArgs:      2,
+		MaxArgs:      2,
+		Kinds:        []ArgKind{ArrayArg | MapArg, PathArg | FnArg},
+		CallbackArgs: 1,
+		Desc: `Groups the elements of an array or the values of an object (map)
+by a key and returns an object of the keys and arrays of the
+elements with that key. The second argument is either a path
+that is applied to each element to get the key or a function
+that is evaluated with @.src set to the element. A function
+defined with defn is called with the element as the first
+argument. Keys that are not strings are converted to SEN
+strings.`,
+	})
+}
+
+func groupby(root map[string]any, at any, args ...any) any {
+	if len(args) != 2 {
+		panic(fmt.Errorf("groupby expects exactly two arguments. %d given", len(args)))
+	}
+	groups := map[string]any{}
+	for _, v := range members("groupby", evalArg(root, at, args[0])) {
+		var key string
+		switch tk := elementValue(root, "groupby", args[1], v).(type) {
+		case string:
+			key = tk
+		default:
+			key = sen.String(tk, &sen.Options{Sort: true})
+		}
+		list, _ := groups[key].([]any)
+		groups[key] = append(list, v)
+	}
+	return groups
+}
+
+// elementValue returns the value of a path applied to an element or the
+// result of calling a function with @.src set to the element.
+func elementValue(root map[string]any, name string, arg any, elem any) any {
+	switch ta := arg.(type) {
+	case jp.Expr:
+		return ta.First(elem)
+	case *Fn:
+		return callFn(root, map[string]any{"src": elem}, ta, elem)
+	}
+	panic(fmt.Errorf("%s expects a path or function argument, not a %T", name, arg))
+}
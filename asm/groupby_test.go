@@ -0,0 +1,54 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestGroupby(t *testing.T) {
+	root := testPlan(t,
+		`[
+           [defn parity [n] [cond [[eq [mod $n 2] 0] even] [true odd]]]
+           [set $.asm.a [groupby $.src.items @.kind]]
+           [set $.asm.b [groupby [1 2 3 4 5] [parity]]]
+           [set $.asm.c [groupby $.src.items [toupper @.src.kind]]]
+           [set $.asm.d [groupby {x: {n: 1} y: {n: 2} z: {n: 1}} @.n]]
+         ]`,
+		"{src: {items: [{kind: a v: 1}{kind: b v: 2}{kind: a v: 3}]}}",
+	)
+	tt.Equal(t,
+		`{a:{a:[{kind:a v:1}{kind:a v:3}] b:[{kind:b v:2}]} `+
+			`b:{even:[2 4] odd:[1 3 5]} `+
+			`c:{A:[{kind:a v:1}{kind:a v:3}] B:[{kind:b v:2}]} `+
+			`d:{"1":[{n:1}{n:1}] "2":[{n:2}]}}`,
+		sen.String(root["asm"], &sopt))
+}
+
+func TestGroupbyArgCount(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"groupby", []any{}},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
+
+func TestGroupbyArgType(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"groupby", 1, "@.x"},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
+
+func TestGroupbyKeyType(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"groupby", []any{"list", 1}, 2},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
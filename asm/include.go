@@ -9,8 +9,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "include",
-		Eval: include,
+		Name:    "include",
+		Eval:    include,
+		MinArgs: 2,
+		MaxArgs: 2,
+		Kinds:   []ArgKind{ArrayArg | StringArg, AnyArg},
 		Desc: `Returns true if a list first argument includes the second
 argument. It will also return true if the first argument is a
 string and the second string argument is included in the first.`,
@@ -10,8 +10,10 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "int",
-		Eval: intEval,
+		Name:    "int",
+		Eval:    intEval,
+		MinArgs: 1,
+		MaxArgs: 1,
 		Desc: `Converts a value into a integer if possible. I no conversion is
 possible nil is returned.`,
 	})
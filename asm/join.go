@@ -9,8 +9,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "join",
-		Eval: join,
+		Name:    "join",
+		Eval:    join,
+		MinArgs: 1,
+		MaxArgs: 2,
+		Kinds:   []ArgKind{ArrayArg, StringArg},
 		Desc: `Join an array of strings with the provided separator. If a
 separator is not provided as the second argument then an empty
 string is used.`,
@@ -0,0 +1,42 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+import (
+	"fmt"
+)
+
+func init() {
+	Define(&Fn{
+		Name:    "keys",
+		Eval:    keys,
+		MinArgs: 1,
+		MaxArgs: 1,
+		Kinds:   []ArgKind{ArrayArg | MapArg},
+		Desc: `Returns the sorted keys of an object (map). If the argument is
+an array the indexes of the array are returned.`,
+	})
+}
+
+func keys(root map[string]any, at any, args ...any) any {
+	if len(args) != 1 {
+		panic(fmt.Errorf("keys expects exactly one argument. %d given", len(args)))
+	}
+	switch v := evalArg(root, at, args[0]).(type) {
+	case []any:
+		list := make([]any, len(v))
+		for i := range v {
+			list[i] = int64(i)
+		}
+		return list
+	case map[string]any:
+		ks := sortedKeys(v)
+		list := make([]any, len(ks))
+		for i, k := range ks {
+			list[i] = k
+		}
+		return list
+	default:
+		panic(fmt.Errorf("keys expects an array or object argument, not a %T", v))
+	}
+}
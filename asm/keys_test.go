@@ -0,0 +1,38 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestKeys(t *testing.T) {
+	root := testPlan(t,
+		`[
+           [set $.asm.a [keys {b: 1 a: 2 c: 3}]]
+           [set $.asm.b [keys [x y]]]
+         ]`,
+		"{src: []}",
+	)
+	tt.Equal(t, `{a:[a b c] b:[0 1]}`, sen.String(root["asm"], &sopt))
+}
+
+func TestKeysArgCount(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"keys"},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
+
+func TestKeysArgType(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"keys", 1},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+import (
+	"fmt"
+
+	"github.com/ohler55/ojg/jp"
+)
+
+func init() {
+	Define(&Fn{
+		Name:    "let",
+		Eval:    letEval,
+		Compile: compileLet,
+		MinArgs: 2,
+		MaxArgs: -1,
+		Kinds:   []ArgKind{MapArg, AnyArg},
+		Desc: `Binds variables for use in the remaining arguments. The first
+argument must be a map of variable names to values. The values
+are evaluated before any are bound. The remaining arguments are
+evaluated in order with the variables referenced with a $ prefix
+such as $x or $x.a and the value of the last one is returned.`,
+	})
+}
+
+// letEval is replaced when the let is compiled.
+func letEval(root map[string]any, at any, args ...any) any {
+	return nil
+}
+
+func compileLet(f *Fn) {
+	if len(f.Args) < 2 {
+		panic(fmt.Errorf("let expects at least two arguments. %d given", len(f.Args)))
+	}
+	bindings, ok := f.Args[0].(map[string]any)
+	if !ok {
+		panic(fmt.Errorf("let expects a map of bindings as the first argument, not a %T", f.Args[0]))
+	}
+	vs := &scope{parent: f.scope}
+	compiled := make(map[string]any, len(bindings))
+	for k, v := range bindings {
+		compiled[k] = vs.compileArg(v, append(f.argLoc(0), jp.Child(k)))
+	}
+	f.Args[0] = compiled
+	bs := &scope{parent: f.scope}
+	decls := make(map[string]*varDecl, len(bindings))
+	for k := range bindings {
+		decls[k] = bs.declare(k)
+	}
+	for i, b := range f.Args[1:] {
+		f.Args[i+1] = bs.compileArg(b, f.argLoc(i+1))
+	}
+	f.Eval = func(root map[string]any, at any, args ...any) (result any) {
+		bound, _ := args[0].(map[string]any)
+		vars := make(map[*varDecl]any, len(decls))
+		for k, decl := range decls {
+			vars[decl] = evalArg(root, at, bound[k])
+		}
+		for _, b := range args[1:] {
+			result = evalArg(root, at, bind(b, vars, -1))
+		}
+		return
+	}
+}
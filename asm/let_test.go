@@ -0,0 +1,39 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestLet(t *testing.T) {
+	root := testPlan(t,
+		`[
+           [set $.asm [let {x: 3 p: $.src.person}
+             [let {x: [sum $x 1] y: $x}
+               [list $x $y $p.name]]]]
+         ]`,
+		"{src: {person: {name: Ann}}}",
+	)
+	tt.Equal(t, "[4 3 Ann]", sen.String(root["asm"], &sopt))
+}
+
+func TestLetInDefn(t *testing.T) {
+	root := testPlan(t,
+		`[
+           [defn area [w h] [let {a: [product $w $h]} [cond [[gt $a 10] big] [true $a]]]]
+           [set $.asm [list [area 2 3] [area 4 5]]]
+         ]`,
+		"{src: []}",
+	)
+	tt.Equal(t, "[6 big]", sen.String(root["asm"], &sopt))
+}
+
+func TestLetErrors(t *testing.T) {
+	tt.Panic(t, func() { _ = asm.NewPlan([]any{[]any{"let", map[string]any{}}}) })
+	tt.Panic(t, func() { _ = asm.NewPlan([]any{[]any{"let", 1, 2}}) })
+}
@@ -8,8 +8,10 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "map?",
-		Eval: mapEval,
+		Name:    "map?",
+		Eval:    mapEval,
+		MinArgs: 1,
+		MaxArgs: 1,
 		Desc: `Returns true if the single required argumement is a map
 otherwise false is returned.`,
 	})
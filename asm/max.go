@@ -0,0 +1,21 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+func init() {
+	Define(&Fn{
+		Name:    "max",
+		Eval:    maxEval,
+		MinArgs: 1,
+		MaxArgs: -1,
+		Desc: `Returns the largest of the arguments. If there is only one
+argument and it is an array or an object (map) then the largest
+element or value is returned. Values must all be numbers, all be
+strings, or all be times. Null values are ignored and null is
+returned if there are no values.`,
+	})
+}
+
+func maxEval(root map[string]any, at any, args ...any) any {
+	return extreme(root, at, "max", 1, args)
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestMax(t *testing.T) {
+	root := testPlan(t,
+		`[
+           [set $.asm.a [max [3 1.5 2 null]]]
+           [set $.asm.b [max 3 4 2]]
+           [set $.asm.c [max {x: b y: a z: c}]]
+           [set $.asm.d [max 3 3.5]]
+         ]`,
+		"{src: []}",
+	)
+	tt.Equal(t, `{a:3 b:4 c:c d:3.5}`, sen.String(root["asm"], &sopt))
+}
+
+func TestMaxArgCount(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"max"},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
+
+func TestMaxArgType(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"max", true, false},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
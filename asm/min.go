@@ -0,0 +1,110 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+import (
+	"fmt"
+	"time"
+)
+
+func init() {
+	Define(&Fn{
+		Name:    "min",
+		Eval:    minEval,
+		MinArgs: 1,
+		MaxArgs: -1,
+		Desc: `Returns the smallest of the arguments. If there is only one
+argument and it is an array or an object (map) then the smallest
+element or value is returned. Values must all be numbers, all be
+strings, or all be times. Null values are ignored and null is
+returned if there are no values.`,
+	})
+}
+
+func minEval(root map[string]any, at any, args ...any) any {
+	return extreme(root, at, "min", -1, args)
+}
+
+// extreme returns the argument or member value that compares with the
+// others with the sign provided.
+func extreme(root map[string]any, at any, name string, sign int, args []any) (result any) {
+	if len(args) < 1 {
+		panic(fmt.Errorf("%s expects at least one argument. %d given", name, len(args)))
+	}
+	for _, v := range aggregateValues(root, at, args) {
+		if v == nil {
+			continue
+		}
+		if result == nil || compareValues(name, v, result)*sign > 0 {
+			result = v
+		}
+	}
+	return
+}
+
+// aggregateValues returns the evaluated arguments or, if there is only one
+// argument that is an array or object, the members of that argument.
+func aggregateValues(root map[string]any, at any, args []any) []any {
+	vals := make([]any, len(args))
+	for i, a := range args {
+		vals[i] = evalArg(root, at, a)
+	}
+	if len(vals) == 1 {
+		switch vals[0].(type) {
+		case []any, map[string]any:
+			return members("", vals[0])
+		}
+	}
+	return vals
+}
+
+// compareValues returns -1, 0, or 1 if v0 is less than, equal to, or
+// greater than v1. Numbers, strings, and times can be compared.
+func compareValues(name string, v0, v1 any) int {
+	switch t0 := v0.(type) {
+	case string:
+		if t1, ok := v1.(string); ok {
+			switch {
+			case t0 < t1:
+				return -1
+			case t1 < t0:
+				return 1
+			}
+			return 0
+		}
+	case time.Time:
+		if t1, ok := v1.(time.Time); ok {
+			switch {
+			case t0.Before(t1):
+				return -1
+			case t1.Before(t0):
+				return 1
+			}
+			return 0
+		}
+	default:
+		if i0, ok := asInt(v0); ok {
+			if i1, ok := asInt(v1); ok {
+				switch {
+				case i0 < i1:
+					return -1
+				case i1 < i0:
+					return 1
+				}
+				return 0
+			}
+		}
+		if f0, ok := asFloat(v0); ok {
+			if f1, ok := asFloat(v1); ok {
+				switch {
+				case f0 < f1:
+					return -1
+				case f1 < f0:
+					return 1
+				}
+				return 0
+			}
+		}
+	}
+	panic(fmt.Errorf("%s can not compare a %T and a %T", name, v0, v1))
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestMin(t *testing.T) {
+	root := testPlan(t,
+		`[
+           [set $.asm.a [min [3 1.5 2 null]]]
+           [set $.asm.b [min 3 2 4]]
+           [set $.asm.c [min {x: b y: a z: c}]]
+           [set $.asm.d [min []]]
+           [set $.asm.e [min [list [time 2] [time 1]]]]
+         ]`,
+		"{src: []}",
+	)
+	tt.Equal(t, `{a:1.5 b:2 c:a d:null e:"1970-01-01T00:00:01Z"}`, sen.String(root["asm"], &sopt))
+}
+
+func TestMinArgCount(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"min"},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
+
+func TestMinMixed(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"min", 1, "a"},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
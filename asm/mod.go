@@ -8,8 +8,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "mod",
-		Eval: mod,
+		Name:    "mod",
+		Eval:    mod,
+		MinArgs: 2,
+		MaxArgs: 2,
+		Kinds:   []ArgKind{IntArg},
 		Desc: `Returns the remainer of a modulo operation on the first two
 argument. Both arguments must be integers and are both required.
 An error is raised if the wrong argument types are given.`,
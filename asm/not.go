@@ -8,8 +8,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "not",
-		Eval: not,
+		Name:    "not",
+		Eval:    not,
+		MinArgs: 1,
+		MaxArgs: 1,
+		Kinds:   []ArgKind{BoolArg},
 		Desc: `Returns the boolean NOT of the argument. Exactly one argument
 is expected and it must be a boolean.`,
 	})
@@ -8,8 +8,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "nth",
-		Eval: nth,
+		Name:    "nth",
+		Eval:    nth,
+		MinArgs: 2,
+		MaxArgs: 2,
+		Kinds:   []ArgKind{ArrayArg, IntArg},
 		Desc: `Returns a nth element of an array. The second argument must be
 an integer that indicates the element of the array to return.
 If the index is less than 0 then the index is from the end of
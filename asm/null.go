@@ -8,14 +8,18 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "null?",
-		Eval: null,
+		Name:    "null?",
+		Eval:    null,
+		MinArgs: 1,
+		MaxArgs: 1,
 		Desc: `Returns true if the single required argumement is null (JSON)
 or nil (golang) otherwise false is returned.`,
 	})
 	Define(&Fn{
-		Name: "nil?",
-		Eval: null,
+		Name:    "nil?",
+		Eval:    null,
+		MinArgs: 1,
+		MaxArgs: 1,
 		Desc: `Returns true if the single required argumement is null (JSON)
 or nil (golang) otherwise false is returned.`,
 	})
@@ -8,8 +8,10 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "num?",
-		Eval: num,
+		Name:    "num?",
+		Eval:    num,
+		MinArgs: 1,
+		MaxArgs: 1,
 		Desc: `Returns true if the single required argumement is number
 otherwise false is returned.`,
 	})
@@ -8,8 +8,9 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "or",
-		Eval: or,
+		Name:  "or",
+		Eval:  or,
+		Kinds: []ArgKind{BoolArg},
 		Desc: `Returns true if any of the argument evaluate to true. Any
 arguments that do not evaluate to a boolean or null (false)
 raise an error.`,
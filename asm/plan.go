@@ -10,8 +10,28 @@ import "github.com/ohler55/ojg"
 // usually an 'asm' function. The plan operates on a data map which is the
 // root during evaluation. The source data is in the $.src and the expected
 // assembled output should be in $.asm.
+//
+// A compiled plan is not modified when it is executed. Array and object
+// literals in the plan are copied when evaluated and all other state is
+// local to an execution so a single plan can be executed concurrently by
+// multiple goroutines as long as each has its own root. Functions must not
+// be defined with Define while plans are being compiled or executed.
 type Plan struct {
 	Fn
+
+	// Trace if not nil is called when each function in the plan is
+	// entered and when it exits. Executions of a plan with a Trace
+	// function are serialized. Trace must not be changed while the plan is
+	// being executed.
+	Trace func(ev *TraceEvent)
+
+	// MaxCallDepth is the maximum depth of nested calls to functions
+	// defined with defn. If zero DefaultMaxCallDepth is used. A plan that
+	// exceeds the depth fails instead of recursing without bound.
+	// MaxCallDepth must not be changed while the plan is being executed.
+	MaxCallDepth int
+
+	tracer *tracer
 }
 
 // NewPlan creates new place from a simplified (JSON) encoding of the
@@ -20,7 +40,10 @@ func NewPlan(plan []any) *Plan {
 	if len(plan) == 0 {
 		return nil
 	}
-	p := Plan{}
+	// The plan description is copied as it is compiled so the same
+	// description can be used to create more than one plan.
+	plan = append([]any{}, plan...)
+	p := Plan{tracer: &tracer{}}
 	if name, _ := plan[0].(string); 0 < len(name) {
 		if name == "asm" {
 			p.Fn = asmFn
@@ -32,17 +55,35 @@ func NewPlan(plan []any) *Plan {
 	if p.Eval == nil {
 		p.Fn = asmFn
 		p.Args = plan
+		p.unnamed = true
 	}
+	p.Fn.tracer = p.tracer
+	p.scope = &scope{trace: p.tracer, owner: &p}
 	p.compile()
 
 	return &p
 }
 
-// Execute a plan.
+// Execute a plan. If a function in the plan fails the error returned is an
+// *Error that identifies the function. Execute is safe to call from
+// multiple goroutines provided each call is given a different root.
 func (p *Plan) Execute(root map[string]any) (err error) {
+	if p.Trace != nil && p.tracer != nil {
+		p.tracer.mu.Lock()
+		p.tracer.fn = p.Trace
+		p.tracer.depth = 0
+		defer func() {
+			p.tracer.fn = nil
+			p.tracer.mu.Unlock()
+		}()
+	}
 	defer func() {
 		if r := recover(); r != nil {
-			err = ojg.NewError(r)
+			if ae, ok := r.(*Error); ok {
+				err = ae
+			} else {
+				err = ojg.NewError(r)
+			}
 		}
 	}()
 	p.Eval(root, root, p.Args...)
@@ -3,6 +3,8 @@
 package asm_test
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/ohler55/ojg/asm"
@@ -69,3 +71,71 @@ func TestPlanPanic(t *testing.T) {
 	err := p.Execute(root)
 	tt.NotNil(t, err)
 }
+
+func TestPlanReuse(t *testing.T) {
+	desc := sen.MustParse([]byte(`[
+      [set $.asm {a: 1 list: [1 2]}]
+      [set $.asm.b $.src]
+      [set "$.asm.list[1]" $.src]
+      [set $.asm.c [sort [3 1 2] "@"]]
+    ]`)).([]any)
+	p := asm.NewPlan(desc)
+	for _, src := range []int64{1, 2} {
+		root := map[string]any{"src": src}
+		tt.Nil(t, p.Execute(root))
+		tt.Equal(t, fmt.Sprintf("{a:1 b:%d c:[1 2 3] list:[1 %d]}", src, src), sen.String(root["asm"], &sopt))
+	}
+	// The description is not modified by compiling so it can be used for
+	// another plan.
+	tt.Equal(t, `[[set $.asm {a:1 list:[1 2]}][set $.asm.b $.src][set "$.asm.list[1]" $.src][set $.asm.c [sort [3 1 2]@]]]`,
+		sen.String(desc, &sopt))
+	p2 := asm.NewPlan(desc)
+	root := map[string]any{"src": 3}
+	tt.Nil(t, p2.Execute(root))
+	tt.Equal(t, "{a:1 b:3 c:[1 2 3] list:[1 3]}", sen.String(root["asm"], &sopt))
+}
+
+func TestPlanConcurrent(t *testing.T) {
+	p := asm.NewPlan(sen.MustParse([]byte(`[
+      [defn double [x] [sum $x $x]]
+      [defn bigness [x] [cond [[gt $x 2] big] [true small]]]
+      [set $.asm {tags: [x y]}]
+      [set $.asm.id $.src.id]
+      [set "$.asm.tags[1]" $.src.tag]
+      [let {n: $.src.id} [set $.asm.doubled [double $n]]]
+      [set $.asm.each [each $.src.items [set @.asm [product @.src $.src.id]]]]
+      [set $.asm.groups [groupby $.src.items [bigness]]]
+      [set $.asm.total [reduce $.src.items 0 [sum @.acc @.src]]]
+    ]`)).([]any))
+	tt.Nil(t, p.Validate())
+
+	var wg sync.WaitGroup
+	results := make([]string, 64)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				root := map[string]any{
+					"src": map[string]any{
+						"id":    int64(i),
+						"tag":   fmt.Sprintf("t%d", i),
+						"items": []any{int64(1), int64(2), int64(3)},
+					},
+				}
+				if err := p.Execute(root); err != nil {
+					results[i] = err.Error()
+					return
+				}
+				results[i] = sen.String(root["asm"], &sopt)
+			}
+		}(i)
+	}
+	wg.Wait()
+	for i, result := range results {
+		tt.Equal(t,
+			fmt.Sprintf("{doubled:%d each:[%d %d %d] groups:{big:[3] small:[1 2]} id:%d tags:[x t%d] total:6}",
+				i*2, i, i*2, i*3, i, i),
+			result)
+	}
+}
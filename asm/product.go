@@ -8,15 +8,17 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "product",
-		Eval: product,
+		Name:  "product",
+		Eval:  product,
+		Kinds: []ArgKind{NumArg},
 		Desc: `Returns the product of all arguments. All arguments must be
 numbers. If any of the arguments are not a number an error is
 raised.`,
 	})
 	Define(&Fn{
-		Name: "*",
-		Eval: product,
+		Name:  "*",
+		Eval:  product,
+		Kinds: []ArgKind{NumArg},
 		Desc: `Returns the product of all arguments. All arguments must be
 numbers. If any of the arguments are not a number an error is
 raised.`,
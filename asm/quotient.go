@@ -8,16 +8,18 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "quotient",
-		Eval: quotient,
+		Name:  "quotient",
+		Eval:  quotient,
+		Kinds: []ArgKind{NumArg},
 		Desc: `Returns the quotient of all arguments. All arguments must be
 numbers. If any of the arguments are not a number an error is
 raised. If an attempt is made to divide by zero and error will
 be raised.`,
 	})
 	Define(&Fn{
-		Name: "/",
-		Eval: quotient,
+		Name:  "/",
+		Eval:  quotient,
+		Kinds: []ArgKind{NumArg},
 		Desc: `Returns the quotient of all arguments. All arguments must be
 numbers. If any of the arguments are not a number an error is
 raised. If an attempt is made to divide by zero and error will
@@ -0,0 +1,42 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+import (
+	"fmt"
+)
+
+func init() {
+	Define(&Fn{
+		Name:         "reduce",
+		Eval:         reduce,
+		MinArgs:      3,
+		MaxArgs:      3,
+		Kinds:        []ArgKind{ArrayArg | MapArg, AnyArg, FnArg},
+		CallbackArgs: 2,
+		Desc: `Folds the elements of an array or the values of an object (map)
+into a single value. The second argument is the initial value of
+the accumulator. The third argument is a function that is
+evaluated for each element with @.acc set to the accumulator and
+@.src set to the element. The result becomes the new accumulator
+value. A function defined with defn is called with the
+accumulator and the element as the first two arguments. The
+final accumulator value is returned.`,
+	})
+}
+
+func reduce(root map[string]any, at any, args ...any) any {
+	if len(args) != 3 {
+		panic(fmt.Errorf("reduce expects exactly three arguments. %d given", len(args)))
+	}
+	list := members("reduce", evalArg(root, at, args[0]))
+	acc := evalArg(root, at, args[1])
+	fn, _ := args[2].(*Fn)
+	if fn == nil {
+		panic(fmt.Errorf("reduce expects a function third argument, not a %T", args[2]))
+	}
+	for _, v := range list {
+		acc = callFn(root, map[string]any{"acc": acc, "src": v}, fn, acc, v)
+	}
+	return acc
+}
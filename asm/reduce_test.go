@@ -0,0 +1,49 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestReduce(t *testing.T) {
+	root := testPlan(t,
+		`[
+           [defn add [acc x] [sum $acc $x]]
+           [set $.asm.a [reduce [1 2 3 4] 0 [sum @.acc @.src]]]
+           [set $.asm.b [reduce {x: 2 y: 3} 1 [product @.acc @.src]]]
+           [set $.asm.c [reduce [a b c] "" [add]]]
+           [set $.asm.d [reduce [] 7 [add]]]
+         ]`,
+		"{src: []}",
+	)
+	tt.Equal(t, `{a:10 b:6 c:abc d:7}`, sen.String(root["asm"], &sopt))
+}
+
+func TestReduceArgCount(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"reduce", []any{}, 0},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
+
+func TestReduceArgType(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"reduce", 1, 0, []any{"sum"}},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
+
+func TestReduceArgFn(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"reduce", []any{"list", 1}, 0, 1},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
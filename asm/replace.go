@@ -9,8 +9,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "replace",
-		Eval: replace,
+		Name:    "replace",
+		Eval:    replace,
+		MinArgs: 3,
+		MaxArgs: 3,
+		Kinds:   []ArgKind{StringArg},
 		Desc: `Replace an occurrences the second argument with the third
 argument. All three arguments must be strings.`,
 	})
@@ -8,9 +8,12 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "reverse",
-		Eval: reverse,
-		Desc: `Reverse the items in an array and return a copy of it.`,
+		Name:    "reverse",
+		Eval:    reverse,
+		MinArgs: 1,
+		MaxArgs: 1,
+		Kinds:   []ArgKind{ArrayArg},
+		Desc:    `Reverse the items in an array and return a copy of it.`,
 	})
 }
 
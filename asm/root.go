@@ -10,8 +10,9 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "root",
-		Eval: root,
+		Name:  "root",
+		Eval:  root,
+		Kinds: []ArgKind{StringArg},
 		Desc: `Forms a path starting with @. The remaining string arguments are
 joined with a '.' and parsed to form a jp.Expr.`,
 	})
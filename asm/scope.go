@@ -0,0 +1,252 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+import (
+	"fmt"
+
+	"github.com/ohler55/ojg/jp"
+)
+
+// DefaultMaxCallDepth is the maximum depth of nested calls to functions
+// defined with defn when the MaxCallDepth of a plan is not set.
+const DefaultMaxCallDepth = 100
+
+// scope is the lexical scope used when compiling a plan. Functions defined
+// with defn are visible in the scope they are defined in and all nested
+// scopes. Variables are not visible across a function boundary so a
+// function body can only refer to its own parameters and the variables
+// bound within the body.
+type scope struct {
+	parent   *scope
+	fns      map[string]*userFn
+	vars     map[string]*varDecl
+	boundary bool
+	trace    *tracer
+	owner    *Plan
+}
+
+// varDecl is a variable declared as a function parameter or in a let. The
+// pointer identifies the variable so inner variables with the same name
+// shadow outer variables.
+type varDecl struct {
+	name string
+}
+
+// varRef is a reference to a variable such as $x or $x.a.b in a compiled
+// plan. A reference is replaced by the value of the variable before it is
+// evaluated.
+type varRef struct {
+	decl *varDecl
+	path jp.Expr
+}
+
+// userFn is a function defined in a plan with defn.
+type userFn struct {
+	name   string
+	params []*varDecl
+	body   []any
+	plan   *Plan
+}
+
+// String returns the variable reference as it appears in a plan.
+func (vr *varRef) String() string {
+	if 1 < len(vr.path) {
+		return "$" + vr.decl.name + vr.path[1:].String()
+	}
+	return "$" + vr.decl.name
+}
+
+// compileArg compiles an argument at the location provided in the plan.
+func (sc *scope) compileArg(a any, loc jp.Expr) any {
+	switch ta := a.(type) {
+	case []any:
+		if 0 < len(ta) {
+			if name, _ := ta[0].(string); 0 < len(name) {
+				if af := sc.newFn(name); af != nil {
+					af.Args = append([]any{}, ta[1:]...)
+					af.loc = loc
+					af.compile()
+					return af
+				}
+			}
+		}
+	case string:
+		if 0 < len(ta) && (ta[0] == '$' || ta[0] == '@') {
+			if vr := sc.varRef(ta); vr != nil {
+				return vr
+			}
+			if x, err := jp.Parse([]byte(ta)); err == nil {
+				return x
+			}
+		}
+	}
+	return a
+}
+
+func (sc *scope) newFn(name string) (af *Fn) {
+	for s := sc; s != nil && af == nil; s = s.parent {
+		if uf := s.fns[name]; uf != nil {
+			af = &Fn{Name: name, Eval: uf.eval(0), user: uf, scope: sc, Compile: compileUserCall}
+		}
+	}
+	if af == nil {
+		if af = NewFn(name); af == nil {
+			return nil
+		}
+		af.scope = sc
+	}
+	af.tracer = sc.tracer()
+	return
+}
+
+// tracer returns the tracer of the plan the scope is in.
+func (sc *scope) tracer() *tracer {
+	for s := sc; s != nil; s = s.parent {
+		if s.trace != nil {
+			return s.trace
+		}
+	}
+	return nil
+}
+
+// plan returns the plan the scope is in or nil if not in a plan.
+func (sc *scope) plan() *Plan {
+	for s := sc; s != nil; s = s.parent {
+		if s.owner != nil {
+			return s.owner
+		}
+	}
+	return nil
+}
+
+// varRef returns a reference if str is a $ followed by the name of a
+// variable in scope and optionally a path into the variable value.
+func (sc *scope) varRef(str string) *varRef {
+	if len(str) < 2 || str[0] != '$' {
+		return nil
+	}
+	end := 1
+	for ; end < len(str) && str[end] != '.' && str[end] != '['; end++ {
+	}
+	name := str[1:end]
+	for s := sc; s != nil; s = s.parent {
+		if decl := s.vars[name]; decl != nil {
+			vr := varRef{decl: decl}
+			if end < len(str) {
+				x, err := jp.ParseString("@" + str[end:])
+				if err != nil {
+					panic(err)
+				}
+				vr.path = x
+			}
+			return &vr
+		}
+		if s.boundary {
+			break
+		}
+	}
+	return nil
+}
+
+func (sc *scope) declare(name string) *varDecl {
+	if sc.vars == nil {
+		sc.vars = map[string]*varDecl{}
+	}
+	decl := &varDecl{name: name}
+	sc.vars[name] = decl
+
+	return decl
+}
+
+func compileUserCall(f *Fn) {
+	sc := &scope{parent: f.scope}
+	for i, a := range f.Args {
+		f.Args[i] = sc.compileArg(a, f.argLoc(i))
+	}
+}
+
+// eval returns an evaluation function for a call to the function at the
+// provided depth.
+func (uf *userFn) eval(depth int) func(root map[string]any, at any, args ...any) any {
+	return func(root map[string]any, at any, args ...any) (result any) {
+		if limit := uf.maxCallDepth(); limit <= depth {
+			panic(fmt.Errorf("%s exceeded the maximum call depth of %d", uf.name, limit))
+		}
+		if len(args) != len(uf.params) {
+			panic(fmt.Errorf("%s expects %d arguments. %d given", uf.name, len(uf.params), len(args)))
+		}
+		vars := make(map[*varDecl]any, len(args))
+		for i, a := range args {
+			vars[uf.params[i]] = evalArg(root, at, a)
+		}
+		for _, b := range uf.body {
+			result = evalArg(root, at, bind(b, vars, depth+1))
+		}
+		return
+	}
+}
+
+// maxCallDepth returns the call depth limit of the plan the function is
+// defined in.
+func (uf *userFn) maxCallDepth() int {
+	if uf.plan != nil && 0 < uf.plan.MaxCallDepth {
+		return uf.plan.MaxCallDepth
+	}
+	return DefaultMaxCallDepth
+}
+
+// boundValue returns the value bound to a variable without copying it.
+func boundValue(root map[string]any, at any, args ...any) any {
+	return args[0]
+}
+
+// bind returns a copy of a compiled value with variable references replaced
+// by the variable values. Calls to functions defined with defn are set to
+// the depth provided unless depth is less than zero.
+func bind(v any, vars map[*varDecl]any, depth int) any {
+	switch tv := v.(type) {
+	case *varRef:
+		if val, has := vars[tv.decl]; has {
+			if tv.path != nil {
+				val = tv.path.First(val)
+			}
+			// Values that could be mistaken for a path or a function call
+			// are quoted as are arrays and objects so they are not copied
+			// like literals in the plan. Others are used as is since some
+			// functions do not evaluate all their arguments.
+			switch tval := val.(type) {
+			case string:
+				if 0 < len(tval) && (tval[0] == '$' || tval[0] == '@') {
+					return &Fn{Name: "quote", Eval: boundValue, Args: []any{val}, compiled: true}
+				}
+			case []any, map[string]any, *Fn, jp.Expr:
+				return &Fn{Name: "quote", Eval: boundValue, Args: []any{val}, compiled: true}
+			}
+			return val
+		}
+	case *Fn:
+		nf := *tv
+		if nf.user != nil && 0 <= depth {
+			nf.Eval = nf.guard(nf.user.eval(depth))
+		}
+		nf.Args = make([]any, len(tv.Args))
+		for i, a := range tv.Args {
+			nf.Args[i] = bind(a, vars, depth)
+		}
+		return &nf
+	case []any:
+		list := make([]any, len(tv))
+		for i, a := range tv {
+			list[i] = bind(a, vars, depth)
+		}
+		return list
+	case map[string]any:
+		obj := make(map[string]any, len(tv))
+		for k, a := range tv {
+			obj[k] = bind(a, vars, depth)
+		}
+		return obj
+	}
+	return v
+}
@@ -10,8 +10,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "set",
-		Eval: set,
+		Name:    "set",
+		Eval:    set,
+		MinArgs: 2,
+		MaxArgs: 2,
+		Kinds:   []ArgKind{PathArg | FnArg, AnyArg},
 		Desc: `Sets a single value in either the root ($) or local (@) data. Two
 arguments are required, the first must be a path and the second
 argument is evaluate to a value and inserted using the
@@ -38,7 +38,7 @@ func TestSetFn(t *testing.T) {
 func TestSetExprError(t *testing.T) {
 	p := asm.NewPlan([]any{
 		map[string]any{}, // Sets @
-		[]any{"set", jp.D(), 1},
+		[]any{"set", jp.R(), 1},
 		[]any{"set", "$.asm", "@"},
 	})
 	err := p.Execute(map[string]any{})
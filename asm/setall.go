@@ -10,8 +10,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "setall",
-		Eval: setall,
+		Name:    "setall",
+		Eval:    setall,
+		MinArgs: 2,
+		MaxArgs: 2,
+		Kinds:   []ArgKind{PathArg | FnArg, AnyArg},
 		Desc: `Sets multiple values in either the root ($) or local (@) data.
 Two arguments are required, the first must be a path and the
 second argument is evaluate to a value and inserted using the
@@ -26,7 +26,7 @@ func TestSetall(t *testing.T) {
 func TestSetallExprError(t *testing.T) {
 	p := asm.NewPlan([]any{
 		map[string]any{}, // Sets @
-		[]any{"setall", jp.D(), 1},
+		[]any{"setall", jp.R(), 1},
 		[]any{"setall", "$.asm", "@"},
 	})
 	err := p.Execute(map[string]any{})
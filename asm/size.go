@@ -8,8 +8,10 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "size",
-		Eval: size,
+		Name:    "size",
+		Eval:    size,
+		MinArgs: 1,
+		MaxArgs: 1,
 		Desc: `Returns the size or length of a string, array, or object (map).
 For all other types zero is returned`,
 	})
@@ -12,8 +12,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "sort",
-		Eval: sortEval,
+		Name:    "sort",
+		Eval:    sortEval,
+		MinArgs: 2,
+		MaxArgs: 2,
+		Kinds:   []ArgKind{ArrayArg, PathArg},
 		Desc: `Sort the items in an array and return a copy of the array. Valid
 types for comparison are strings, numbers, and times. Any other
 type returned or a type mismatch will raise an error.`,
@@ -9,9 +9,12 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "split",
-		Eval: split,
-		Desc: `Split a string on using a specified separator.`,
+		Name:    "split",
+		Eval:    split,
+		MinArgs: 2,
+		MaxArgs: 2,
+		Kinds:   []ArgKind{StringArg},
+		Desc:    `Split a string on using a specified separator.`,
 	})
 }
 
@@ -11,15 +11,20 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "string?",
-		Eval: stringCheck,
+		Name:    "string?",
+		Eval:    stringCheck,
+		MinArgs: 1,
+		MaxArgs: 1,
 		Desc: `Returns true if the single required argumement is a string
 otherwise false is returned.`,
 	})
 	Define(&Fn{
-		Name: "string",
-		Eval: stringConv,
-		Desc: `Converts a value into a string.`,
+		Name:    "string",
+		Eval:    stringConv,
+		MinArgs: 1,
+		MaxArgs: 2,
+		Kinds:   []ArgKind{AnyArg, StringArg},
+		Desc:    `Converts a value into a string.`,
 	})
 }
 
@@ -8,8 +8,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "substr",
-		Eval: substr,
+		Name:    "substr",
+		Eval:    substr,
+		MinArgs: 2,
+		MaxArgs: 3,
+		Kinds:   []ArgKind{StringArg, IntArg},
 		Desc: `Returns a substring of the input string. The second argument
 must be an integer that marks the start of the substring. The
 third integer argument indicates the length of the substring
@@ -8,16 +8,18 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "sum",
-		Eval: sum,
+		Name:  "sum",
+		Eval:  sum,
+		Kinds: []ArgKind{NumArg | StringArg},
 		Desc: `Returns the sum of all arguments. All arguments must be numbers
 or strings. If any argument is a string then the result will be
 a string otherwise the result will be a number. If any of the
 arguments are not a number or a string an error is raised.`,
 	})
 	Define(&Fn{
-		Name: "+",
-		Eval: sum,
+		Name:  "+",
+		Eval:  sum,
+		Kinds: []ArgKind{NumArg | StringArg},
 		Desc: `Returns the sum of all arguments. All arguments must be numbers
 or strings. If any argument is a string then the result will be
 a string otherwise the result will be a number. If any of the
@@ -9,14 +9,19 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "time?",
-		Eval: timeCheck,
+		Name:    "time?",
+		Eval:    timeCheck,
+		MinArgs: 1,
+		MaxArgs: 1,
 		Desc: `Returns true if the single required argumement is a time
 otherwise false is returned.`,
 	})
 	Define(&Fn{
-		Name: "time",
-		Eval: timeConv,
+		Name:    "time",
+		Eval:    timeConv,
+		MinArgs: 1,
+		MaxArgs: 2,
+		Kinds:   []ArgKind{NumArg | StringArg, StringArg},
 		Desc: `Converts the first argument to a time if possible otherwise
 an error is raised. The first argument can be a integer, float,
 or string and are converted as follows:
@@ -9,8 +9,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "title",
-		Eval: title,
+		Name:    "title",
+		Eval:    title,
+		MinArgs: 1,
+		MaxArgs: 1,
+		Kinds:   []ArgKind{StringArg},
 		Desc: `Convert a string to capitalized string. There must be exactly
 one string argument.`,
 	})
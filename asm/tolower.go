@@ -9,8 +9,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "tolower",
-		Eval: tolower,
+		Name:    "tolower",
+		Eval:    tolower,
+		MinArgs: 1,
+		MaxArgs: 1,
+		Kinds:   []ArgKind{StringArg},
 		Desc: `Convert a string to lowercase. There must be exactly one
 string argument.`,
 	})
@@ -9,8 +9,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "toupper",
-		Eval: toupper,
+		Name:    "toupper",
+		Eval:    toupper,
+		MinArgs: 1,
+		MaxArgs: 1,
+		Kinds:   []ArgKind{StringArg},
 		Desc: `Convert a string to uppercase. There must be exactly one
 string argument.`,
 	})
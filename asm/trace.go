@@ -0,0 +1,113 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ohler55/ojg"
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/oj"
+	"github.com/ohler55/ojg/sen"
+)
+
+// TraceEvent describes the entry to or the exit from a function in a plan.
+// Trace events are delivered to the Trace function of a Plan.
+type TraceEvent struct {
+	// Loc is the location of the function in the plan.
+	Loc jp.Expr
+	// Name of the function.
+	Name string
+	// Depth is the call depth starting with zero for the plan function.
+	Depth int
+	// Exit is false when the function is entered and true when it exits.
+	Exit bool
+	// Args are the simplified arguments on entry with variables replaced
+	// by their values. Args are nil on exit.
+	Args []any
+	// Result is the value returned by the function. Result is nil on
+	// entry.
+	Result any
+	// Elapsed is the time taken by the function. It is zero on entry.
+	Elapsed time.Duration
+	// Err is the error raised by the function if it failed.
+	Err error
+}
+
+// Simplify the event into a map that can be encoded as JSON or SEN. The
+// elapsed time is in nanoseconds.
+func (ev *TraceEvent) Simplify() any {
+	simple := map[string]any{
+		"loc":   ev.Loc.String(),
+		"fn":    ev.Name,
+		"depth": int64(ev.Depth),
+	}
+	if ev.Exit {
+		simple["event"] = "exit"
+		simple["result"] = simplifyArg(ev.Result)
+		simple["elapsed"] = int64(ev.Elapsed)
+		if ev.Err != nil {
+			simple["error"] = ev.Err.Error()
+		}
+	} else {
+		simple["event"] = "enter"
+		simple["args"] = ev.Args
+	}
+	return simple
+}
+
+// TraceWriter returns a trace function for a Plan that writes each event
+// to w on a single line indented by the depth of the event so the lines
+// form a call tree. Events are written as SEN if asSEN is true and as JSON
+// otherwise. If opts is nil keys are sorted. The indent option is ignored.
+func TraceWriter(w io.Writer, asSEN bool, opts *ojg.Options) func(ev *TraceEvent) {
+	o := ojg.Options{Sort: true}
+	if opts != nil {
+		o = *opts
+	}
+	o.Indent = 0
+	return func(ev *TraceEvent) {
+		b := []byte(strings.Repeat("  ", ev.Depth))
+		if asSEN {
+			b = append(b, sen.String(ev.Simplify(), &o)...)
+		} else {
+			b = append(b, oj.JSON(ev.Simplify(), &o)...)
+		}
+		b = append(b, '\n')
+		_, _ = w.Write(b)
+	}
+}
+
+// tracer holds the trace state of a plan. Only one traced execution of a
+// plan is active at a time so the depth is that of the active execution.
+type tracer struct {
+	mu    sync.Mutex
+	fn    func(ev *TraceEvent)
+	depth int
+}
+
+// call evaluates a function while delivering entry and exit events.
+func (tr *tracer) call(f *Fn, eval func(root map[string]any, at any, args ...any) any, root map[string]any, at any, args []any) (result any) {
+	ev := TraceEvent{Loc: f.loc, Name: f.Name, Depth: tr.depth, Args: make([]any, len(args))}
+	for i, a := range args {
+		ev.Args[i] = simplifyArg(a)
+	}
+	tr.fn(&ev)
+	tr.depth++
+	start := time.Now()
+	defer func() {
+		tr.depth--
+		exit := TraceEvent{Loc: f.loc, Name: f.Name, Depth: tr.depth, Exit: true, Result: result, Elapsed: time.Since(start)}
+		if r := recover(); r != nil {
+			err := f.newError(r, root, at, args)
+			exit.Err = err
+			tr.fn(&exit)
+			panic(err)
+		}
+		tr.fn(&exit)
+	}()
+	return eval(root, at, args...)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestPlanTrace(t *testing.T) {
+	p := asm.NewPlan(sen.MustParse([]byte(`[
+      [defn double [x] [sum $x $x]]
+      [set $.asm [double $.src.n]]
+    ]`)).([]any))
+	var lines []string
+	p.Trace = func(ev *asm.TraceEvent) {
+		ev.Elapsed = 0
+		lines = append(lines, strings.Repeat(" ", ev.Depth)+sen.String(ev, &sopt))
+	}
+	root := map[string]any{"src": map[string]any{"n": 3}}
+	tt.Nil(t, p.Execute(root))
+	tt.Equal(t, 6, root["asm"])
+	tt.Equal(t, `{args:[[defn double [x][sum $x $x]][set $.asm [double $.src.n]]] depth:0 event:enter fn:asm loc:""}
+ {args:[double [x][sum $x $x]] depth:1 event:enter fn:defn loc:"[0]"}
+ {depth:1 elapsed:0 event:exit fn:defn loc:"[0]" result:{src:{n:3}}}
+ {args:[$.asm [double $.src.n]] depth:1 event:enter fn:set loc:"[1]"}
+  {args:[$.src.n] depth:2 event:enter fn:double loc:"[1][2]"}
+   {args:[3 3] depth:3 event:enter fn:sum loc:"[0][3]"}
+   {depth:3 elapsed:0 event:exit fn:sum loc:"[0][3]" result:6}
+  {depth:2 elapsed:0 event:exit fn:double loc:"[1][2]" result:6}
+ {depth:1 elapsed:0 event:exit fn:set loc:"[1]" result:{asm:6 src:{n:3}}}
+{depth:0 elapsed:0 event:exit fn:asm loc:"" result:{asm:6 src:{n:3}}}`, strings.Join(lines, "\n"))
+}
+
+func TestPlanTraceError(t *testing.T) {
+	p := asm.NewPlan([]any{[]any{"nth", []any{"list", "$.src"}, "x"}})
+	var b strings.Builder
+	p.Trace = asm.TraceWriter(&b, false, nil)
+	err := p.Execute(map[string]any{"src": 1})
+	tt.NotNil(t, err)
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	tt.Equal(t, 6, len(lines))
+	tt.Equal(t, `    {"depth":2,"elapsed":`, lines[3][:len(`    {"depth":2,"elapsed":`)])
+	tt.Equal(t, true, strings.Contains(lines[4], `"error":"nth expects an integer second argument, not a string at [0]`))
+
+	b.Reset()
+	p = asm.NewPlan([]any{[]any{"list", 1}})
+	p.Trace = asm.TraceWriter(&b, true, nil)
+	tt.Nil(t, p.Execute(map[string]any{}))
+	tt.Equal(t, `{args:[[list 1]] depth:0 event:enter fn:asm loc:""}
+  {args:[1] depth:1 event:enter fn:list loc:"[0]"}
+`, b.String()[:strings.Index(b.String(), "  {depth")])
+}
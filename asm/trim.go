@@ -9,8 +9,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "trim",
-		Eval: trim,
+		Name:    "trim",
+		Eval:    trim,
+		MinArgs: 1,
+		MaxArgs: 2,
+		Kinds:   []ArgKind{StringArg},
 		Desc: `Trim white space from both ends of a string unless a second
 argument provides an alternative cut set.`,
 	})
@@ -0,0 +1,192 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ohler55/ojg/jp"
+)
+
+// ArgKind identifies the kinds of values allowed for a function argument.
+// Kinds can be combined such as StringArg | IntArg.
+type ArgKind uint16
+
+const (
+	// AnyArg allows any value.
+	AnyArg ArgKind = 0
+	// NullArg allows a nil value.
+	NullArg ArgKind = 1 << iota
+	// BoolArg allows a boolean value.
+	BoolArg
+	// IntArg allows an integer value.
+	IntArg
+	// FloatArg allows a float value.
+	FloatArg
+	// StringArg allows a string value.
+	StringArg
+	// TimeArg allows a time.Time value.
+	TimeArg
+	// ArrayArg allows an array value.
+	ArrayArg
+	// MapArg allows a map value.
+	MapArg
+	// PathArg allows a path that is not evaluated.
+	PathArg
+	// FnArg allows a function that may be called by the function it is an
+	// argument of, such as each, instead of being evaluated first.
+	FnArg
+
+	// NumArg allows an integer or float value.
+	NumArg = IntArg | FloatArg
+)
+
+var argKindNames = []struct {
+	kind ArgKind
+	name string
+}{
+	{kind: NullArg, name: "null"},
+	{kind: BoolArg, name: "boolean"},
+	{kind: IntArg, name: "integer"},
+	{kind: FloatArg, name: "float"},
+	{kind: StringArg, name: "string"},
+	{kind: TimeArg, name: "time"},
+	{kind: ArrayArg, name: "array"},
+	{kind: MapArg, name: "map"},
+	{kind: PathArg, name: "path"},
+	{kind: FnArg, name: "function"},
+}
+
+// String returns the names of the kinds separated by " or ".
+func (k ArgKind) String() string {
+	if k == AnyArg {
+		return "any"
+	}
+	var names []string
+	for _, kn := range argKindNames {
+		if k&kn.kind != 0 {
+			names = append(names, kn.name)
+		}
+	}
+	return strings.Join(names, " or ")
+}
+
+// allows returns true if the argument is allowed. Paths, functions, and
+// variables that are evaluated are allowed for any kind of value since the
+// value is not known until the plan is executed.
+func (k ArgKind) allows(arg any) bool {
+	if k == AnyArg {
+		return true
+	}
+	var ak ArgKind
+	switch arg.(type) {
+	case *varRef:
+		return true
+	case *Fn:
+		return k != PathArg
+	case jp.Expr:
+		return k != FnArg
+	case nil:
+		ak = NullArg
+	case bool:
+		ak = BoolArg
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		ak = IntArg
+	case float32, float64:
+		ak = FloatArg
+	case string:
+		ak = StringArg
+	case time.Time:
+		ak = TimeArg
+	case []any:
+		ak = ArrayArg
+	case map[string]any:
+		ak = MapArg
+	}
+	return k&ak != 0
+}
+
+// Validate checks the number of arguments and the kinds of literal
+// arguments of all the functions in the plan. The first problem found is
+// returned as an *Error.
+func (p *Plan) Validate() error {
+	return p.Fn.validate(0)
+}
+
+// validate checks the function and then the functions in the arguments.
+// The leading value is the number of arguments a function defined with
+// defn is called with ahead of those given in the plan when it is called
+// by another function such as each.
+func (f *Fn) validate(leading int) error {
+	given := len(f.Args)
+	if f.user != nil {
+		given += leading
+		if given != len(f.user.params) {
+			return f.validateError(fmt.Errorf("%s expects %d arguments. %d given", f.Name, len(f.user.params), given))
+		}
+	} else if f.MinArgs != 0 || f.MaxArgs != 0 {
+		if given < f.MinArgs || (0 <= f.MaxArgs && f.MaxArgs < given) {
+			return f.validateError(fmt.Errorf("%s expects %s. %d given", f.Name, f.arity(), given))
+		}
+	}
+	for i, a := range f.Args {
+		k := f.argKind(i)
+		if !k.allows(a) {
+			return f.validateError(fmt.Errorf("%s expects a %s argument %d, not a %T", f.Name, k, i+1, a))
+		}
+		leading := 0
+		if k&FnArg != 0 {
+			leading = f.CallbackArgs
+		}
+		if err := validateArg(a, leading); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateArg(a any, leading int) error {
+	switch ta := a.(type) {
+	case *Fn:
+		return ta.validate(leading)
+	case []any:
+		for _, v := range ta {
+			if err := validateArg(v, 0); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		for _, v := range ta {
+			if err := validateArg(v, 0); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (f *Fn) arity() string {
+	switch {
+	case f.MaxArgs < 0:
+		return fmt.Sprintf("at least %d arguments", f.MinArgs)
+	case f.MinArgs == f.MaxArgs:
+		return fmt.Sprintf("exactly %d arguments", f.MinArgs)
+	}
+	return fmt.Sprintf("%d to %d arguments", f.MinArgs, f.MaxArgs)
+}
+
+func (f *Fn) validateError(err error) *Error {
+	args := make([]any, len(f.Args))
+	for i, a := range f.Args {
+		args[i] = simplifyArg(a)
+	}
+	return &Error{
+		Loc:   f.loc,
+		Name:  f.Name,
+		Args:  args,
+		Paths: argPaths(nil, f.Args),
+		Err:   err,
+	}
+}
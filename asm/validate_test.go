@@ -0,0 +1,110 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestPlanValidate(t *testing.T) {
+	for _, d := range []struct {
+		plan string
+		loc  string
+		err  string
+	}{
+		{plan: `[[set $.asm [sum 1 2]]]`},
+		{plan: `[[set $.asm [each $.src [set $.asm [toupper @.src]]]]]`},
+		{plan: `[[defn inc [x] [sum $x 1]] [set $.asm [each [list 1 2] [inc]]]]`},
+		{plan: `[[let {x: 1} [set $.asm [sum $x 2]]]]`},
+		{plan: `[[set $.asm [get [at x]]]]`},
+		{plan: `[[defn add [acc x] [sum $acc $x]] [set $.asm [reduce [1 2] 0 [add]]]]`},
+		{plan: `[[defn big [x] [gt $x 2]] [set $.asm [count [1 2 3] [big]]]]`},
+		{
+			plan: `[[set $.asm [groupby [1 2] 3]]]`,
+			loc:  "[0][2]",
+			err:  "groupby expects a path or function argument 2, not a int64",
+		},
+		{
+			plan: `[asm [set $.asm [substr abc]]]`,
+			loc:  "[1][2]",
+			err:  "substr expects 2 to 3 arguments. 1 given",
+		},
+		{
+			plan: `[[set $.asm [list [tolower 1]]]]`,
+			loc:  "[0][2][1]",
+			err:  "tolower expects a string argument 1, not a int64",
+		},
+		{
+			plan: `[[del [at x]]]`,
+			loc:  "[0]",
+			err:  "del expects a path argument 1, not a *asm.Fn",
+		},
+		{
+			plan: `[[each $.src $.x]]`,
+			loc:  "[0]",
+			err:  "each expects a function argument 2, not a jp.Expr",
+		},
+		{
+			plan: `[[zone $.t true]]`,
+			loc:  "[0]",
+			err:  "zone expects a integer or string argument 2, not a bool",
+		},
+		{
+			plan: `[[defn inc [x] [sum $x 1]] [inc 1 2]]`,
+			loc:  "[1]",
+			err:  "inc expects 1 arguments. 2 given",
+		},
+		{
+			plan: `[[defn dbl [x] [sum $x $x]] [set $.asm [each $.src [dbl @]]]]`,
+			loc:  "[1][2][2]",
+			err:  "dbl expects 1 arguments. 2 given",
+		},
+		{
+			plan: `[[defn two [x y] [sum $x $y]] [set $.asm [each $.src [two]]]]`,
+			loc:  "[1][2][2]",
+			err:  "two expects 2 arguments. 1 given",
+		},
+		{
+			plan: `[[defn add [x] [sum $x 1]] [set $.asm [reduce [1 2] 0 [add]]]]`,
+			loc:  "[1][2][3]",
+			err:  "add expects 1 arguments. 2 given",
+		},
+		{
+			plan: `[[defn key [x y] [sum $x $y]] [set $.asm [groupby [1 2] [key 1 2]]]]`,
+			loc:  "[1][2][2]",
+			err:  "key expects 2 arguments. 3 given",
+		},
+		{
+			plan: `[[defn inc [x] [sum $x [mod 1]]]]`,
+			loc:  "[0][3][2]",
+			err:  "mod expects exactly 2 arguments. 1 given",
+		},
+		{
+			plan: `[[cond [true [not 1]]]]`,
+			loc:  "[0][1][1]",
+			err:  "not expects a boolean argument 1, not a int64",
+		},
+	} {
+		p := asm.NewPlan(sen.MustParse([]byte(d.plan)).([]any))
+		err := p.Validate()
+		if len(d.err) == 0 {
+			tt.Nil(t, err, "%s", d.plan)
+			continue
+		}
+		var ae *asm.Error
+		tt.Equal(t, true, errors.As(err, &ae), "%s", d.plan)
+		tt.Equal(t, d.loc, ae.Loc.String(), "%s", d.plan)
+		tt.Equal(t, d.err, ae.Err.Error(), "%s", d.plan)
+	}
+}
+
+func TestArgKindString(t *testing.T) {
+	tt.Equal(t, "any", asm.AnyArg.String())
+	tt.Equal(t, "integer or float", asm.NumArg.String())
+	tt.Equal(t, "path or function", (asm.PathArg | asm.FnArg).String())
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+import (
+	"fmt"
+	"sort"
+)
+
+func init() {
+	Define(&Fn{
+		Name:    "values",
+		Eval:    values,
+		MinArgs: 1,
+		MaxArgs: 1,
+		Kinds:   []ArgKind{ArrayArg | MapArg},
+		Desc: `Returns the values of an object (map) ordered by key. If the
+argument is an array a copy of the array is returned.`,
+	})
+}
+
+func values(root map[string]any, at any, args ...any) any {
+	if len(args) != 1 {
+		panic(fmt.Errorf("values expects exactly one argument. %d given", len(args)))
+	}
+	return members("values", evalArg(root, at, args[0]))
+}
+
+// members returns the elements of an array or the values of a map ordered
+// by key. A copy of an array is returned so it can be modified.
+func members(name string, v any) []any {
+	switch tv := v.(type) {
+	case []any:
+		list := make([]any, len(tv))
+		copy(list, tv)
+		return list
+	case map[string]any:
+		keys := sortedKeys(tv)
+		list := make([]any, len(keys))
+		for i, k := range keys {
+			list[i] = tv[k]
+		}
+		return list
+	}
+	panic(fmt.Errorf("%s expects an array or object argument, not a %T", name, v))
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
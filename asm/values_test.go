@@ -0,0 +1,38 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestValues(t *testing.T) {
+	root := testPlan(t,
+		`[
+           [set $.asm.a [values {b: 1 a: 2 c: 3}]]
+           [set $.asm.b [values [x y]]]
+         ]`,
+		"{src: []}",
+	)
+	tt.Equal(t, `{a:[2 1 3] b:[x y]}`, sen.String(root["asm"], &sopt))
+}
+
+func TestValuesArgCount(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"values"},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
+
+func TestValuesArgType(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"values", 1},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
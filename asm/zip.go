@@ -0,0 +1,49 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm
+
+import (
+	"fmt"
+)
+
+func init() {
+	Define(&Fn{
+		Name:    "zip",
+		Eval:    zip,
+		MinArgs: 1,
+		MaxArgs: -1,
+		Kinds:   []ArgKind{ArrayArg},
+		Desc: `Returns an array of arrays where the first array contains the
+first element of each argument, the second contains the second
+element of each, and so on. All arguments must be arrays. The
+result is as long as the shortest argument.`,
+	})
+}
+
+func zip(root map[string]any, at any, args ...any) any {
+	if len(args) < 1 {
+		panic(fmt.Errorf("zip expects at least one argument. %d given", len(args)))
+	}
+	lists := make([][]any, len(args))
+	size := -1
+	for i, a := range args {
+		v := evalArg(root, at, a)
+		list, ok := v.([]any)
+		if !ok {
+			panic(fmt.Errorf("zip expects array arguments, not a %T", v))
+		}
+		if size < 0 || len(list) < size {
+			size = len(list)
+		}
+		lists[i] = list
+	}
+	result := make([]any, size)
+	for i := range result {
+		tuple := make([]any, len(lists))
+		for j, list := range lists {
+			tuple[j] = list[i]
+		}
+		result[i] = tuple
+	}
+	return result
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package asm_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestZip(t *testing.T) {
+	root := testPlan(t,
+		`[
+           [set $.asm.a [zip [a b c] [1 2 3]]]
+           [set $.asm.b [zip [a b c] [1 2] [true false]]]
+           [set $.asm.c [zip [a b]]]
+         ]`,
+		"{src: []}",
+	)
+	tt.Equal(t, `{a:[[a 1][b 2][c 3]] b:[[a 1 true][b 2 false]] c:[[a][b]]}`, sen.String(root["asm"], &sopt))
+}
+
+func TestZipArgCount(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"zip"},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
+
+func TestZipArgType(t *testing.T) {
+	p := asm.NewPlan([]any{
+		[]any{"zip", []any{"list"}, 1},
+	})
+	err := p.Execute(map[string]any{})
+	tt.NotNil(t, err)
+}
@@ -9,8 +9,11 @@ import (
 
 func init() {
 	Define(&Fn{
-		Name: "zone",
-		Eval: zone,
+		Name:    "zone",
+		Eval:    zone,
+		MinArgs: 2,
+		MaxArgs: 2,
+		Kinds:   []ArgKind{TimeArg, StringArg | IntArg},
 		Desc: `Changes the timezone on a time to the location specified in the
 second argument. Raises an error if the first argument does not
 evaluate to a time or the location can not be determined.
@@ -0,0 +1,73 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ohler55/ojg/asm"
+	"github.com/ohler55/ojg/sen"
+)
+
+const asmPlanSEN = `[
+  [defn full [first last] [join [list $first $last] " "]]
+  [set $.asm {kind: person tags: []}]
+  [set $.asm.id $.src.id]
+  [set $.asm.name [full $.src.first $.src.last]]
+  [set $.asm.tags [each $.src.tags [set @.asm [toupper @.src]]]]
+  [set $.asm.total [reduce $.src.scores 0 [sum @.acc @.src]]]
+]`
+
+func asmPlanDesc() []any {
+	return sen.MustParse([]byte(asmPlanSEN)).([]any)
+}
+
+func asmRoot(i int) map[string]any {
+	return map[string]any{
+		"src": map[string]any{
+			"id":     int64(i),
+			"first":  "Pat",
+			"last":   fmt.Sprintf("Doe-%d", i),
+			"tags":   []any{"one", "two", "three"},
+			"scores": []any{int64(3), int64(5), int64(7)},
+		},
+	}
+}
+
+// asmCompileEach compiles the plan for each message.
+func asmCompileEach(b *testing.B) {
+	desc := asmPlanDesc()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		p := asm.NewPlan(desc)
+		if err := p.Execute(asmRoot(n)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// asmCompileOnce compiles the plan once and executes it for each message.
+func asmCompileOnce(b *testing.B) {
+	p := asm.NewPlan(asmPlanDesc())
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if err := p.Execute(asmRoot(n)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// asmParallel compiles the plan once and executes it concurrently.
+func asmParallel(b *testing.B) {
+	p := asm.NewPlan(asmPlanDesc())
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			if err := p.Execute(asmRoot(i)); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
@@ -3,6 +3,9 @@
 package main
 
 import (
+	"fmt"
+	"runtime"
+	"sync"
 	"testing"
 
 	"github.com/ohler55/ojg/jp"
@@ -25,3 +28,39 @@ func jpFirst(b *testing.B) {
 		_ = p.First(data)
 	}
 }
+
+var (
+	bigListOnce sync.Once
+	bigList     any
+)
+
+// buildBigList builds a list of a million objects to be filtered.
+func buildBigList() any {
+	bigListOnce.Do(func() {
+		list := make([]any, 1_000_000)
+		for i := range list {
+			list[i] = map[string]any{"id": int64(i), "name": fmt.Sprintf("item-%d", i)}
+		}
+		bigList = map[string]any{"items": list}
+	})
+	return bigList
+}
+
+func jpGetFilter(b *testing.B) {
+	p := jp.MustParseString("$.items[?(@.name =~ /^item-[0-9]*7$/ && @.id > 1000)].id")
+	data := buildBigList()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_ = p.Get(data)
+	}
+}
+
+func jpGetParallelFilter(b *testing.B) {
+	p := jp.MustParseString("$.items[?(@.name =~ /^item-[0-9]*7$/ && @.id > 1000)].id")
+	data := buildBigList()
+	workers := runtime.NumCPU()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_ = p.GetParallel(data, workers)
+	}
+}
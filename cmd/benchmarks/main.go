@@ -101,12 +101,14 @@ func main() {
 		benchSuite("Unmarshal []byte to type", []*bench{
 			{pkg: "json", name: "Unmarshal", fun: goUnmarshalCatalog},
 			{pkg: "oj", name: "Unmarshal", fun: ojUnmarshalCatalog},
+			{pkg: "oj", name: "Decoder", fun: ojDecodeCatalog},
 			{pkg: "sen", name: "Unmarshal", fun: senUnmarshalCatalog},
 		})
 	} else {
 		benchSuite("Unmarshal []byte to type", []*bench{
 			{pkg: "json", name: "Unmarshal", fun: goUnmarshalPatient},
 			{pkg: "oj", name: "Unmarshal", fun: ojUnmarshalPatient},
+			{pkg: "oj", name: "Decoder", fun: ojDecodePatient},
 			{pkg: "sen", name: "Unmarshal", fun: senUnmarshalPatient},
 		})
 	}
@@ -192,6 +194,15 @@ func main() {
 	benchSuite("JSONPath First  $..a[2].c", []*bench{
 		{pkg: "jp", name: "First", fun: jpFirst},
 	})
+	benchSuite("JSONPath filter over 1M elements", []*bench{
+		{pkg: "jp", name: "Get", fun: jpGetFilter},
+		{pkg: "jp", name: "GetParallel", fun: jpGetParallelFilter},
+	})
+	benchSuite("Assembly plan per message", []*bench{
+		{pkg: "asm", name: "CompileEach", fun: asmCompileEach},
+		{pkg: "asm", name: "CompileOnce", fun: asmCompileOnce},
+		{pkg: "asm", name: "Parallel", fun: asmParallel},
+	})
 
 	fmt.Println()
 	fmt.Println(" Higher values (longer bars) are better in all cases. The bar graph compares the")
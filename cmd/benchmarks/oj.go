@@ -146,6 +146,30 @@ func ojUnmarshalCatalog(b *testing.B) {
 	}
 }
 
+func ojDecodePatient(b *testing.B) {
+	sample, _ := ioutil.ReadFile(patFilename)
+	d := oj.Decoder{}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		var out Patient
+		if err := d.Unmarshal(sample, &out); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func ojDecodeCatalog(b *testing.B) {
+	sample, _ := ioutil.ReadFile(catFilename)
+	d := oj.Decoder{}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		var out Catalog
+		if err := d.Unmarshal(sample, &out); err != nil {
+			panic(err)
+		}
+	}
+}
+
 func ojParseChan(b *testing.B) {
 	sample, _ := ioutil.ReadFile(filename)
 	rc := make(chan any, b.N)
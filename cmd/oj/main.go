@@ -42,6 +42,8 @@ var (
 	dig            = false
 	annotate       = false
 	discovery      = false
+	explain        = false
+	trace          = false
 
 	// If true wrap extracts with an array.
 	wrapExtract = false
@@ -82,9 +84,11 @@ func init() {
 	flag.Var(&delValue{}, "d", "delete path")
 	flag.BoolVar(&dig, "dig", dig, "dig into a large document using the tokenizer")
 	flag.BoolVar(&discovery, "discover", discovery, "discover JSON or SEN in a file")
+	flag.BoolVar(&explain, "explain", explain, "explain how extraction paths are evaluated instead of writing the results")
 	flag.BoolVar(&showVersion, "version", showVersion, "display version and exit")
 	flag.StringVar(&planDef, "a", planDef, "assembly plan or plan file using @<plan>")
 	flag.BoolVar(&showRoot, "r", showRoot, "print root if an assemble plan provided")
+	flag.BoolVar(&trace, "trace", trace, "write an assembly plan call trace to stderr")
 	flag.StringVar(&prettyOpt, "p", prettyOpt,
 		`pretty print with the width, depth, and align as <width>.<max-depth>.<align>`)
 	flag.BoolVar(&html, "html", html, "output colored output as HTML")
@@ -142,7 +146,12 @@ occurrences of -d are supported.
 
 Oj can also be used to assemble new JSON output from input data. An assembly
 plan that describes how to assemble the new JSON if specified by the -a
-option. The -fn option will display the documentation for assembly.
+option. The -fn option will display the documentation for assembly. The
+-trace flag writes a line to stderr as each assembly function is entered and
+exited. Lines are indented to form a call tree and are written as JSON or as
+SEN if the -sen option is given.
+
+  oj -trace -a '[set $.asm [sum $.src.a 1]]' -z {a:1}
 
 Pretty mode output can be used with JSON or the -sen option. It indents
 according to a defined width and maximum depth in a best effort approach. The
@@ -152,6 +161,14 @@ are integers and align is a boolean.
 The -discover flag will attempt to discover JSON or SEN in a file and process
 the discovered document according to the -lazy flag.
 
+The -explain flag describes how each extraction path is evaluated against the
+input instead of writing the extracted values. The number of values entering
+and leaving each path fragment is shown along with elements rejected by
+filters, values that were the wrong type for a fragment, and any problems
+found with the path itself.
+
+  oj -explain -x "$.a[?(@.x > 1)].b" myfile.json
+
 `, filepath.Base(os.Args[0]))
 		flag.PrintDefaults()
 		fmt.Fprintln(os.Stderr)
@@ -296,6 +313,12 @@ func run() (err error) {
 			panic(fmt.Errorf("assembly plan not an array"))
 		}
 		plan = asm.NewPlan(plist)
+		if err = plan.Validate(); err != nil {
+			panic(err)
+		}
+		if trace {
+			plan.Trace = asm.TraceWriter(os.Stderr, senOut, nil)
+		}
 	}
 	if 0 < len(files) {
 		var f *os.File
@@ -450,6 +473,13 @@ func write(v any) bool {
 		_ = x.Del(v)
 	}
 	switch {
+	case explain && 0 < len(extracts):
+		for _, x := range extracts {
+			fmt.Print(x.Explain(v))
+			for _, issue := range x.Analyze().Issues {
+				fmt.Printf("  warning: %s\n", issue)
+			}
+		}
 	case 0 < len(extracts):
 		if wrapExtract {
 			var w []any
@@ -36,6 +36,9 @@ type Parser struct {
 	result     Node
 	mode       string
 	nextMode   string
+	kline      int // line of the most recent key
+	kcol       int // column of the most recent key
+	dupKeys    bool
 
 	// OnlyOne returns an error if more than one JSON is in the string or stream.
 	OnlyOne bool
@@ -43,6 +46,16 @@ type Parser struct {
 	// Reuse maps. Previously returned maps will no longer be valid or rather
 	// could be modified during parsing.
 	Reuse bool
+
+	// CheckDupKeys if true causes a duplicate key in an object to be
+	// reported as a *ParseError at the line and column of the duplicate key.
+	CheckDupKeys bool
+
+	// OnDupKey if not nil is called for each duplicate key encountered
+	// instead of returning an error. The line and column are those of the
+	// duplicate key. Parsing continues and the last value wins. Setting
+	// OnDupKey also turns on duplicate key detection.
+	OnDupKey func(key string, line, column int)
 }
 
 // Parse a JSON string in to simple types. An error is returned if not valid JSON.
@@ -79,6 +92,7 @@ func (p *Parser) Parse(buf []byte, args ...any) (Node, error) {
 	p.result = nil
 	p.noff = -1
 	p.line = 1
+	p.dupKeys = p.CheckDupKeys || p.OnDupKey != nil
 	p.mode = valueMap
 	p.mi = 0
 	var err error
@@ -135,6 +149,7 @@ func (p *Parser) ParseReader(r io.Reader, args ...any) (data Node, err error) {
 	p.result = nil
 	p.noff = -1
 	p.line = 1
+	p.dupKeys = p.CheckDupKeys || p.OnDupKey != nil
 	p.mi = 0
 	buf := make([]byte, readBufSize)
 	eof := false
@@ -168,6 +183,7 @@ func (p *Parser) ParseReader(r io.Reader, args ...any) (data Node, err error) {
 
 			return
 		}
+		p.noff -= len(buf) - skip
 		skip = 0
 		if eof {
 			break
@@ -213,6 +229,10 @@ func (p *Parser) parseBuffer(buf []byte, last bool) error {
 		case strOk:
 			p.tmp = append(p.tmp, b)
 		case keyQuote:
+			if p.dupKeys {
+				p.kline = p.line
+				p.kcol = off - p.noff
+			}
 			start := off + 1
 			if len(buf) <= start {
 				p.tmp = p.tmp[:0]
@@ -230,6 +250,11 @@ func (p *Parser) parseBuffer(buf []byte, last bool) error {
 				off++
 				p.stack = append(p.stack, Key(buf[start:off]))
 				p.mode = colonMap
+				if p.dupKeys {
+					if err := p.checkKey(); err != nil {
+						return err
+					}
+				}
 			} else {
 				p.tmp = p.tmp[:0]
 				p.tmp = append(p.tmp, buf[start:off+1]...)
@@ -435,6 +460,11 @@ func (p *Parser) parseBuffer(buf []byte, last bool) error {
 			p.mode = p.nextMode
 			if p.mode[':'] == colonColon {
 				p.stack = append(p.stack, Key(p.tmp))
+				if p.dupKeys {
+					if err := p.checkKey(); err != nil {
+						return err
+					}
+				}
 			} else {
 				p.add(String(p.tmp))
 			}
@@ -574,6 +604,25 @@ func (p *Parser) add(n Node) {
 	p.stack = append(p.stack, n)
 }
 
+// checkKey checks the key on the top of the stack against the keys already
+// in the object it belongs to.
+func (p *Parser) checkKey() error {
+	k := p.stack[len(p.stack)-1].(Key)
+	if obj, _ := p.stack[len(p.stack)-2].(Object); obj != nil {
+		if _, has := obj[string(k)]; has {
+			if p.OnDupKey == nil {
+				return &ParseError{
+					Message: fmt.Sprintf("duplicate key '%s'", k),
+					Line:    p.kline,
+					Column:  p.kcol,
+				}
+			}
+			p.OnDupKey(string(k), p.kline, p.kcol)
+		}
+	}
+	return nil
+}
+
 func (p *Parser) newError(off int, format string, args ...any) error {
 	return &ParseError{
 		Message: fmt.Sprintf(format, args...),
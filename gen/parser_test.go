@@ -348,3 +348,31 @@ func TestParserParseReaderChan(t *testing.T) {
 	}
 	tt.Equal(t, `1 [2] {"x":3} true false 123`, string(results))
 }
+
+func TestParserDupKeys(t *testing.T) {
+	p := gen.Parser{CheckDupKeys: true}
+	v, err := p.Parse([]byte(`{"a":{"a":1},"b":[{"a":2},{"a":3}]}`))
+	tt.Nil(t, err)
+	tt.Equal(t, `{"a":{"a":1},"b":[{"a":2},{"a":3}]}`, v.String())
+
+	_, err = p.Parse([]byte("{\n  \"a\": 1,\n  \"a\": 2}"))
+	tt.NotNil(t, err)
+	tt.Equal(t, "duplicate key 'a' at 3:3", err.Error())
+
+	_, err = p.Parse([]byte(`{"a\tb":1,"a\u0009b":2}`))
+	tt.NotNil(t, err)
+	tt.Equal(t, "duplicate key 'a\tb' at 1:11", err.Error())
+
+	_, err = p.ParseReader(strings.NewReader(strings.Repeat(" ", 4090) + `{"abc":1,"abc":2}`))
+	tt.NotNil(t, err)
+	tt.Equal(t, "duplicate key 'abc' at 1:4100", err.Error())
+
+	var dups []string
+	p.OnDupKey = func(key string, line, column int) {
+		dups = append(dups, fmt.Sprintf("%s@%d:%d", key, line, column))
+	}
+	v, err = p.Parse([]byte(`{"a":1,"a":2,"b":{"c":3,"c":4}}`))
+	tt.Nil(t, err)
+	tt.Equal(t, `{"a":2,"b":{"c":4}}`, v.String())
+	tt.Equal(t, []string{"a@1:8", "c@1:25"}, dups)
+}
@@ -37,6 +37,11 @@ func N(n int) Expr {
 	return Expr{Nth(n)}
 }
 
+// P creates an Expr with a Param fragment.
+func P(name string) Expr {
+	return Expr{Param(name)}
+}
+
 // R creates an Expr with a Root fragment.
 func R() Expr {
 	return Expr{Root('$')}
@@ -112,6 +117,16 @@ func (x Expr) Nth(n int) Expr {
 	return append(x, Nth(n))
 }
 
+// P appends a Param fragment to the Expr.
+func (x Expr) P(name string) Expr {
+	return append(x, Param(name))
+}
+
+// Param appends a Param fragment to the Expr.
+func (x Expr) Param(name string) Expr {
+	return append(x, Param(name))
+}
+
 // R appends a Root fragment to the Expr.
 func (x Expr) R() Expr {
 	return append(x, Root('$'))
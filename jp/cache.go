@@ -0,0 +1,204 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache is a concurrency safe, size bounded, least recently used cache of
+// parsed expressions and scripts. Parsing the same string more than once
+// returns the same shared value. Filters and the expressions used in
+// filter scripts are interned as well so identical filters in different
+// expressions share one Filter.
+//
+// Values returned by a Cache are shared and must be treated as immutable.
+// Evaluating an expression with Get(), Locate(), Walk(), Set(), or any of
+// the other functions that take data does not modify the expression so
+// shared expressions can be evaluated concurrently. The fragments of a
+// shared expression must not be modified. Returned expressions have a
+// capacity equal to their length so appending fragments, for example with
+// x.C("a"), creates a new expression instead of modifying the shared one.
+type Cache struct {
+	mu        sync.Mutex
+	max       int
+	order     *list.List
+	entries   map[string]*list.Element
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// CacheStats are the statistics for a Cache.
+type CacheStats struct {
+	// Hits is the number of parse calls that returned a cached value.
+	Hits uint64
+	// Misses is the number of parse calls that had to parse.
+	Misses uint64
+	// Evictions is the number of entries removed to stay within the
+	// maximum size.
+	Evictions uint64
+	// Size is the number of entries in the cache including interned
+	// filters and script expressions.
+	Size int
+}
+
+type cacheEntry struct {
+	key   string
+	value any
+}
+
+// NewCache creates a Cache that holds at most max entries. A max of less
+// than one is treated as one.
+func NewCache(max int) *Cache {
+	if max < 1 {
+		max = 1
+	}
+	return &Cache{
+		max:     max,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+// ParseString returns the cached expression for s or parses s and adds the
+// expression to the cache. Parse errors are not cached.
+func (c *Cache) ParseString(s string) (x Expr, err error) {
+	key := "x:" + s
+	if v, has := c.lookup(key, true); has {
+		return v.(Expr), nil
+	}
+	if x, err = ParseString(s); err != nil {
+		return nil, err
+	}
+	return c.store(key, c.intern(x)).(Expr), nil
+}
+
+// MustParseString returns the cached expression for s or parses s and
+// adds the expression to the cache. It panics on a parse error.
+func (c *Cache) MustParseString(s string) Expr {
+	x, err := c.ParseString(s)
+	if err != nil {
+		panic(err)
+	}
+	return x
+}
+
+// NewScript returns the cached script for str or parses str and adds the
+// script to the cache. Parse errors are not cached.
+func (c *Cache) NewScript(str string) (s *Script, err error) {
+	key := "s:" + str
+	if v, has := c.lookup(key, true); has {
+		return v.(*Script), nil
+	}
+	if s, err = NewScript(str); err != nil {
+		return nil, err
+	}
+	s.template = c.internTemplate(s.template)
+
+	return c.store(key, s).(*Script), nil
+}
+
+// Stats returns the current statistics for the cache.
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return CacheStats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Size:      len(c.entries),
+	}
+}
+
+// Clear removes all entries from the cache. The statistics are not reset.
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	c.order.Init()
+	c.entries = map[string]*list.Element{}
+	c.mu.Unlock()
+}
+
+func (c *Cache) lookup(key string, count bool) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, has := c.entries[key]; has {
+		c.order.MoveToFront(e)
+		if count {
+			c.hits++
+		}
+		return e.Value.(*cacheEntry).value, true
+	}
+	if count {
+		c.misses++
+	}
+	return nil, false
+}
+
+// store adds the value to the cache unless another goroutine has already
+// added a value for the key in which case the existing value is returned.
+func (c *Cache) store(key string, value any) any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, has := c.entries[key]; has {
+		c.order.MoveToFront(e)
+		return e.Value.(*cacheEntry).value
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value})
+	for c.max < c.order.Len() {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*cacheEntry).key)
+		c.evictions++
+	}
+	return value
+}
+
+// intern replaces the filters in a newly parsed expression with shared
+// filters and limits the capacity of the expression.
+func (c *Cache) intern(x Expr) Expr {
+	for i, f := range x {
+		switch tf := f.(type) {
+		case *Filter:
+			x[i] = c.internFilter(tf)
+		case Union:
+			for j, k := range tf {
+				if kf, ok := k.(*Filter); ok {
+					tf[j] = c.internFilter(kf)
+				}
+			}
+		}
+	}
+	return x[:len(x):len(x)]
+}
+
+func (c *Cache) internFilter(f *Filter) *Filter {
+	key := "f:" + f.String()
+	if v, has := c.lookup(key, false); has {
+		return v.(*Filter)
+	}
+	f.template = c.internTemplate(f.template)
+
+	return c.store(key, f).(*Filter)
+}
+
+func (c *Cache) internTemplate(template []any) []any {
+	for i, v := range template {
+		switch tv := v.(type) {
+		case Expr:
+			key := "x:" + tv.String()
+			if cv, has := c.lookup(key, false); has {
+				template[i] = cv
+			} else {
+				template[i] = c.store(key, c.intern(tv))
+			}
+		case []any:
+			template[i] = c.internTemplate(tv)
+		}
+	}
+	return template
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestCacheParseString(t *testing.T) {
+	c := jp.NewCache(10)
+	x := c.MustParseString("$.a[?(@.b > 2)].c")
+	tt.Equal(t, "$.a[?(@.b > 2)].c", x.String())
+	x2 := c.MustParseString("$.a[?(@.b > 2)].c")
+	tt.Equal(t, fmt.Sprintf("%p", x), fmt.Sprintf("%p", x2))
+
+	// Filters are shared by different expressions.
+	y := c.MustParseString("$.x[?(@.b > 2)]")
+	tt.Equal(t, true, x[2] == y[2])
+
+	stats := c.Stats()
+	tt.Equal(t, uint64(1), stats.Hits)
+	tt.Equal(t, uint64(2), stats.Misses)
+	tt.Equal(t, uint64(0), stats.Evictions)
+	// Two expressions, one filter, and @.b from the filter script.
+	tt.Equal(t, 4, stats.Size)
+
+	// Appending to a shared expression does not change it.
+	_ = x.C("d")
+	tt.Equal(t, "$.a[?(@.b > 2)].c", c.MustParseString("$.a[?(@.b > 2)].c").String())
+
+	_, err := c.ParseString("$[")
+	tt.NotNil(t, err)
+	tt.Panic(t, func() { _ = c.MustParseString("$[") })
+	tt.Equal(t, 4, c.Stats().Size)
+
+	c.Clear()
+	tt.Equal(t, 0, c.Stats().Size)
+	tt.Equal(t, uint64(2), c.Stats().Hits)
+}
+
+func TestCacheEviction(t *testing.T) {
+	c := jp.NewCache(2)
+	_ = c.MustParseString("a")
+	_ = c.MustParseString("b")
+	_ = c.MustParseString("a") // a is now the most recently used
+	_ = c.MustParseString("c") // evicts b
+	_ = c.MustParseString("a")
+	_ = c.MustParseString("b")
+
+	stats := c.Stats()
+	tt.Equal(t, uint64(2), stats.Hits)
+	tt.Equal(t, uint64(4), stats.Misses)
+	tt.Equal(t, uint64(2), stats.Evictions)
+	tt.Equal(t, 2, stats.Size)
+
+	c = jp.NewCache(0)
+	_ = c.MustParseString("a")
+	_ = c.MustParseString("b")
+	tt.Equal(t, 1, c.Stats().Size)
+}
+
+func TestCacheNewScript(t *testing.T) {
+	c := jp.NewCache(10)
+	s, err := c.NewScript("@.x == 3")
+	tt.Nil(t, err)
+	s2, _ := c.NewScript("@.x == 3")
+	tt.Equal(t, true, s == s2)
+	tt.Equal(t, true, s.Match(map[string]any{"x": 3}))
+
+	_, err = c.NewScript("@.x ==")
+	tt.NotNil(t, err)
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	c := jp.NewCache(8)
+	data := map[string]any{"a": []any{map[string]any{"b": 1}, map[string]any{"b": 3}}}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for n := 0; n < 200; n++ {
+				x := c.MustParseString(fmt.Sprintf("$.a[?(@.b > %d)].b", (i+n)%4))
+				_ = x.Get(data)
+			}
+		}(i)
+	}
+	wg.Wait()
+	stats := c.Stats()
+	tt.Equal(t, uint64(1600), stats.Hits+stats.Misses)
+}
@@ -0,0 +1,638 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ohler55/ojg/alt"
+	"github.com/ohler55/ojg/gen"
+)
+
+// ContainerPolicy returns a new empty container to be added to the data
+// when a Set() follows a path that does not exist. The next fragment is the
+// Child or Nth that will be applied to the new container and node is true
+// if the data being modified is gen.Node data. An array returned by the
+// policy is extended as needed to hold the next element.
+type ContainerPolicy func(next Frag, node bool) any
+
+// DefaultContainerPolicy creates a map[string]any for a Child and a []any
+// for a Nth. If node is true then a gen.Object or gen.Array is created
+// instead.
+func DefaultContainerPolicy(next Frag, node bool) any {
+	if _, ok := next.(Nth); ok {
+		if node {
+			return gen.Array{}
+		}
+		return []any{}
+	}
+	if node {
+		return gen.Object{}
+	}
+	return map[string]any{}
+}
+
+// Editor changes data by first locating the elements that match an
+// expression and then changing each element. Any expression that can be
+// used with Get() can be used with an Editor. Each function returns the
+// normalized paths of the elements that were changed in the order they were
+// changed. The root of the data is never set or deleted although it can be
+// replaced by Modify() and is returned as the result.
+//
+// Expressions that do not match are not an error. When setting, the
+// trailing Child and Nth fragments of an expression are used to create
+// missing members and containers according to the Policy. Missing array
+// elements are added to arrays that are not the root of the data. A
+// trailing KeyName renames the member of a map to the value set.
+type Editor struct {
+	// Policy is used to create missing containers. If nil the
+	// DefaultContainerPolicy is used.
+	Policy ContainerPolicy
+}
+
+// Set all matching elements to value and return the paths to the elements
+// set.
+func (e *Editor) Set(x Expr, data, value any) (changed []Expr, err error) {
+	return e.set(x, data, value, "set", 0)
+}
+
+// SetOne sets at most one element to value.
+func (e *Editor) SetOne(x Expr, data, value any) (changed []Expr, err error) {
+	return e.set(x, data, value, "set", 1)
+}
+
+// Del deletes matching map members and sets matching array elements to
+// nil.
+func (e *Editor) Del(x Expr, data any) (changed []Expr, err error) {
+	return e.set(x, data, delFlag, "delete", 0)
+}
+
+// DelOne deletes at most one element.
+func (e *Editor) DelOne(x Expr, data any) (changed []Expr, err error) {
+	return e.set(x, data, delFlag, "delete", 1)
+}
+
+// Modify calls modifier with each matching element and replaces the element
+// if the modifier returns true for changed. Elements are modified deepest
+// first so a modifier that is called for both a member and the member's
+// parent sees the already modified member. The modified data is returned.
+func (e *Editor) Modify(
+	x Expr,
+	data any,
+	modifier func(element any) (altered any, changed bool)) (result any, changed []Expr, err error) {
+
+	return e.modify(x, data, modifier, 0)
+}
+
+// ModifyOne modifies at most one element.
+func (e *Editor) ModifyOne(
+	x Expr,
+	data any,
+	modifier func(element any) (altered any, changed bool)) (result any, changed []Expr, err error) {
+
+	return e.modify(x, data, modifier, 1)
+}
+
+// Remove matching elements. Array elements are removed and the array is
+// shortened. The modified data is returned.
+func (e *Editor) Remove(x Expr, data any) (result any, changed []Expr, err error) {
+	return e.remove(x, data, 0)
+}
+
+// RemoveOne removes at most one element.
+func (e *Editor) RemoveOne(x Expr, data any) (result any, changed []Expr, err error) {
+	return e.remove(x, data, 1)
+}
+
+func (e *Editor) set(x Expr, data, value any, fun string, max int) (changed []Expr, err error) {
+	if len(x) == 0 {
+		return nil, fmt.Errorf("can not %s with an empty expression", fun)
+	}
+	switch x[len(x)-1].(type) {
+	case Root, At, Bracket:
+		ta := strings.Split(fmt.Sprintf("%T", x[len(x)-1]), ".")
+		return nil, fmt.Errorf("can not %s with an expression ending with a %s", fun, ta[len(ta)-1])
+	}
+	_, node := data.(gen.Node)
+	if node && value != delFlag && value != nil {
+		if _, ok := value.(gen.Node); !ok {
+			gv := alt.Generify(value)
+			if gv == nil {
+				return nil, fmt.Errorf("can not %s a %T in a %T", fun, value, data)
+			}
+			value = gv
+		}
+	}
+	if value == delFlag {
+		for _, loc := range pruneLocs(x.Locate(data, 0)) {
+			if err = delAt(data, loc); err != nil {
+				return
+			}
+			if _, ok := loc[len(loc)-1].(KeyName); ok {
+				// Deleting a key name deletes the member so the member path
+				// is reported as it is by Remove.
+				loc = loc[:len(loc)-1]
+			}
+			if changed = append(changed, loc); len(changed) == max {
+				break
+			}
+		}
+		return
+	}
+	// The trailing Child and Nth fragments are used to create missing
+	// elements so only the fragments before them are located.
+	i := len(x)
+	for 0 < i && creatable(x[i-1]) {
+		i--
+	}
+	if i == len(x) {
+		for _, loc := range pruneLocs(x.Locate(data, 0)) {
+			if err = setAt(data, loc, value); err != nil {
+				return
+			}
+			if changed = append(changed, loc); len(changed) == max {
+				break
+			}
+		}
+		return
+	}
+	// Bases that can not be followed are found before any changes are made
+	// so an error leaves the data unchanged.
+	var bases []Expr
+	for _, base := range baseLocs(x[:i], data) {
+		v, _ := locValue(base, data)
+		if !isContainer(v) || !selects(v, x[i]) {
+			// Skipped the same as Expr.Set() skips a key in an array or an
+			// index in an object.
+			continue
+		}
+		if err = followable(v, base, x[i:]); err != nil {
+			return
+		}
+		if bases = append(bases, base); len(bases) == max {
+			break
+		}
+	}
+	for _, base := range bases {
+		v, _ := locValue(base, data)
+		if !isContainer(v) || !selects(v, x[i]) {
+			// Replaced when an earlier base was set.
+			continue
+		}
+		var loc Expr
+		root := rootLoc(base)
+		if v, loc, err = e.build(v, base, x[i:], value, node, root); err != nil {
+			return
+		}
+		if !root {
+			if err = setAt(data, base, v); err != nil {
+				return
+			}
+		}
+		changed = append(changed, loc)
+	}
+	return
+}
+
+func (e *Editor) modify(
+	x Expr,
+	data any,
+	modifier func(element any) (altered any, changed bool),
+	max int) (result any, changed []Expr, err error) {
+
+	if len(x) == 0 {
+		return data, nil, fmt.Errorf("can not modify with an empty expression")
+	}
+	_, node := data.(gen.Node)
+	result = data
+	locs := x.Locate(data, 0)
+	if max != 1 {
+		sort.SliceStable(locs, func(i, j int) bool { return len(locs[j]) < len(locs[i]) })
+	}
+	for _, loc := range locs {
+		var v any
+		if _, ok := loc[len(loc)-1].(KeyName); ok {
+			v = keyOf(loc[:len(loc)-1])
+		} else if v, ok = locValue(loc, result); !ok {
+			continue
+		}
+		nv, ok := modifier(v)
+		if !ok {
+			continue
+		}
+		if node && nv != nil {
+			if _, ok := nv.(gen.Node); !ok {
+				nv = alt.Generify(nv)
+			}
+		}
+		if rootLoc(loc) {
+			result = nv
+		} else if err = setAt(result, loc, nv); err != nil {
+			return
+		}
+		if changed = append(changed, loc); len(changed) == max {
+			break
+		}
+	}
+	return
+}
+
+func (e *Editor) remove(x Expr, data any, max int) (result any, changed []Expr, err error) {
+	if len(x) == 0 {
+		return data, nil, fmt.Errorf("can not remove with an empty expression")
+	}
+	result = data
+	for _, loc := range pruneLocs(x.Locate(data, 0)) {
+		if _, ok := loc[len(loc)-1].(KeyName); ok {
+			loc = loc[:len(loc)-1]
+		}
+		pp := loc[:len(loc)-1]
+		parent, _ := locValue(pp, result)
+		var (
+			np  any
+			rem bool
+		)
+		switch tf := loc[len(loc)-1].(type) {
+		case Child:
+			np, rem = tf.remove(parent)
+			if !rem {
+				if k, ok := parent.(Keyed); ok {
+					k.RemoveValueForKey(string(tf))
+					np = parent
+					rem = true
+				}
+			}
+		case Nth:
+			np, rem = tf.remove(parent)
+		}
+		if !rem {
+			continue
+		}
+		if rootLoc(pp) {
+			result = np
+		} else if err = setAt(result, pp, np); err != nil {
+			return
+		}
+		if changed = append(changed, loc); len(changed) == max {
+			break
+		}
+	}
+	return
+}
+
+// build sets value at the end of rest relative to the container c and
+// creates missing containers along the way. The container or a replacement
+// for the container is returned along with the normalized path to the
+// element set. If fixed is true the container can not be replaced so an
+// array can not be extended.
+func (e *Editor) build(c any, path, rest Expr, value any, node, fixed bool) (any, Expr, error) {
+	f := absNth(c, rest[0])
+	loc := append(append(Expr{}, path...), f)
+	if len(rest) == 1 {
+		nc, err := putValue(c, loc, value, fixed)
+		return nc, loc, err
+	}
+	child, has := Expr{f}.FirstFound(c)
+	switch {
+	case !has:
+		policy := e.Policy
+		if policy == nil {
+			policy = DefaultContainerPolicy
+		}
+		child = policy(rest[1], node)
+		if node {
+			if _, ok := child.(gen.Node); !ok {
+				child = alt.Generify(child)
+			}
+		}
+	case !isContainer(child):
+		return c, nil, fmt.Errorf("can not follow a %T at '%s'", child, loc)
+	}
+	child, end, err := e.build(child, loc, rest[1:], value, node, false)
+	if err != nil {
+		return c, nil, err
+	}
+	nc, err := putValue(c, loc, child, fixed)
+
+	return nc, end, err
+}
+
+// followable returns the error build() would return if an existing element
+// along rest can not be followed or if a negative index can not be
+// converted to an index from the start of an array.
+func followable(c any, path, rest Expr) error {
+	for 0 < len(rest) {
+		f := absNth(c, rest[0])
+		if n, ok := f.(Nth); ok && n < 0 {
+			return fmt.Errorf("can not deduce the length of the array to add at '%s'", path)
+		}
+		if len(rest) == 1 {
+			break
+		}
+		path = append(append(Expr{}, path...), f)
+		child, has := Expr{f}.FirstFound(c)
+		if !has {
+			// Containers created from here on are empty.
+			c = nil
+		} else if !isContainer(child) {
+			return fmt.Errorf("can not follow a %T at '%s'", child, path)
+		} else {
+			c = child
+		}
+		rest = rest[1:]
+	}
+	return nil
+}
+
+// absNth returns f with a negative Nth converted to an index from the start
+// of the array c.
+func absNth(c any, f Frag) Frag {
+	if n, ok := f.(Nth); ok && n < 0 {
+		switch tc := c.(type) {
+		case []any:
+			f = n + Nth(len(tc))
+		case gen.Array:
+			f = n + Nth(len(tc))
+		case Indexed:
+			f = n + Nth(tc.Size())
+		default:
+			if rv := reflect.ValueOf(c); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+				f = n + Nth(rv.Len())
+			}
+		}
+	}
+	return f
+}
+
+// putValue sets the element identified by the last fragment of loc in the
+// container c and returns c or a replacement for c if an array had to be
+// extended.
+func putValue(c any, loc Expr, value any, fixed bool) (any, error) {
+	switch tf := loc[len(loc)-1].(type) {
+	case Child:
+		switch tc := c.(type) {
+		case map[string]any:
+			tc[string(tf)] = value
+			return c, nil
+		case gen.Object:
+			tn, _ := value.(gen.Node)
+			tc[string(tf)] = tn
+			return c, nil
+		case Keyed:
+			tc.SetValueForKey(string(tf), value)
+			return c, nil
+		}
+		if reflectSetChild(c, string(tf), value) {
+			return c, nil
+		}
+	case Nth:
+		i := int(tf)
+		if i < 0 {
+			return c, fmt.Errorf("can not deduce the length of the array to add at '%s'", loc[:len(loc)-1])
+		}
+		switch tc := c.(type) {
+		case []any:
+			if len(tc) <= i {
+				if fixed {
+					return c, fmt.Errorf("can not follow out of bounds array index at '%s'", loc)
+				}
+				tc = append(tc, make([]any, i+1-len(tc))...)
+			}
+			tc[i] = value
+			return tc, nil
+		case gen.Array:
+			if len(tc) <= i {
+				if fixed {
+					return c, fmt.Errorf("can not follow out of bounds array index at '%s'", loc)
+				}
+				tc = append(tc, make(gen.Array, i+1-len(tc))...)
+			}
+			tn, _ := value.(gen.Node)
+			tc[i] = tn
+			return tc, nil
+		case Indexed:
+			if i < tc.Size() {
+				tc.SetValueAtIndex(i, value)
+				return c, nil
+			}
+			return c, fmt.Errorf("can not follow out of bounds array index at '%s'", loc)
+		}
+		if reflectSetNth(c, i, value) {
+			return c, nil
+		}
+	}
+	return c, fmt.Errorf("can not set a %T in a %T at '%s'", value, c, loc)
+}
+
+// setAt sets the element at the normalized path loc. A loc that ends with
+// a KeyName renames the member.
+func setAt(data any, loc Expr, value any) error {
+	if _, ok := loc[len(loc)-1].(KeyName); ok {
+		return renameAt(data, loc[:len(loc)-1], value)
+	}
+	parent, has := locValue(loc[:len(loc)-1], data)
+	if !has {
+		return nil
+	}
+	_, err := putValue(parent, loc, value, true)
+
+	return err
+}
+
+func renameAt(data any, loc Expr, value any) error {
+	key, ok := loc[len(loc)-1].(Child)
+	name, ok2 := value.(string)
+	if gs, ok3 := value.(gen.String); ok3 {
+		name = string(gs)
+		ok2 = true
+	}
+	if !ok || !ok2 {
+		return fmt.Errorf("can not rename '%s' to a %T", loc, value)
+	}
+	parent, _ := locValue(loc[:len(loc)-1], data)
+	switch tp := parent.(type) {
+	case map[string]any:
+		v := tp[string(key)]
+		delete(tp, string(key))
+		tp[name] = v
+	case gen.Object:
+		v := tp[string(key)]
+		delete(tp, string(key))
+		tp[name] = v
+	case Keyed:
+		v, _ := tp.ValueForKey(string(key))
+		tp.RemoveValueForKey(string(key))
+		tp.SetValueForKey(name, v)
+	default:
+		return fmt.Errorf("can not rename a member of a %T at '%s'", parent, loc)
+	}
+	return nil
+}
+
+// delAt deletes the element at the normalized path loc. Array elements are
+// set to nil.
+func delAt(data any, loc Expr) error {
+	if _, ok := loc[len(loc)-1].(KeyName); ok {
+		loc = loc[:len(loc)-1]
+	}
+	parent, has := locValue(loc[:len(loc)-1], data)
+	if !has {
+		return nil
+	}
+	if tf, ok := loc[len(loc)-1].(Child); ok {
+		switch tp := parent.(type) {
+		case map[string]any:
+			delete(tp, string(tf))
+			return nil
+		case gen.Object:
+			delete(tp, string(tf))
+			return nil
+		case Keyed:
+			tp.RemoveValueForKey(string(tf))
+			return nil
+		}
+	}
+	_, err := putValue(parent, loc, nil, true)
+
+	return err
+}
+
+// baseLocs returns the normalized paths that match x or x itself if x only
+// identifies the root.
+func baseLocs(x Expr, data any) []Expr {
+	for _, f := range x {
+		switch f.(type) {
+		case Root, At, Bracket:
+		default:
+			return x.Locate(data, 0)
+		}
+	}
+	base := Expr{}
+	for _, f := range x {
+		if _, ok := f.(Bracket); !ok {
+			base = append(base, f)
+		}
+	}
+	return []Expr{base}
+}
+
+// pruneLocs drops locations to the root and locations that are inside
+// another location then orders the rest so that array elements with a
+// higher index come first. Removing elements in that order does not change
+// the index of the elements that follow.
+func pruneLocs(locs []Expr) []Expr {
+	keep := make([]Expr, 0, len(locs))
+	seen := map[string]bool{}
+	for _, loc := range locs {
+		if !rootLoc(loc) {
+			keep = append(keep, loc)
+			seen[loc.String()] = true
+		}
+	}
+	pruned := keep[:0]
+	for _, loc := range keep {
+		inside := false
+		for i := len(loc) - 1; 0 < i && !inside; i-- {
+			inside = seen[loc[:i].String()]
+		}
+		if !inside {
+			pruned = append(pruned, loc)
+		}
+	}
+	sort.SliceStable(pruned, func(i, j int) bool {
+		li := pruned[i]
+		lj := pruned[j]
+		for k := 0; k < len(li) && k < len(lj); k++ {
+			ni, ok := li[k].(Nth)
+			nj, ok2 := lj[k].(Nth)
+			if ok && ok2 && ni != nj {
+				return nj < ni
+			}
+		}
+		return false
+	})
+	return pruned
+}
+
+// rootLoc returns true if the normalized path identifies the root.
+func rootLoc(loc Expr) bool {
+	for _, f := range loc {
+		switch f.(type) {
+		case Root, At, Bracket:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func creatable(f Frag) bool {
+	switch f.(type) {
+	case Child, Nth:
+		return true
+	}
+	return false
+}
+
+// selects returns false if the Child or Nth fragment f can not select a
+// member of the container c such as a key in an array or an index in an
+// object.
+func selects(c any, f Frag) bool {
+	switch c.(type) {
+	case map[string]any, gen.Object:
+		_, ok := f.(Child)
+		return ok
+	case []any, gen.Array, Indexed:
+		_, ok := f.(Nth)
+		return ok
+	case Keyed:
+		_, ok := f.(Child)
+		return ok
+	}
+	rv := reflect.ValueOf(c)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		_, ok := f.(Nth)
+		return ok
+	case reflect.Map, reflect.Struct:
+		_, ok := f.(Child)
+		return ok
+	}
+	return true
+}
+
+// editorOnly returns true if the expression includes fragments that the
+// stack based set and modify functions do not handle so an Editor is used
+// instead. Modify and remove handle a trailing Slice or Filter but set
+// does not.
+func (x Expr) editorOnly(set bool) bool {
+	for _, f := range x {
+		switch tf := f.(type) {
+		case Parent, KeyName, Param, ParamSlice:
+			return true
+		case Union:
+			for _, k := range tf {
+				switch k.(type) {
+				case string, int64:
+				default:
+					return true
+				}
+			}
+		}
+	}
+	if 0 < len(x) {
+		switch x[len(x)-1].(type) {
+		case Descent:
+			return true
+		case Slice, *Filter:
+			return set
+		}
+	}
+	return false
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/ohler55/ojg/alt"
+	"github.com/ohler55/ojg/gen"
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/oj"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func pathStrings(paths []jp.Expr) []string {
+	strs := make([]string, len(paths))
+	for i, p := range paths {
+		strs[i] = p.String()
+	}
+	return strs
+}
+
+func TestEditorSet(t *testing.T) {
+	for _, d := range []struct {
+		src     string
+		data    string
+		value   any
+		expect  string
+		changed []string
+		one     bool
+	}{
+		{
+			src:     "$.a..",
+			data:    "{a: {b: {c: 1}} x: 2}",
+			value:   0,
+			expect:  `{"a":0,"x":2}`,
+			changed: []string{"$.a"},
+		},
+		{
+			src:     "$.a[?(@.x > 1)]",
+			data:    "{a: [{x: 1} {x: 2} {x: 3}]}",
+			value:   true,
+			expect:  `{"a":[{"x":1},true,true]}`,
+			changed: []string{"$.a[2]", "$.a[1]"},
+		},
+		{
+			src:     "$.a[?(@.x > 1)].y.z[1]",
+			data:    "{a: [{x: 1} {x: 2} {x: 3 y: {z: [0]}}]}",
+			value:   5,
+			expect:  `{"a":[{"x":1},{"x":2,"y":{"z":[null,5]}},{"x":3,"y":{"z":[0,5]}}]}`,
+			changed: []string{"$.a[1].y.z[1]", "$.a[2].y.z[1]"},
+		},
+		{
+			src:     "$.a[1:]",
+			data:    "{a: [1 2 3]}",
+			value:   0,
+			expect:  `{"a":[1,0,0]}`,
+			changed: []string{"$.a[2]", "$.a[1]"},
+		},
+		{
+			src:     "$.a[1:]",
+			data:    "{a: [1 2 3]}",
+			value:   0,
+			expect:  `{"a":[1,2,0]}`,
+			changed: []string{"$.a[2]"},
+			one:     true,
+		},
+		{
+			src:     "$.x.y[2].z",
+			data:    "{}",
+			value:   1,
+			expect:  `{"x":{"y":[null,null,{"z":1}]}}`,
+			changed: []string{"$.x.y[2].z"},
+		},
+		{
+			src:     "$.a[-1]",
+			data:    "{a: [1 2]}",
+			value:   3,
+			expect:  `{"a":[1,3]}`,
+			changed: []string{"$.a[1]"},
+		},
+		{
+			src:     "$.a[?(@ == 2)]^.b",
+			data:    "{a: {x: 2}}",
+			value:   3,
+			expect:  `{"a":{"b":3,"x":2}}`,
+			changed: []string{"$.a.b"},
+		},
+		{
+			src:     "$.a~",
+			data:    "{a: 1 b: 2}",
+			value:   "c",
+			expect:  `{"b":2,"c":1}`,
+			changed: []string{"$.a~"},
+		},
+		{
+			src:     "$[*].b",
+			data:    "[{a: 1} 2 {b: 3}]",
+			value:   0,
+			expect:  `[{"a":1,"b":0},2,{"b":0}]`,
+			changed: []string{"$[0].b", "$[2].b"},
+		},
+	} {
+		x := jp.MustParseString(d.src)
+		data := sen.MustParse([]byte(d.data))
+		var (
+			changed []jp.Expr
+			err     error
+		)
+		if d.one {
+			changed, err = (&jp.Editor{}).SetOne(x, data, d.value)
+		} else {
+			changed, err = (&jp.Editor{}).Set(x, data, d.value)
+		}
+		tt.Nil(t, err, "%s", d.src)
+		tt.Equal(t, d.expect, oj.JSON(data, &oj.Options{Sort: true}), "%s", d.src)
+		tt.Equal(t, d.changed, pathStrings(changed), "%s", d.src)
+
+		gd := alt.Generify(sen.MustParse([]byte(d.data)))
+		if d.one {
+			_, err = (&jp.Editor{}).SetOne(x, gd, d.value)
+		} else {
+			_, err = (&jp.Editor{}).Set(x, gd, d.value)
+		}
+		tt.Nil(t, err, "gen %s", d.src)
+		tt.Equal(t, d.expect, oj.JSON(gd, &oj.Options{Sort: true}), "gen %s", d.src)
+	}
+}
+
+func TestEditorSetErrors(t *testing.T) {
+	for _, d := range []struct {
+		src  string
+		data string
+		err  string
+	}{
+		{src: "", data: "{}", err: "can not set with an empty expression"},
+		{src: "$", data: "{}", err: "can not set with an expression ending with a Root"},
+		{src: "a.b", data: "{a: 4}", err: "can not follow a int64 at 'a'"},
+		{src: "a[-1]", data: "{}", err: "can not deduce the length of the array to add at 'a'"},
+		{src: "[3]", data: "[1]", err: "can not follow out of bounds array index at '[3]'"},
+		{src: "$.a~", data: "{a: 1}", err: "can not rename '$.a' to a int"},
+		{src: "$.a[-5].q", data: "{a: [{} {} {}]}", err: "can not deduce the length of the array to add at '$.a'"},
+		{src: "$.a[-4]", data: "{a: [1 2 3]}", err: "can not deduce the length of the array to add at '$.a'"},
+		{src: "$.a[1][-1]", data: "{a: [{}]}", err: "can not deduce the length of the array to add at '$.a[1]'"},
+	} {
+		var value any = 7
+		data := sen.MustParse([]byte(d.data))
+		_, err := (&jp.Editor{}).Set(jp.MustParseString(d.src), data, value)
+		tt.NotNil(t, err, "%s", d.src)
+		tt.Equal(t, d.err, err.Error(), "%s", d.src)
+		// The data is not changed when there is an error.
+		tt.Equal(t, sen.String(sen.MustParse([]byte(d.data))), sen.String(data), "%s", d.src)
+	}
+}
+
+func TestEditorSetDescent(t *testing.T) {
+	// Keys are skipped in arrays and indexes in objects as with Expr.Set().
+	data := sen.MustParse([]byte("{a: {x: 1} b: [{x: 2}] c: {x: {y: 3}}}"))
+	changed, err := (&jp.Editor{}).Set(jp.MustParseString("$..x"), data, 9)
+	tt.Nil(t, err)
+	tt.Equal(t, `{"a":{"x":9},"b":[{"x":9}],"c":{"x":9},"x":9}`, oj.JSON(data, &oj.Options{Sort: true}))
+	strs := pathStrings(changed)
+	sort.Strings(strs)
+	tt.Equal(t, []string{"$.a.x", "$.b[0].x", "$.c.x", "$.x"}, strs)
+
+	data = sen.MustParse([]byte("{a: {x: 1} b: [{x: 2}]}"))
+	changed, err = (&jp.Editor{}).Set(jp.MustParseString("$..[1]"), data, 9)
+	tt.Nil(t, err)
+	tt.Equal(t, `{"a":{"x":1},"b":[{"x":2},9]}`, oj.JSON(data, &oj.Options{Sort: true}))
+	tt.Equal(t, []string{"$.b[1]"}, pathStrings(changed))
+
+	// An error is found before any changes are made.
+	data = sen.MustParse([]byte("{x: {y: 1} a: {x: 2}}"))
+	_, err = (&jp.Editor{}).Set(jp.MustParseString("$..x.y"), data, 9)
+	tt.NotNil(t, err)
+	tt.Equal(t, "can not follow a int64 at '$.a.x'", err.Error())
+	tt.Equal(t, `{"a":{"x":2},"x":{"y":1}}`, oj.JSON(data, &oj.Options{Sort: true}))
+}
+
+func TestEditorPolicy(t *testing.T) {
+	var created []string
+	e := jp.Editor{
+		Policy: func(next jp.Frag, node bool) any {
+			created = append(created, jp.Expr{next}.String())
+			return jp.DefaultContainerPolicy(next, node)
+		},
+	}
+	data := map[string]any{}
+	changed, err := e.Set(jp.MustParseString("$.a[1].b"), data, 3)
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"$.a[1].b"}, pathStrings(changed))
+	tt.Equal(t, []string{"[1]", "b"}, created)
+	tt.Equal(t, `{"a":[null,{"b":3}]}`, oj.JSON(data))
+
+	gd := gen.Object{}
+	_, err = e.Set(jp.MustParseString("$.a[0]"), gd, 3)
+	tt.Nil(t, err)
+	tt.Equal(t, gen.Object{"a": gen.Array{gen.Int(3)}}, gd)
+
+	e.Policy = func(next jp.Frag, node bool) any { return map[string]any{} }
+	_, err = e.Set(jp.MustParseString("$.x[1]"), data, 3)
+	tt.NotNil(t, err)
+}
+
+func TestEditorDel(t *testing.T) {
+	data := sen.MustParse([]byte("{a: [{x: 1} {x: 2} {x: 3}] b: {c: {d: 1}}}"))
+	changed, err := (&jp.Editor{}).Del(jp.MustParseString("$.a[?(@.x != 2)]"), data)
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"$.a[2]", "$.a[0]"}, pathStrings(changed))
+	tt.Equal(t, `{"a":[null,{"x":2},null],"b":{"c":{"d":1}}}`, oj.JSON(data, &oj.Options{Sort: true}))
+
+	changed, err = (&jp.Editor{}).Del(jp.MustParseString("$.b.."), data)
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"$.b"}, pathStrings(changed))
+	tt.Equal(t, `{"a":[null,{"x":2},null]}`, oj.JSON(data, &oj.Options{Sort: true}))
+
+	changed, err = (&jp.Editor{}).DelOne(jp.MustParseString("$.a[*]"), data)
+	tt.Nil(t, err)
+	tt.Equal(t, 1, len(changed))
+
+	// Del and Remove report the member path for a key name.
+	data = sen.MustParse([]byte("{k: {x: 1 y: 2}}"))
+	changed, err = (&jp.Editor{}).Del(jp.MustParseString("$.k.y~"), data)
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"$.k.y"}, pathStrings(changed))
+	tt.Equal(t, `{"k":{"x":1}}`, oj.JSON(data))
+	_, changed, err = (&jp.Editor{}).Remove(jp.MustParseString("$.k.x~"), data)
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"$.k.x"}, pathStrings(changed))
+
+	// Expr.Del uses the same rules for a trailing Descent or Filter.
+	data = sen.MustParse([]byte("{a: [1 2 3] b: {c: 1}}"))
+	tt.Nil(t, jp.MustParseString("$.a[?(@ > 1)]").Del(data))
+	tt.Nil(t, jp.MustParseString("$.b..").Del(data))
+	tt.Equal(t, `{"a":[1,null,null]}`, oj.JSON(data))
+}
+
+func TestEditorModify(t *testing.T) {
+	data := sen.MustParse([]byte("{a: [1 [2 3]] b: 4}"))
+	count := func(v any) (any, bool) {
+		if list, ok := v.([]any); ok {
+			return int64(len(list)), true
+		}
+		return v, false
+	}
+	result, changed, err := (&jp.Editor{}).Modify(jp.MustParseString("$.."), data, count)
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"$.a[1]", "$.a"}, pathStrings(changed))
+	tt.Equal(t, `{"a":2,"b":4}`, oj.JSON(result, &oj.Options{Sort: true}))
+
+	result, changed, err = (&jp.Editor{}).Modify(jp.MustParseString("$"), []any{1, 2}, count)
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"$"}, pathStrings(changed))
+	tt.Equal(t, int64(2), result)
+
+	gd := alt.Generify(sen.MustParse([]byte("{a: 1 b: 2}")))
+	result, changed, err = (&jp.Editor{}).ModifyOne(jp.MustParseString("$.*~"), gd, func(v any) (any, bool) {
+		return v.(string) + v.(string), true
+	})
+	tt.Nil(t, err)
+	tt.Equal(t, 1, len(changed))
+	tt.Equal(t, 2, len(result.(gen.Object)))
+
+	result = jp.MustParseString("$.x..").MustModify(
+		map[string]any{"x": map[string]any{"y": 1}},
+		func(v any) (any, bool) {
+			if n, ok := v.(int); ok {
+				return n + 1, true
+			}
+			return v, false
+		})
+	tt.Equal(t, `{"x":{"y":2}}`, oj.JSON(result))
+
+	_, _, err = (&jp.Editor{}).Modify(jp.Expr{}, data, count)
+	tt.NotNil(t, err)
+}
+
+func TestEditorRemove(t *testing.T) {
+	data := sen.MustParse([]byte("[{x: 1} {x: 2} [3 {x: 4}]]"))
+	result, changed, err := (&jp.Editor{}).Remove(jp.MustParseString("$..[?(@.x)]"), data)
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"$[2][1]", "$[1]", "$[0]"}, pathStrings(changed))
+	tt.Equal(t, "[[3]]", oj.JSON(result))
+
+	data = sen.MustParse([]byte("{a: {b: 1 c: 2}}"))
+	result, changed, err = (&jp.Editor{}).RemoveOne(jp.MustParseString("$.a.b~"), data)
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"$.a.b"}, pathStrings(changed))
+	tt.Equal(t, `{"a":{"c":2}}`, oj.JSON(result))
+
+	result, err = jp.MustParseString("$.a.c^").Remove(data)
+	tt.Nil(t, err)
+	tt.Equal(t, `{}`, oj.JSON(result))
+
+	_, _, err = (&jp.Editor{}).Remove(jp.Expr{}, data)
+	tt.NotNil(t, err)
+}
@@ -16,6 +16,7 @@ type Equation struct {
 	result any
 	left   *Equation
 	right  *Equation
+	args   []*Equation
 }
 
 // MustParseEquation parses the string argument and returns an Equation or panics.
@@ -111,6 +112,12 @@ func ConstList(list []any) *Equation {
 	return &Equation{result: list}
 }
 
+// Parameter creates and returns an Equation for a named parameter that is
+// replaced by a value when the expression is bound.
+func Parameter(name string) *Equation {
+	return &Equation{result: Param(name)}
+}
+
 // ConstRegex creates and returns an Equation for a regex constant.
 func ConstRegex(rx *regexp.Regexp) *Equation {
 	return &Equation{result: rx}
@@ -236,7 +243,8 @@ func Search(left, right *Equation) *Equation {
 func (e *Equation) Append(buf []byte, parens bool) []byte {
 	if e.o != nil {
 		switch e.o.code {
-		case not.code, length.code, count.code, match.code, search.code, group.code:
+		case not.code, length.code, rfcLength.code, count.code, value.code, match.code, rfcMatch.code,
+			search.code, rfcSearch.code, group.code, userOpCode:
 			parens = false
 		}
 	}
@@ -256,12 +264,12 @@ func (e *Equation) Append(buf []byte, parens bool) []byte {
 			if e.left != nil {
 				buf = e.appendValue(buf, e.left.result)
 			}
-		case length.code, count.code:
+		case length.code, rfcLength.code, count.code, value.code:
 			buf = append(buf, e.o.name...)
 			buf = append(buf, '(')
 			buf = e.appendValue(buf, e.left.result)
 			buf = append(buf, ')')
-		case match.code, search.code:
+		case match.code, rfcMatch.code, search.code, rfcSearch.code:
 			buf = append(buf, e.o.name...)
 			buf = append(buf, '(')
 			buf = e.left.Append(buf, false)
@@ -272,6 +280,22 @@ func (e *Equation) Append(buf []byte, parens bool) []byte {
 			if e.left != nil {
 				buf = e.left.Append(buf, e.left.o != nil && e.left.o.prec >= e.o.prec)
 			}
+		case userOpCode:
+			buf = append(buf, e.o.name...)
+			buf = append(buf, '(')
+			args := e.args
+			if e.o.varFun == nil {
+				args = []*Equation{e.left, e.right}[:e.o.cnt]
+			}
+			for i, arg := range args {
+				if 0 < i {
+					buf = append(buf, ',', ' ')
+				}
+				if arg != nil {
+					buf = arg.Append(buf, false)
+				}
+			}
+			buf = append(buf, ')')
 		default:
 			if e.left != nil {
 				buf = e.left.Append(buf, e.left.o != nil && e.left.o.prec >= e.o.prec)
@@ -319,6 +343,9 @@ func (e *Equation) appendValue(buf []byte, v any) []byte {
 		buf = append(buf, ']')
 	case Expr:
 		buf = tv.Append(buf)
+	case Param:
+		buf = append(buf, '$')
+		buf = append(buf, tv...)
 	case *regexp.Regexp:
 		buf = AppendString(buf, tv.String(), '/')
 	}
@@ -342,6 +369,11 @@ func (e *Equation) buildScript(stack []any) []any {
 			}
 			stack = append(stack, e.left.result) // should always be an Expr
 		}
+	} else if e.o.varFun != nil {
+		stack = append(stack, e.o)
+		for _, arg := range e.args {
+			stack = arg.buildScript(stack)
+		}
 	} else {
 		stack = append(stack, e.o)
 		if e.left == nil {
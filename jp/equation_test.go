@@ -97,6 +97,38 @@ func TestParseEquation(t *testing.T) {
 	tt.Equal(t, "!(@.text ~= /(?i)notexpected/)", eq.String())
 }
 
+func TestEquationFunctionRoundTrip(t *testing.T) {
+	for _, src := range []string{
+		"(length(@.b) == 2)",
+		"(count(@.b[*]) == 2)",
+		"(value(@.b.x) == 2)",
+		"match(@.b, 'a.*')",
+		"search(@.b, 'a')",
+	} {
+		eq := jp.MustParseEquation(src)
+		tt.Equal(t, src, eq.String(), "%s", src)
+		tt.Equal(t, src, jp.MustParseEquation(eq.String()).String(), "%s", src)
+
+		x := jp.MustParseString("$[?" + src + "]")
+		y, err := jp.ParseString(x.String())
+		tt.Nil(t, err, "%s", x)
+		tt.Equal(t, x.String(), y.String())
+	}
+	for _, src := range []string{
+		"$[?length(@.b) == 2]",
+		"$[?count(@.b[*]) == 2]",
+		"$[?value(@.b.x) == 2]",
+		"$[?match(@.b, 'a.*')]",
+		"$[?search(@.b, 'a')]",
+	} {
+		x, err := jp.ParseRFC9535String(src)
+		tt.Nil(t, err, "%s", src)
+		y, err := jp.ParseRFC9535String(x.String())
+		tt.Nil(t, err, "%s", x)
+		tt.Equal(t, x.String(), y.String())
+	}
+}
+
 func TestEquationUnary(t *testing.T) {
 	jp.RegisterUnaryFunction("double", false, func(v any) any {
 		return v.(int64) * 2
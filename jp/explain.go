@@ -0,0 +1,292 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ohler55/ojg/gen"
+)
+
+// Explanation describes how an expression was evaluated against some
+// data. It is intended to help determine why an expression does not return
+// the expected results.
+type Explanation struct {
+	// Expr is the expression that was explained.
+	Expr Expr
+	// Steps has one entry for each fragment of the expression.
+	Steps []*ExplainStep
+	// Results are the normalized paths of the final matches.
+	Results []Expr
+}
+
+// ExplainStep describes the evaluation of a single fragment.
+type ExplainStep struct {
+	// Frag is the fragment evaluated.
+	Frag Frag
+	// In is the number of nodes the fragment was applied to.
+	In int
+	// Out is the number of nodes selected by the fragment.
+	Out int
+	// Rejected are the normalized paths of the elements rejected by a
+	// filter.
+	Rejected []Expr
+	// Mismatches are the nodes the fragment could not be applied to
+	// because of the node type.
+	Mismatches []*Mismatch
+}
+
+// Mismatch identifies a node that was the wrong type for a fragment, such
+// as a child fragment applied to an array.
+type Mismatch struct {
+	Path    Expr
+	Message string
+}
+
+// Analysis is the result of a static analysis of an expression.
+type Analysis struct {
+	// Definite is true if the expression can match at most one value. That
+	// is the case when the expression includes only child names and
+	// indexes.
+	Definite bool
+	// Issues are the problems found with the expression.
+	Issues []*Issue
+}
+
+// Issue describes a problem found by Analyze().
+type Issue struct {
+	// Index of the fragment in the expression.
+	Index   int
+	Frag    Frag
+	Message string
+}
+
+type explainNode struct {
+	path  Expr
+	value any
+}
+
+// Explain evaluates the expression against data one fragment at a time
+// and returns a description of the evaluation.
+func (x Expr) Explain(data any) *Explanation {
+	ex := Explanation{Expr: x}
+	nodes := []*explainNode{{path: R(), value: data}}
+	for i, f := range x {
+		step := ExplainStep{Frag: f, In: len(nodes)}
+		var next []*explainNode
+		switch tf := f.(type) {
+		case Root:
+			next = []*explainNode{{path: R(), value: data}}
+		case At:
+			if i == 0 {
+				next = []*explainNode{{path: Expr{At('@')}, value: data}}
+			} else {
+				next = nodes
+			}
+		case Bracket:
+			next = nodes
+		default:
+			for _, n := range nodes {
+				if msg := mismatch(tf, n.value); 0 < len(msg) {
+					step.Mismatches = append(step.Mismatches, &Mismatch{Path: n.path, Message: msg})
+					continue
+				}
+				locs := f.locate(nil, n.value, data, nil, 0)
+				if _, ok := f.(*Filter); ok {
+					step.Rejected = append(step.Rejected, rejected(n, locs, data)...)
+				}
+				for _, loc := range locs {
+					en := explainNode{path: append(append(Expr{}, n.path...), loc...), value: n.value}
+					if 0 < len(loc) {
+						en.value = loc.First(n.value)
+					}
+					next = append(next, &en)
+				}
+			}
+		}
+		step.Out = len(next)
+		ex.Steps = append(ex.Steps, &step)
+		nodes = next
+	}
+	if 0 < len(x) {
+		for _, n := range nodes {
+			ex.Results = append(ex.Results, n.path)
+		}
+	}
+	return &ex
+}
+
+// String returns a multiple line description of the evaluation with one
+// line for each fragment followed by any rejections or mismatches for the
+// fragment.
+func (ex *Explanation) String() string {
+	var b []byte
+	b = fmt.Appendf(b, "%s\n", ex.Expr)
+	width := 0
+	frags := make([]string, len(ex.Steps))
+	for i, step := range ex.Steps {
+		frags[i] = string(step.Frag.Append(nil, false, i == 0))
+		if width < len(frags[i]) {
+			width = len(frags[i])
+		}
+	}
+	for i, step := range ex.Steps {
+		b = fmt.Appendf(b, "  %-*s  in: %d  out: %d\n", width, frags[i], step.In, step.Out)
+		for _, p := range step.Rejected {
+			b = fmt.Appendf(b, "    rejected %s\n", p)
+		}
+		for _, m := range step.Mismatches {
+			b = fmt.Appendf(b, "    %s %s\n", m.Path, m.Message)
+		}
+	}
+	b = fmt.Appendf(b, "  results: %d\n", len(ex.Results))
+
+	return string(b)
+}
+
+// Analyze the expression without data and report whether the expression is
+// definite along with any problems such as fragments that can never match
+// or that can never be reached.
+func (x Expr) Analyze() *Analysis {
+	a := Analysis{Definite: true}
+	if len(x) == 0 {
+		a.Definite = false
+		a.Issues = append(a.Issues, &Issue{Index: -1, Message: "an empty expression never matches"})
+		return &a
+	}
+	empty := false
+	for i, f := range x {
+		if empty {
+			a.Issues = append(a.Issues, &Issue{Index: i, Frag: f, Message: "is unreachable after an always empty fragment"})
+			continue
+		}
+		switch tf := f.(type) {
+		case Root, At:
+			if 0 < i {
+				a.Issues = append(a.Issues, &Issue{Index: i, Frag: f, Message: "is only valid as the first fragment"})
+			}
+		case Child, Nth, Bracket:
+		case Slice:
+			a.Definite = false
+			if sliceEmpty(tf) {
+				a.Issues = append(a.Issues, &Issue{Index: i, Frag: f, Message: "always selects nothing"})
+				empty = true
+			}
+		case Union:
+			if len(tf) != 1 {
+				a.Definite = false
+			}
+			if len(tf) == 0 {
+				a.Issues = append(a.Issues, &Issue{Index: i, Frag: f, Message: "always selects nothing"})
+				empty = true
+			}
+		case Descent:
+			a.Definite = false
+			if i+1 < len(x) {
+				if _, ok := x[i+1].(Descent); ok {
+					a.Issues = append(a.Issues, &Issue{Index: i + 1, Frag: x[i+1], Message: "is redundant after a descent"})
+				}
+			}
+		default:
+			a.Definite = false
+		}
+	}
+	return &a
+}
+
+// String returns a description of the issue.
+func (issue *Issue) String() string {
+	if issue.Frag == nil {
+		return issue.Message
+	}
+	return fmt.Sprintf("fragment %d (%s) %s", issue.Index, Expr{issue.Frag}, issue.Message)
+}
+
+func sliceEmpty(f Slice) bool {
+	start, end, step := sliceParams(f)
+	switch {
+	case step == 0:
+		return true
+	case (0 <= start) != (0 <= end):
+		return false
+	case 0 < step:
+		return end <= start
+	default:
+		return start <= end
+	}
+}
+
+func rejected(n *explainNode, locs []Expr, root any) (rejects []Expr) {
+	accepted := map[string]bool{}
+	for _, loc := range locs {
+		accepted[loc.String()] = true
+	}
+	for _, loc := range Wildcard('*').locate(nil, n.value, root, nil, 0) {
+		if !accepted[loc.String()] {
+			rejects = append(rejects, append(append(Expr{}, n.path...), loc...))
+		}
+	}
+	return
+}
+
+// mismatch returns a description of why the fragment can not be applied
+// to the value or an empty string if it can be applied.
+func mismatch(f Frag, v any) string {
+	kind := kindName(v)
+	switch f.(type) {
+	case Child:
+		if kind != "object" {
+			return fmt.Sprintf("is %s %s, not an object", article(kind), kind)
+		}
+	case Nth, Slice:
+		if kind != "array" {
+			return fmt.Sprintf("is %s %s, not an array", article(kind), kind)
+		}
+	default:
+		if kind != "object" && kind != "array" {
+			return fmt.Sprintf("is %s %s, not an object or array", article(kind), kind)
+		}
+	}
+	return ""
+}
+
+func article(kind string) string {
+	if strings.IndexByte("aeiou", kind[0]) < 0 {
+		return "a"
+	}
+	return "an"
+}
+
+func kindName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool, gen.Bool:
+		return "boolean"
+	case string, gen.String:
+		return "string"
+	case int, uint, int8, int16, int32, int64, uint8, uint16, uint32, uint64, gen.Int,
+		float32, float64, gen.Float:
+		return "number"
+	case map[string]any, gen.Object, Keyed:
+		return "object"
+	case []any, gen.Array, Indexed:
+		return "array"
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return "null"
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Struct, reflect.Map:
+		return "object"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	}
+	return rv.Kind().String()
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestExprExplain(t *testing.T) {
+	data := sen.MustParse([]byte(`{a: [{x: 1 b: 1} {x: 2 b: 2} {x: 3}] c: 7}`))
+	ex := jp.MustParseString("$.a[?(@.x > 1)].b").Explain(data)
+	tt.Equal(t, 4, len(ex.Steps))
+	tt.Equal(t, 1, ex.Steps[1].Out)
+	tt.Equal(t, 2, ex.Steps[2].Out)
+	tt.Equal(t, 1, len(ex.Steps[2].Rejected))
+	tt.Equal(t, "$.a[0]", ex.Steps[2].Rejected[0].String())
+	tt.Equal(t, 1, len(ex.Results))
+	tt.Equal(t, `$.a[?(@.x > 1)].b
+  $             in: 1  out: 1
+  .a            in: 1  out: 1
+  [?(@.x > 1)]  in: 1  out: 2
+    rejected $.a[0]
+  .b            in: 2  out: 1
+  results: 1
+`, ex.String())
+
+	ex = jp.MustParseString("$.c.d").Explain(data)
+	tt.Equal(t, 0, len(ex.Results))
+	tt.Equal(t, 1, len(ex.Steps[2].Mismatches))
+	tt.Equal(t, "$.c is a number, not an object", ex.Steps[2].Mismatches[0].Path.String()+" "+ex.Steps[2].Mismatches[0].Message)
+
+	ex = jp.MustParseString("$.a.x").Explain(data)
+	tt.Equal(t, "is an array, not an object", ex.Steps[2].Mismatches[0].Message)
+
+	ex = jp.MustParseString("$.c[0]").Explain(data)
+	tt.Equal(t, "is a number, not an array", ex.Steps[2].Mismatches[0].Message)
+
+	ex = jp.MustParseString("$.c.*").Explain(data)
+	tt.Equal(t, "is a number, not an object or array", ex.Steps[2].Mismatches[0].Message)
+
+	ex = jp.MustParseString("@..x").Explain(data)
+	tt.Equal(t, 3, len(ex.Results))
+	tt.Equal(t, "@.a[0].x", ex.Results[0].String())
+
+	ex = jp.MustParseString("$.a[1:].x").Explain(&struct{ A []any }{A: []any{1, nil}})
+	tt.Equal(t, "is a null, not an object", ex.Steps[3].Mismatches[0].Message)
+
+	tt.Equal(t, 0, len(jp.Expr{}.Explain(data).Results))
+}
+
+func TestExprAnalyze(t *testing.T) {
+	for _, d := range []struct {
+		path     string
+		definite bool
+		issues   []string
+	}{
+		{path: "$.a[1]['b']", definite: true},
+		{path: "a.b", definite: true},
+		{path: "$.a[*]"},
+		{path: "$..a"},
+		{path: "$.a[1,2]"},
+		{path: "$.a[?(@.x == 1)]"},
+		{path: "$.a[3:1].b", issues: []string{"fragment 2 ([3:1]) always selects nothing", "fragment 3 (b) is unreachable after an always empty fragment"}},
+		{path: "$.a[::0]", issues: []string{"fragment 2 ([::0]) always selects nothing"}},
+		{path: "$.a[1:3:-1]", issues: []string{"fragment 2 ([1:3:-1]) always selects nothing"}},
+		{path: "$.a[-1:2]"},
+	} {
+		a := jp.MustParseString(d.path).Analyze()
+		tt.Equal(t, d.definite, a.Definite, d.path)
+		var issues []string
+		for _, issue := range a.Issues {
+			issues = append(issues, issue.String())
+		}
+		tt.Equal(t, d.issues, issues, d.path)
+	}
+	a := jp.Expr{}.Analyze()
+	tt.Equal(t, false, a.Definite)
+	tt.Equal(t, "an empty expression never matches", a.Issues[0].String())
+
+	a = jp.R().C("a").Descent().Descent().C("b").Analyze()
+	tt.Equal(t, "fragment 3 (..) is redundant after a descent", a.Issues[0].String())
+
+	a = append(jp.R().C("a"), jp.Root('$')).Analyze()
+	tt.Equal(t, "fragment 2 ($) is only valid as the first fragment", a.Issues[0].String())
+}
@@ -10,6 +10,13 @@ import (
 // JSONPath as described by https://goessner.net/articles/JsonPath. Where the
 // definition is unclear Oj has implemented the description based on the best
 // judgement of the author.
+//
+// Evaluating an expression never modifies the expression or its fragments
+// so an expression can be shared between goroutines as long as no
+// goroutine modifies it. The builder functions such as C() and N() append
+// to the expression and may modify the underlying array of a shared
+// expression unless the expression has a capacity equal to its length as
+// expressions returned by a Cache do.
 type Expr []Frag
 
 // String returns a string representation of the expression.
@@ -32,6 +39,16 @@ func (x Expr) Append(buf []byte, brackets ...bool) []byte {
 			bracket = true
 			continue
 		}
+		if 0 < i && !bracket {
+			// A descent is written as a '.' so a parent or key name that
+			// follows needs another '.' as in $..^ or $..~.
+			switch frag.(type) {
+			case Parent, KeyName:
+				if _, ok := x[i-1].(Descent); ok {
+					buf = append(buf, '.')
+				}
+			}
+		}
 		buf = frag.Append(buf, bracket, i == 0)
 	}
 	if 0 < len(x) {
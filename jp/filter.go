@@ -290,21 +290,22 @@ func (f *Filter) removeOne(value any) (out any, changed bool) {
 	return
 }
 
-func (f *Filter) locate(pp Expr, data any, rest Expr, max int) (locs []Expr) {
-	ns, lcs := f.evalWithRoot([]any{}, data, nil)
+func (f *Filter) locate(pp Expr, data, root any, rest Expr, max int) (locs []Expr) {
+	ns, lcs := f.evalWithRoot([]any{}, data, root)
 	stack, _ := ns.([]any)
+	// Matches are in reverse order so iterate from the end.
 	if len(rest) == 0 { // last one
-		for _, lc := range lcs {
-			locs = locateAppendFrag(locs, pp, lc)
+		for i := len(lcs) - 1; 0 <= i; i-- {
+			locs = locateAppendFrag(locs, pp, lcs[i])
 			if 0 < max && max <= len(locs) {
 				break
 			}
 		}
 	} else {
 		cp := append(pp, nil) // place holder
-		for i, lc := range lcs {
-			cp[len(pp)] = lc
-			locs = locateContinueFrag(locs, cp, stack[i], rest, max)
+		for i := len(lcs) - 1; 0 <= i; i-- {
+			cp[len(pp)] = lcs[i]
+			locs = locateContinueFrag(locs, cp, stack[i], root, rest, max)
 			if 0 < max && max <= len(locs) {
 				break
 			}
@@ -412,6 +413,9 @@ func (f *Filter) Walk(rest, path Expr, nodes []any, cb func(path Expr, nodes []a
 		}
 	default:
 		rv := reflect.ValueOf(tv)
+		for rv.Kind() == reflect.Ptr {
+			rv = rv.Elem()
+		}
 		switch rv.Kind() {
 		case reflect.Slice:
 			cnt := rv.Len()
@@ -20,11 +20,30 @@ type Form struct {
 	// Right is the left side a form. The type can be a *Form, Expr, or any of
 	// the simple types.
 	Right any
+
+	// Args are the arguments to a function registered with
+	// RegisterVariadicFunction. Left and Right are nil for those functions.
+	Args []any
 }
 
 // Simplify the form.
 func (f *Form) Simplify() any {
 	simple := map[string]any{"op": f.Op}
+	if f.Args != nil {
+		args := make([]any, len(f.Args))
+		for i, arg := range f.Args {
+			switch tv := arg.(type) {
+			case Expr:
+				args[i] = tv.String()
+			case *Form:
+				args[i] = tv.Simplify()
+			default:
+				args[i] = tv
+			}
+		}
+		simple["args"] = args
+		return simple
+	}
 	switch tv := f.Left.(type) {
 	case Expr:
 		simple["left"] = tv.String()
@@ -38,10 +38,21 @@ func (x Expr) Get(data any) (results []any) {
 	if len(x) == 0 {
 		return
 	}
+	if x.hasPathFrag() {
+		return x.pathGet(data, 0)
+	}
+	return x.get(data, data)
+}
+
+// get the elements of the data identified by the path where root is the
+// data used for Root fragments including those in filters.
+func (x Expr) get(data, root any) (results []any) {
 	var v any
 	var prev any
 	var has bool
 
+	rfc := x.rfc()
+
 	stack := make([]any, 0, 64)
 	stack = append(stack, data)
 
@@ -290,6 +301,8 @@ func (x Expr) Get(data any) (results []any) {
 			top := (di & descentChildFlag) == 0
 			// first pass expands, second continues evaluation
 			if (di & descentFlag) == 0 {
+				keepSiblingIndex(&stack)
+				base := len(stack) - 1
 				switch tv := prev.(type) {
 				case map[string]any:
 					// Put prev back and slide fi.
@@ -460,6 +473,13 @@ func (x Expr) Get(data any) (results []any) {
 						}
 					}
 				}
+				// Unless last, move prev above the children so that it is
+				// evaluated before the descendants as required by RFC 9535.
+				if rfc && int(fi) < len(x)-1 && base+2 < len(stack) {
+					copy(stack[base:], stack[base+2:])
+					stack[len(stack)-2] = prev
+					stack[len(stack)-1] = di | descentFlag
+				}
 			} else {
 				if int(fi) == len(x)-1 { // last one
 					if top {
@@ -471,9 +491,9 @@ func (x Expr) Get(data any) (results []any) {
 			}
 		case Root:
 			if int(fi) == len(x)-1 { // last one
-				results = append(results, data)
+				results = append(results, root)
 			} else {
-				stack = append(stack, data)
+				stack = append(stack, root)
 			}
 		case At, Bracket:
 			if int(fi) == len(x)-1 { // last one
@@ -527,6 +547,8 @@ func (x Expr) Get(data any) (results []any) {
 						default:
 							v, has = reflectGetNth(tv, i)
 						}
+					case Frag:
+						results = append(results, unionFragGet(tu, prev, root)...)
 					}
 					if has {
 						results = append(results, v)
@@ -578,6 +600,13 @@ func (x Expr) Get(data any) (results []any) {
 						default:
 							v, has = reflectGetNth(tv, i)
 						}
+					case Frag:
+						vals := unionFragGet(tu, prev, root)
+						for vi := len(vals) - 1; 0 <= vi; vi-- {
+							if isContainer(vals[vi]) {
+								stack = append(stack, vals[vi])
+							}
+						}
 					}
 					if has {
 						switch v.(type) {
@@ -614,27 +643,16 @@ func (x Expr) Get(data any) (results []any) {
 			}
 			switch tv := prev.(type) {
 			case []any:
-				if start < 0 {
-					start = len(tv) + start
-					if start < 0 {
-						start = 0
-					}
-				}
-				if end < 0 {
-					end = len(tv) + end
-				}
-				if len(tv) <= start {
-					continue
-				}
-				if len(tv) < end {
-					end = len(tv)
-				}
+				start, end = sliceBounds(start, end, step, len(tv), rfc)
 				if 0 < step {
 					if int(fi) == len(x)-1 { // last one
 						for i := start; i < end; i += step {
 							results = append(results, tv[i])
 						}
 					} else {
+						if end <= start {
+							continue
+						}
 						end = start + (end-start-1)/step*step
 						for i := end; start <= i; i -= step {
 							v = tv[i]
@@ -654,14 +672,14 @@ func (x Expr) Get(data any) (results []any) {
 						}
 					}
 				} else {
-					if end < -1 {
-						end = -1
-					}
 					if int(fi) == len(x)-1 { // last one
 						for i := start; end < i; i += step {
 							results = append(results, tv[i])
 						}
 					} else {
+						if start <= end {
+							continue
+						}
 						end = start - (start-end-1)/step*step
 						for i := end; i <= start; i -= step {
 							v = tv[i]
@@ -683,27 +701,16 @@ func (x Expr) Get(data any) (results []any) {
 				}
 			case Indexed:
 				size := tv.Size()
-				if start < 0 {
-					start = size + start
-					if start < 0 {
-						start = 0
-					}
-				}
-				if end < 0 {
-					end = size + end
-				}
-				if size <= start {
-					continue
-				}
-				if size < end {
-					end = size
-				}
+				start, end = sliceBounds(start, end, step, size, rfc)
 				if 0 < step {
 					if int(fi) == len(x)-1 { // last one
 						for i := start; i < end; i += step {
 							results = append(results, tv.ValueAtIndex(i))
 						}
 					} else {
+						if end <= start {
+							continue
+						}
 						end = start + (end-start-1)/step*step
 						for i := end; start <= i; i -= step {
 							v = tv.ValueAtIndex(i)
@@ -723,14 +730,14 @@ func (x Expr) Get(data any) (results []any) {
 						}
 					}
 				} else {
-					if end < -1 {
-						end = -1
-					}
 					if int(fi) == len(x)-1 { // last one
 						for i := start; end < i; i += step {
 							results = append(results, tv.ValueAtIndex(i))
 						}
 					} else {
+						if start <= end {
+							continue
+						}
 						end = start - (start-end-1)/step*step
 						for i := end; i <= start; i -= step {
 							v = tv.ValueAtIndex(i)
@@ -751,27 +758,16 @@ func (x Expr) Get(data any) (results []any) {
 					}
 				}
 			case gen.Array:
-				if start < 0 {
-					start = len(tv) + start
-					if start < 0 {
-						start = 0
-					}
-				}
-				if end < 0 {
-					end = len(tv) + end
-				}
-				if len(tv) <= start {
-					continue
-				}
+				start, end = sliceBounds(start, end, step, len(tv), rfc)
 				if 0 < step {
-					if len(tv) < end {
-						end = len(tv)
-					}
 					if int(fi) == len(x)-1 { // last one
 						for i := start; i < end; i += step {
 							results = append(results, tv[i])
 						}
 					} else {
+						if end <= start {
+							continue
+						}
 						end = start + (end-start-1)/step*step
 						for i := end; start <= i; i -= step {
 							v = tv[i]
@@ -782,14 +778,14 @@ func (x Expr) Get(data any) (results []any) {
 						}
 					}
 				} else {
-					if end < -1 {
-						end = -1
-					}
 					if int(fi) == len(x)-1 { // last one
 						for i := start; end < i; i += step {
 							results = append(results, tv[i])
 						}
 					} else {
+						if start <= end {
+							continue
+						}
 						end = start - (start-end-1)/step*step
 						for i := end; i <= start; i -= step {
 							v = tv[i]
@@ -801,7 +797,7 @@ func (x Expr) Get(data any) (results []any) {
 					}
 				}
 			default:
-				got := reflectGetSlice(tv, start, end, step)
+				got := reflectGetSlice(tv, start, end, step, rfc)
 				if int(fi) == len(x)-1 { // last one
 					for i := len(got) - 1; 0 <= i; i-- {
 						results = append(results, got[i])
@@ -826,7 +822,7 @@ func (x Expr) Get(data any) (results []any) {
 			}
 		case *Filter:
 			before := len(stack)
-			ns, _ := tf.evalWithRoot(stack, prev, data)
+			ns, _ := tf.evalWithRoot(stack, prev, root)
 			stack, _ = ns.([]any)
 			if int(fi) == len(x)-1 { // last one
 				for i := len(stack) - 1; before <= i; i-- {
@@ -875,6 +871,18 @@ func (x Expr) Get(data any) (results []any) {
 	return
 }
 
+// keepSiblingIndex pushes a copy of the fragment index on the top of the
+// stack if it is shared with siblings of the value being descended into. The
+// descent replaces the top index so the siblings would otherwise be treated
+// as already expanded.
+func keepSiblingIndex(stack *[]any) {
+	if 1 < len(*stack) {
+		if _, ok := (*stack)[len(*stack)-2].(fragIndex); !ok {
+			*stack = append(*stack, (*stack)[len(*stack)-1])
+		}
+	}
+}
+
 // First element of the data identified by the path.
 func (x Expr) First(data any) any {
 	first, _ := x.FirstFound(data)
@@ -886,11 +894,18 @@ func (x Expr) FirstFound(data any) (any, bool) {
 	if len(x) == 0 {
 		return nil, false
 	}
+	if x.hasPathFrag() {
+		if results := x.pathGet(data, 1); 0 < len(results) {
+			return results[0], true
+		}
+		return nil, false
+	}
 	var (
 		v    any
 		prev any
 		has  bool
 	)
+	rfc := x.rfc()
 	stack := make([]any, 0, 64)
 	defer func() {
 		stack = stack[0:cap(stack)]
@@ -1138,6 +1153,7 @@ func (x Expr) FirstFound(data any) (any, bool) {
 			di, _ := stack[len(stack)-1].(fragIndex)
 			// first pass expands, second continues evaluation
 			if (di & descentFlag) == 0 {
+				keepSiblingIndex(&stack)
 				switch tv := prev.(type) {
 				case map[string]any:
 					// Put prev back and slide fi.
@@ -1362,6 +1378,10 @@ func (x Expr) FirstFound(data any) (any, bool) {
 						default:
 							v, has = reflectGetNth(tv, i)
 						}
+					case Frag:
+						if vals := unionFragGet(tu, prev, data); 0 < len(vals) {
+							return vals[0], true
+						}
 					}
 					if has {
 						return v, true
@@ -1413,6 +1433,13 @@ func (x Expr) FirstFound(data any) (any, bool) {
 						default:
 							v, has = reflectGetNth(tv, i)
 						}
+					case Frag:
+						vals := unionFragGet(tu, prev, data)
+						for vi := len(vals) - 1; 0 <= vi; vi-- {
+							if isContainer(vals[vi]) {
+								stack = append(stack, vals[vi])
+							}
+						}
 					}
 					if has {
 						switch v.(type) {
@@ -1449,25 +1476,14 @@ func (x Expr) FirstFound(data any) (any, bool) {
 			}
 			switch tv := prev.(type) {
 			case []any:
-				if start < 0 {
-					start = len(tv) + start
-					if start < 0 {
-						start = 0
-					}
-				}
-				if len(tv) <= start {
-					continue
-				}
-				if end < 0 {
-					end = len(tv) + end
-				}
-				if len(tv) < end {
-					end = len(tv)
-				}
+				start, end = sliceBounds(start, end, step, len(tv), rfc)
 				if 0 < step {
 					if int(fi) == len(x)-1 && start < end { // last one
 						return tv[start], true
 					}
+					if end <= start {
+						continue
+					}
 					end = start + (end-start-1)/step*step
 					for i := end; start <= i; i -= step {
 						v = tv[i]
@@ -1486,12 +1502,12 @@ func (x Expr) FirstFound(data any) (any, bool) {
 						}
 					}
 				} else {
-					if end < -1 {
-						end = -1
-					}
 					if int(fi) == len(x)-1 && end < start { // last one
 						return tv[start], true
 					}
+					if start <= end {
+						continue
+					}
 					end = start - (start-end-1)/step*step
 					for i := end; i <= start; i -= step {
 						v = tv[i]
@@ -1512,25 +1528,14 @@ func (x Expr) FirstFound(data any) (any, bool) {
 				}
 			case Indexed:
 				size := tv.Size()
-				if start < 0 {
-					start = size + start
-					if start < 0 {
-						start = 0
-					}
-				}
-				if size <= start {
-					continue
-				}
-				if end < 0 {
-					end = size + end
-				}
-				if size < end {
-					end = size
-				}
+				start, end = sliceBounds(start, end, step, size, rfc)
 				if 0 < step {
 					if int(fi) == len(x)-1 && start < end { // last one
 						return tv.ValueAtIndex(start), true
 					}
+					if end <= start {
+						continue
+					}
 					end = start + (end-start-1)/step*step
 					for i := end; start <= i; i -= step {
 						v = tv.ValueAtIndex(i)
@@ -1549,12 +1554,12 @@ func (x Expr) FirstFound(data any) (any, bool) {
 						}
 					}
 				} else {
-					if end < -1 {
-						end = -1
-					}
 					if int(fi) == len(x)-1 && end < start { // last one
 						return tv.ValueAtIndex(start), true
 					}
+					if start <= end {
+						continue
+					}
 					end = start - (start-end-1)/step*step
 					for i := end; i <= start; i -= step {
 						v = tv.ValueAtIndex(i)
@@ -1574,25 +1579,14 @@ func (x Expr) FirstFound(data any) (any, bool) {
 					}
 				}
 			case gen.Array:
-				if start < 0 {
-					start = len(tv) + start
-					if start < 0 {
-						start = 0
-					}
-				}
-				if len(tv) <= start {
-					continue
-				}
-				if end < 0 {
-					end = len(tv) + end
-				}
-				if len(tv) < end {
-					end = len(tv)
-				}
+				start, end = sliceBounds(start, end, step, len(tv), rfc)
 				if 0 < step {
 					if int(fi) == len(x)-1 && start < end { // last one
 						return tv[start], true
 					}
+					if end <= start {
+						continue
+					}
 					end = start + (end-start-1)/step*step
 					for i := end; start <= i; i -= step {
 						v = tv[i]
@@ -1602,12 +1596,12 @@ func (x Expr) FirstFound(data any) (any, bool) {
 						}
 					}
 				} else {
-					if end < -1 {
-						end = -1
-					}
 					if int(fi) == len(x)-1 && end < start { // last one
 						return tv[start], true
 					}
+					if start <= end {
+						continue
+					}
 					end = start - (start-end-1)/step*step
 					for i := end; i <= start; i -= step {
 						v = tv[i]
@@ -1939,42 +1933,28 @@ func reflectGetWildOne(data any) (any, bool) {
 	return nil, false
 }
 
-func reflectGetSlice(data any, start, end, step int) (va []any) {
+func reflectGetSlice(data any, start, end, step int, rfc bool) (va []any) {
 	if !isNil(data) {
 		rd := reflect.ValueOf(data)
 		rt := rd.Type()
 		switch rt.Kind() {
 		case reflect.Slice, reflect.Array:
-			size := rd.Len()
-			if start < 0 {
-				start = size + start
-				if start < 0 {
-					start = 0
-				}
-			}
-			if end < 0 {
-				end = size + end
-				if end < -1 {
-					end = -1
-				}
-			}
-			if size < end {
-				end = size
-			}
-			if 0 <= start && start < size {
-				if 0 < step {
-					for i := start; i < end; i += step {
-						rv := rd.Index(i)
-						if rv.CanInterface() {
-							va = append([]any{rv.Interface()}, va...)
-						}
+			start, end = sliceBounds(start, end, step, rd.Len(), rfc)
+			switch {
+			case step == 0:
+				// nothing selected
+			case 0 < step:
+				for i := start; i < end; i += step {
+					rv := rd.Index(i)
+					if rv.CanInterface() {
+						va = append([]any{rv.Interface()}, va...)
 					}
-				} else {
-					for i := start; end < i; i += step {
-						rv := rd.Index(i)
-						if rv.CanInterface() {
-							va = append([]any{rv.Interface()}, va...)
-						}
+				}
+			default:
+				for i := start; end < i; i += step {
+					rv := rd.Index(i)
+					if rv.CanInterface() {
+						va = append([]any{rv.Interface()}, va...)
 					}
 				}
 			}
@@ -1982,3 +1962,20 @@ func reflectGetSlice(data any, start, end, step int) (va []any) {
 	}
 	return
 }
+
+func isContainer(v any) bool {
+	switch v.(type) {
+	case nil, bool, string, float64, float32, gen.Bool, gen.Float, gen.String,
+		int, uint, int8, int16, int32, int64, uint8, uint16, uint32, uint64, gen.Int:
+		return false
+	case map[string]any, []any, gen.Object, gen.Array, Keyed, Indexed:
+		return true
+	}
+	if rt := reflect.TypeOf(v); rt != nil {
+		switch rt.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Struct, reflect.Array, reflect.Map:
+			return true
+		}
+	}
+	return false
+}
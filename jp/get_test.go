@@ -98,6 +98,13 @@ var (
 				"x": 4,
 			},
 		},
+		{path: "$[*]..x",
+			expect: []any{1, 2, 3},
+			data: []any{
+				map[string]any{"x": 1},
+				[]any{map[string]any{"x": 2}, map[string]any{"x": 3}},
+			},
+		},
 		{path: "$..[1].x",
 			expect: []any{42, 200, 500},
 			data: map[string]any{
@@ -333,6 +340,7 @@ var (
 		{path: "..", expect: []any{1}, data: []any{1, 2}},
 		{path: "..a", expect: []any{nil}, data: []any{1, 2}},
 		{path: "..[1]", expect: []any{[]any{2}}, data: []any{1, []any{2}}},
+		{path: "$[*]..x", expect: []any{2}, data: []any{map[string]any{"y": 1}, []any{map[string]any{"x": 2}}}},
 		{path: "a..b", expect: []any{112}},
 		{path: "[0,'a'][-1,'a']['b',1]", expect: []any{2}, data: firstData1},
 		{path: "a[-1:2].b", expect: []any{2}, data: firstData1},
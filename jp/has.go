@@ -14,6 +14,9 @@ func (x Expr) Has(data any) bool {
 	if len(x) == 0 {
 		return false
 	}
+	if x.hasPathFrag() {
+		return 0 < len(x.pathGet(data, 1))
+	}
 	var v any
 	var prev any
 	var has bool
@@ -275,6 +278,7 @@ func (x Expr) Has(data any) bool {
 			di, _ := stack[len(stack)-1].(fragIndex)
 			// first pass expands, second continues evaluation
 			if (di & descentFlag) == 0 {
+				keepSiblingIndex(&stack)
 				switch tv := prev.(type) {
 				case map[string]any:
 					// Put prev back and slide fi.
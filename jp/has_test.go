@@ -27,6 +27,7 @@ var (
 		{path: "$.a.*.b", expect: true, data: firstData1},
 		{path: "@.a[0].b", expect: true, data: firstData1},
 		{path: "..[0].b", expect: true, data: firstData1},
+		{path: "$[*]..x", expect: true, data: []any{map[string]any{"y": 1}, []any{map[string]any{"x": 2}}}},
 		{path: "[-1]", expect: true, data: []any{1, 2}},
 		{path: "[1,'a']", expect: true, data: []any{1, 2}},
 		{path: "[:2]", expect: true, data: []any{1, 2}},
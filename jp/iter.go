@@ -0,0 +1,100 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+//go:build go1.23
+
+package jp
+
+import "iter"
+
+// stopWalk is panicked to unwind a walk when an iterator consumer stops.
+type stopWalk struct{}
+
+// selfDescent replaces a Descent when iterating so that, like Get(), the
+// current element is included along with all the descendants. The tail is
+// the expression starting with the selfDescent.
+type selfDescent struct {
+	Descent
+	tail Expr
+}
+
+// Walk the current element and then each element below it.
+func (f *selfDescent) Walk(rest, path Expr, nodes []any, cb func(path Expr, nodes []any)) {
+	if 0 < len(rest) {
+		rest[0].Walk(rest[1:], path, nodes, cb)
+	} else {
+		cb(path, nodes)
+	}
+	wildWalk(f.tail, path, nodes, cb, nil)
+}
+
+// All returns an iterator over the matches of the expression in data. Each
+// iteration yields the normalized path to the match and the matched
+// value. Matches are found as the iterator advances so breaking out of a
+// range loop stops the evaluation without visiting the rest of the data.
+//
+//	for path, value := range x.All(data) {
+//	    fmt.Println(path, value)
+//	}
+func (x Expr) All(data any) iter.Seq2[Expr, any] {
+	return func(yield func(Expr, any) bool) {
+		x.walkWhile(data, func(path Expr, value any) bool {
+			return yield(append(Expr{}, path...), value)
+		})
+	}
+}
+
+// Values returns an iterator over the values that match the expression in
+// data. Like All() the matches are found as the iterator advances.
+func (x Expr) Values(data any) iter.Seq[any] {
+	return func(yield func(any) bool) {
+		x.walkWhile(data, func(_ Expr, value any) bool {
+			return yield(value)
+		})
+	}
+}
+
+// walkWhile walks the matches of the expression until the cb function
+// returns false. The path passed to cb is reused.
+func (x Expr) walkWhile(data any, cb func(path Expr, value any) bool) {
+	if len(x) == 0 {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(stopWalk); !ok {
+				panic(r)
+			}
+		}
+	}()
+	var path Expr
+	switch x[0].(type) {
+	case Root, At:
+		path = Expr{x[0]}
+	}
+	x = x.iterable()
+	x[0].Walk(x[1:], path, []any{data}, func(p Expr, nodes []any) {
+		if !cb(p, nodes[len(nodes)-1]) {
+			panic(stopWalk{})
+		}
+	})
+}
+
+// iterable returns the expression with each Descent replaced by a
+// selfDescent. The original expression is returned if there are none.
+func (x Expr) iterable() Expr {
+	var ix Expr
+	for i, f := range x {
+		if d, ok := f.(Descent); ok {
+			if ix == nil {
+				ix = append(Expr{}, x...)
+			}
+			sd := &selfDescent{Descent: d}
+			ix[i] = sd
+			sd.tail = ix[i:]
+		}
+	}
+	if ix == nil {
+		return x
+	}
+	return ix
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+//go:build go1.23
+
+package jp_test
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestExprValues(t *testing.T) {
+	data := buildTree(4, 3, 0)
+	for i, d := range getTestData {
+		if testing.Verbose() {
+			fmt.Printf("... %d: %s\n", i, d.path)
+		}
+		x := jp.MustParseString(d.path)
+		src := d.data
+		if src == nil {
+			src = data
+		}
+		results := []any{}
+		for v := range x.Values(src) {
+			results = append(results, v)
+		}
+		sort.Slice(results, func(i, j int) bool {
+			iv, _ := results[i].(int)
+			jv, _ := results[j].(int)
+			return iv < jv
+		})
+		tt.Equal(t, d.expect, results, i, " : ", x)
+	}
+}
+
+func TestExprValuesReflect(t *testing.T) {
+	x := jp.MustParseString("$.x[*].c[?(@.a==true)].b")
+	data := Any{X: []*NestedSample{{C: &[]NestedSample{{A: ptr(false), B: ptr("1")}, {A: ptr(true), B: ptr("2")}}}}}
+	var results []any
+	for v := range x.Values(data) {
+		results = append(results, v)
+	}
+	tt.Equal(t, x.Get(data), results)
+}
+
+func TestExprAll(t *testing.T) {
+	data := buildTree(4, 3, 0)
+	for _, src := range []string{
+		"$.a.*.b",
+		"@.b[1].c",
+		"$..[1].b",
+		"a[1::2].a",
+		"$.a[?(@.a > 125)].b",
+		"$['a','b'][1,2].c",
+		"$..c",
+	} {
+		x := jp.MustParseString(src)
+		var paths []string
+		for path, value := range x.All(data) {
+			paths = append(paths, path.String())
+			tt.Equal(t, []any{value}, path.Get(data), path)
+		}
+		var locs []string
+		for _, loc := range x.Locate(data, 0) {
+			locs = append(locs, loc.String())
+		}
+		sort.Strings(paths)
+		sort.Strings(locs)
+		tt.Equal(t, locs, paths, src)
+	}
+}
+
+func TestExprAllBreak(t *testing.T) {
+	data := buildTree(4, 3, 0)
+	for _, src := range []string{"$..*", "$..[?(@.b > 0)]", "$.*[*]", "$.*[1,2]"} {
+		x := jp.MustParseString(src)
+		cnt := 0
+		for range x.All(data) {
+			cnt++
+			if cnt == 3 {
+				break
+			}
+		}
+		tt.Equal(t, 3, cnt, src)
+
+		cnt = 0
+		for range x.Values(data) {
+			cnt++
+			if cnt == 2 {
+				break
+			}
+		}
+		tt.Equal(t, 2, cnt, src)
+	}
+	var paths []jp.Expr
+	for path := range jp.C("a").W().All(data) {
+		paths = append(paths, path)
+	}
+	// Paths must not be reused across iterations.
+	tt.Equal(t, "a[0] a[1] a[2] a[3]", fmt.Sprintf("%s %s %s %s", paths[0], paths[1], paths[2], paths[3]))
+
+	// Panics in the loop body are not swallowed.
+	defer func() {
+		tt.Equal(t, "boom", recover())
+	}()
+	for range jp.C("a").W().All(data) {
+		panic("boom")
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp
+
+import (
+	"github.com/ohler55/ojg/gen"
+)
+
+// KeyName is a ~ in a JSON path representation that selects the member
+// name or array index of the current element instead of the element
+// itself. It must be the last fragment in an expression. The normalized
+// path of a key name is the path to the element followed by the KeyName
+// fragment.
+type KeyName byte
+
+// Append a fragment string representation of the fragment to the buffer
+// then returning the expanded buffer.
+func (f KeyName) Append(buf []byte, bracket, first bool) []byte {
+	return append(buf, '~')
+}
+
+func (f KeyName) locate(pp Expr, data, root any, rest Expr, max int) (locs []Expr) {
+	if len(rest) == 0 && keyOf(pp) != nil {
+		loc := make(Expr, len(pp)+1)
+		copy(loc, pp)
+		loc[len(pp)] = f
+		locs = []Expr{loc}
+	}
+	return
+}
+
+// Walk calls cb with the last node replaced by the member name or index of
+// the current element.
+func (f KeyName) Walk(rest, path Expr, nodes []any, cb func(path Expr, nodes []any)) {
+	if 0 < len(rest) {
+		return
+	}
+	if 0 < len(path) && 1 < len(nodes) {
+		path = append(append(Expr{}, path[:len(path)-1]...), absNth(nodes[len(nodes)-2], path[len(path)-1]))
+	}
+	if key := keyOf(path); key != nil {
+		nodes = append(append([]any{}, nodes[:len(nodes)-1]...), key)
+		cb(append(path, f), nodes)
+	}
+}
+
+// keyOf returns the member name or array index of the last element of the
+// path or nil if the path does not end with a Child or Nth.
+func keyOf(path Expr) any {
+	if 0 < len(path) {
+		switch tf := path[len(path)-1].(type) {
+		case Child:
+			return string(tf)
+		case Nth:
+			return int64(tf)
+		}
+	}
+	return nil
+}
+
+// hasPathFrag returns true if the expression includes a Parent or KeyName
+// fragment. Those fragments require the path to the current element so
+// the expression is evaluated using locate().
+func (x Expr) hasPathFrag() bool {
+	for _, f := range x {
+		if pathFrag(f) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathGet evaluates an expression that includes Parent or KeyName
+// fragments by locating the matches and then getting the value at each
+// location.
+func (x Expr) pathGet(data any, max int) (results []any) {
+	for _, loc := range x.Locate(data, max) {
+		if _, ok := loc[len(loc)-1].(KeyName); ok {
+			results = append(results, keyOf(loc[:len(loc)-1]))
+			continue
+		}
+		if v, has := locValue(loc, data); has {
+			results = append(results, v)
+		}
+	}
+	return
+}
+
+// pathGetNodes is the gen.Node version of pathGet.
+func (x Expr) pathGetNodes(data gen.Node, max int) (results []gen.Node) {
+	for _, v := range x.pathGet(data, max) {
+		switch tv := v.(type) {
+		case string:
+			results = append(results, gen.String(tv))
+		case int64:
+			results = append(results, gen.Int(tv))
+		case gen.Node:
+			results = append(results, tv)
+		case nil:
+			results = append(results, nil)
+		}
+	}
+	return
+}
+
+func locValue(loc Expr, data any) (any, bool) {
+	for 0 < len(loc) {
+		switch loc[0].(type) {
+		case Root, At:
+			loc = loc[1:]
+			continue
+		}
+		break
+	}
+	if len(loc) == 0 {
+		return data, true
+	}
+	return loc.FirstFound(data)
+}
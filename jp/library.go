@@ -0,0 +1,230 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ohler55/ojg/gen"
+)
+
+// RegisterStandardFunctions registers a library of script functions. The
+// functions are not available until this function is called. Functions
+// return Nothing when called with arguments of the wrong type.
+//
+// String functions:
+//
+//	lower(s)             lowercase of s
+//	upper(s)             uppercase of s
+//	trim(s)              s without leading and trailing white space
+//	starts_with(s, pre)  true if s starts with pre
+//	ends_with(s, suf)    true if s ends with suf
+//	contains(s, v)       true if the string s contains the string v or if
+//	                     the array s has a member equal to v
+//
+// Math functions:
+//
+//	abs(n)               absolute value of n
+//	floor(n)             largest integer value less than or equal to n
+//	ceil(n)              smallest integer value greater than or equal to n
+//	round(n)             nearest integer value rounding half away from zero
+//	min(a, ...)          smallest of the arguments or array members
+//	max(a, ...)          largest of the arguments or array members
+//
+// Type functions:
+//
+//	type_of(v)           one of "null", "boolean", "number", "string",
+//	                     "array", or "object"
+//	is_number(v)         true if v is a number
+//	is_string(v)         true if v is a string
+//	is_boolean(v)        true if v is a boolean
+//	is_array(v)          true if v is an array
+//	is_object(v)         true if v is an object
+//
+// Time functions return the number of nanoseconds since 1970-01-01 UTC so
+// that times can be compared with the usual operators:
+//
+//	time(v)              v as a time where v is an RFC 3339 string, a date
+//	                     such as 2024-03-15, or a number of seconds
+//	time(s, layout)      s parsed with a Go time layout
+//	now()                the current time
+//
+// The built in value() function can be used to coerce a single member
+// node list such as value(@..x) into a value.
+func RegisterStandardFunctions() {
+	RegisterUnaryFunction("lower", false, stringFunc(strings.ToLower))
+	RegisterUnaryFunction("upper", false, stringFunc(strings.ToUpper))
+	RegisterUnaryFunction("trim", false, stringFunc(strings.TrimSpace))
+	RegisterBinaryFunction("starts_with", false, false, stringTestFunc(strings.HasPrefix))
+	RegisterBinaryFunction("ends_with", false, false, stringTestFunc(strings.HasSuffix))
+	RegisterBinaryFunction("contains", false, false, containsFunc)
+
+	RegisterUnaryFunction("abs", false, absFunc)
+	RegisterUnaryFunction("floor", false, floatFunc(math.Floor))
+	RegisterUnaryFunction("ceil", false, floatFunc(math.Ceil))
+	RegisterUnaryFunction("round", false, floatFunc(math.Round))
+	RegisterVariadicFunction("min", 1, -1, extremeFunc(-1))
+	RegisterVariadicFunction("max", 1, -1, extremeFunc(1))
+
+	RegisterUnaryFunction("type_of", false, typeOfFunc)
+	RegisterUnaryFunction("is_number", false, isTypeFunc("number"))
+	RegisterUnaryFunction("is_string", false, isTypeFunc("string"))
+	RegisterUnaryFunction("is_boolean", false, isTypeFunc("boolean"))
+	RegisterUnaryFunction("is_array", false, isTypeFunc("array"))
+	RegisterUnaryFunction("is_object", false, isTypeFunc("object"))
+
+	RegisterVariadicFunction("time", 1, 2, timeFunc)
+	RegisterVariadicFunction("now", 0, 0, func(args []any) any { return time.Now().UnixNano() })
+}
+
+func stringFunc(f func(string) string) func(arg any) any {
+	return func(arg any) any {
+		if s, ok := arg.(string); ok {
+			return f(s)
+		}
+		return Nothing
+	}
+}
+
+func stringTestFunc(f func(s, affix string) bool) func(left, right any) any {
+	return func(left, right any) any {
+		if s, ok := left.(string); ok {
+			if affix, ok := right.(string); ok {
+				return f(s, affix)
+			}
+		}
+		return Nothing
+	}
+}
+
+func containsFunc(left, right any) any {
+	switch tl := left.(type) {
+	case string:
+		if sub, ok := right.(string); ok {
+			return strings.Contains(tl, sub)
+		}
+	case []any:
+		for _, v := range tl {
+			if sameValue(normalize(v), right) {
+				return true
+			}
+		}
+		return false
+	case gen.Array:
+		for _, v := range tl {
+			if sameValue(normalize(v), right) {
+				return true
+			}
+		}
+		return false
+	}
+	return Nothing
+}
+
+func absFunc(arg any) any {
+	switch ta := arg.(type) {
+	case int64:
+		if ta < 0 {
+			return -ta
+		}
+		return ta
+	case float64:
+		return math.Abs(ta)
+	}
+	return Nothing
+}
+
+func floatFunc(f func(float64) float64) func(arg any) any {
+	return func(arg any) any {
+		switch ta := arg.(type) {
+		case int64:
+			return ta
+		case float64:
+			return f(ta)
+		}
+		return Nothing
+	}
+}
+
+// extremeFunc returns a function that returns the argument that compares
+// in the direction of dir to all the others. Array arguments contribute
+// their members.
+func extremeFunc(dir int) func(args []any) any {
+	return func(args []any) any {
+		var values []any
+		for _, arg := range args {
+			switch ta := arg.(type) {
+			case []any:
+				for _, v := range ta {
+					values = append(values, normalize(v))
+				}
+			case gen.Array:
+				for _, v := range ta {
+					values = append(values, normalize(v))
+				}
+			default:
+				values = append(values, ta)
+			}
+		}
+		var result any = Nothing
+		rank := 0
+		for _, v := range values {
+			r := valueRank(v)
+			switch {
+			case r != 3 && r != 4:
+				return Nothing
+			case result == Nothing:
+				result = v
+				rank = r
+			case r != rank:
+				return Nothing
+			case compareValues(v, result) == dir:
+				result = v
+			}
+		}
+		return result
+	}
+}
+
+func typeOfFunc(arg any) any {
+	if arg == Nothing {
+		return Nothing
+	}
+	return kindName(arg)
+}
+
+func isTypeFunc(kind string) func(arg any) any {
+	return func(arg any) any {
+		return arg != Nothing && kindName(arg) == kind
+	}
+}
+
+func timeFunc(args []any) any {
+	if len(args) == 2 {
+		s, ok := args[0].(string)
+		layout, ok2 := args[1].(string)
+		if ok && ok2 {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t.UnixNano()
+			}
+		}
+		return Nothing
+	}
+	switch ta := args[0].(type) {
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+			if t, err := time.Parse(layout, ta); err == nil {
+				return t.UnixNano()
+			}
+		}
+	case int64:
+		return ta * int64(time.Second)
+	case float64:
+		return int64(ta * float64(time.Second))
+	case time.Time:
+		return ta.UnixNano()
+	}
+	return Nothing
+}
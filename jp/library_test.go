@@ -0,0 +1,139 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ohler55/ojg/gen"
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/oj"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestScriptStandardFunctions(t *testing.T) {
+	jp.RegisterStandardFunctions()
+	data := sen.MustParse([]byte(`[
+  {name: " Apple " tags: [red fruit] price: -1.5 count: 3 when: "2024-03-15T10:00:00Z"}
+  {name: banana tags: [yellow fruit] price: 2.5 count: 12 when: "2023-12-01"}
+  {name: Carrot tags: [orange] price: 0.75 count: 7 when: 1700000000}
+  {name: 17 tags: {} price: null count: "x"}
+]`))
+	// alt.Generify() drops null members so parse to get the gen data.
+	gdata, err := (&gen.Parser{}).Parse([]byte(oj.JSON(data)))
+	tt.Nil(t, err)
+	for _, d := range []struct {
+		src    string
+		expect string
+	}{
+		{src: `$[?(lower(@.name) == 'carrot')].count`, expect: "[7]"},
+		{src: `$[?(upper(@.name) == 'BANANA')].count`, expect: "[12]"},
+		{src: `$[?(trim(@.name) == 'Apple')].count`, expect: "[3]"},
+		{src: `$[?(starts_with(@.name, 'ba'))].count`, expect: "[12]"},
+		{src: `$[?(ends_with(lower(@.name), 'ot'))].count`, expect: "[7]"},
+		{src: `$[?(contains(@.name, 'an'))].count`, expect: "[12]"},
+		{src: `$[?(contains(@.tags, 'fruit'))].count`, expect: "[3,12]"},
+		{src: `$[?(abs(@.price) > 1)].count`, expect: "[3,12]"},
+		{src: `$[?(floor(@.price) == -2)].count`, expect: "[3]"},
+		{src: `$[?(ceil(@.price) == 1)].count`, expect: "[7]"},
+		{src: `$[?(round(@.price) == 3)].count`, expect: "[12]"},
+		{src: `$[?(round(@.count) == 7)].count`, expect: "[7]"},
+		{src: `$[?(max(@.count, 5) == 5)].count`, expect: "[3]"},
+		{src: `$[?(min(@.count, 5, 8) == 5)].count`, expect: "[12,7]"},
+		{src: `$[?(max(@.tags) == 'yellow')].count`, expect: "[12]"},
+		{src: `$[?(type_of(@.tags) == 'object')].count`, expect: `["x"]`},
+		{src: `$[?(type_of(@.price) == 'null')].count`, expect: `["x"]`},
+		{src: `$[?(is_number(@.name))].count`, expect: `["x"]`},
+		{src: `$[?(is_string(@.count))].count`, expect: `["x"]`},
+		{src: `$[?(is_array(@.tags) && is_boolean(is_object(@.tags)))].count`, expect: "[3,12,7]"},
+		{src: `$[?(time(@.when) < time('2024-01-01T00:00:00Z'))].count`, expect: "[12,7]"},
+		{src: `$[?(time(@.when) > time('15/03/2024', '02/01/2006'))].count`, expect: "[3]"},
+		{src: `$[?(time(@.when) < now())].count`, expect: "[3,12,7]"},
+		{src: `$[?(type_of(time(@.when)) == 'number')].count`, expect: "[3,12,7]"},
+	} {
+		x := jp.MustParseString(d.src)
+		tt.Equal(t, d.expect, oj.JSON(x.Get(data)), "%s", d.src)
+		tt.Equal(t, d.expect, oj.JSON(x.Get(gdata)), "gen %s", d.src)
+	}
+}
+
+func TestScriptStandardFunctionsValues(t *testing.T) {
+	jp.RegisterStandardFunctions()
+	when := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+	data := map[string]any{"a": 3, "b": -4.5, "t": when, "s": "abc"}
+	for _, d := range []struct {
+		src    string
+		expect any
+	}{
+		{src: "abs(@.b)", expect: 4.5},
+		{src: "abs(@.a - 5)", expect: int64(2)},
+		{src: "abs(@.s)", expect: jp.Nothing},
+		{src: "floor(@.b)", expect: -5.0},
+		{src: "min(@.a, @.b, 7)", expect: -4.5},
+		{src: "max(@.a, @.b, 7)", expect: int64(7)},
+		{src: "max(@.a, @.s)", expect: jp.Nothing},
+		{src: "max(@.missing)", expect: jp.Nothing},
+		{src: "lower(@.a)", expect: jp.Nothing},
+		{src: "contains(@.a, 3)", expect: jp.Nothing},
+		{src: "type_of(@.missing)", expect: jp.Nothing},
+		{src: "time(@.t)", expect: when.UnixNano()},
+		{src: "time(@.s)", expect: jp.Nothing},
+		{src: "time(@.s, 'x')", expect: jp.Nothing},
+	} {
+		result := jp.NewQuery(jp.R()).Project(map[string]*jp.Equation{"v": jp.MustParseEquation(d.src)}).Get(data)
+		v, has := result[0].(map[string]any)["v"]
+		if d.expect == jp.Nothing {
+			tt.Equal(t, false, has, "%s", d.src)
+		} else {
+			tt.Equal(t, d.expect, v, "%s", d.src)
+		}
+	}
+}
+
+func TestScriptRegisterVariadicFunction(t *testing.T) {
+	jp.RegisterVariadicFunction("join", 0, -1, func(args []any) any {
+		var s string
+		for _, arg := range args {
+			s += fmt.Sprint(arg)
+		}
+		return s
+	})
+	s := jp.MustNewScript("join(@.x, 'y', 3) == 'xy3'")
+	tt.Equal(t, "(join(@.x, 'y', 3) == 'xy3')", s.String())
+	tt.Equal(t, true, s.Match(map[string]any{"x": "x"}))
+	tt.Equal(t, "(join(@.x, 'y', 3) == 'xy3')", jp.MustParseEquation("join(@.x, 'y', 3) == 'xy3'").String())
+
+	s = jp.MustNewScript("join() == ''")
+	tt.Equal(t, "(join() == '')", s.String())
+	tt.Equal(t, true, s.Match(map[string]any{}))
+
+	s = jp.MustNewScript("join((1 + 2) * 3, @.x) == '9x'")
+	tt.Equal(t, true, s.Match(map[string]any{"x": "x"}))
+
+	f := jp.MustNewScript("join(@.x, 1)").Inspect()
+	tt.Equal(t, "join", f.Op)
+	tt.Equal(t, 2, len(f.Args))
+	tt.Equal(t, `{args:[@.x 1] op:join}`, sen.String(f.Simplify(), &sen.Options{Sort: true}))
+
+	jp.RegisterVariadicFunction("pair", 2, 2, func(args []any) any { return args[0] })
+	_, err := jp.NewScript("pair(1)")
+	tt.NotNil(t, err)
+	_, err = jp.NewScript("pair(1, 2, 3)")
+	tt.NotNil(t, err)
+	_, err = jp.NewScript("pair(1, 2")
+	tt.NotNil(t, err)
+
+	tt.Panic(t, func() { jp.RegisterVariadicFunction("length", 0, 1, func(args []any) any { return nil }) })
+}
+
+func TestScriptUserFunctionString(t *testing.T) {
+	jp.RegisterBinaryFunction("both_set", false, false, func(left, right any) any {
+		return left != jp.Nothing && right != jp.Nothing
+	})
+	eq := jp.MustParseEquation("both_set(@.x, @.y) && true")
+	tt.Equal(t, "(both_set(@.x, @.y) && true)", eq.String())
+	tt.Equal(t, true, eq.Script().Match(map[string]any{"x": 1, "y": 2}))
+}
@@ -13,28 +13,31 @@ import (
 // specified. A max of 0 or less indicates there is no maximum.
 func (x Expr) Locate(data any, max int) (locs []Expr) {
 	if 0 < len(x) {
-		locs = x[0].locate(nil, data, x[1:], max)
+		locs = x[0].locate(nil, data, data, x[1:], max)
 	}
 	return
 }
 
-func locateNthChildHas(pp Expr, f Frag, v any, rest Expr, max int) (locs []Expr) {
+func locateNthChildHas(pp Expr, f Frag, v, root any, rest Expr, max int) (locs []Expr) {
 	if len(rest) == 0 { // last one
 		loc := make(Expr, len(pp)+1)
 		copy(loc, pp)
 		loc[len(pp)] = f
 		locs = []Expr{loc}
 	} else {
+		if pathFrag(rest[0]) {
+			return rest[0].locate(append(pp, f), v, root, rest[1:], max)
+		}
 		switch v.(type) {
 		case nil, bool, string, float64, float32, gen.Bool, gen.Float, gen.String,
 			int, uint, int8, int16, int32, int64, uint8, uint16, uint32, uint64, gen.Int:
 		case map[string]any, []any, gen.Object, gen.Array, Keyed, Indexed:
-			locs = rest[0].locate(append(pp, f), v, rest[1:], max)
+			locs = rest[0].locate(append(pp, f), v, root, rest[1:], max)
 		default:
 			if rt := reflect.TypeOf(v); rt != nil {
 				switch rt.Kind() {
 				case reflect.Ptr, reflect.Slice, reflect.Struct, reflect.Array, reflect.Map:
-					locs = rest[0].locate(append(pp, f), v, rest[1:], max)
+					locs = rest[0].locate(append(pp, f), v, root, rest[1:], max)
 				}
 			}
 		}
@@ -50,23 +53,36 @@ func locateAppendFrag(locs []Expr, pp Expr, f Frag) []Expr {
 	return append(locs, loc)
 }
 
-func locateContinueFrag(locs []Expr, cp Expr, v any, rest Expr, max int) []Expr {
+func locateContinueFrag(locs []Expr, cp Expr, v, root any, rest Expr, max int) []Expr {
 	mx := max
 	if 0 < max {
 		mx = max - len(locs)
 	}
+	if pathFrag(rest[0]) {
+		return append(locs, rest[0].locate(cp, v, root, rest[1:], mx)...)
+	}
 	switch v.(type) {
 	case nil, bool, string, float64, float32, gen.Bool, gen.Float, gen.String,
 		int, uint, int8, int16, int32, int64, uint8, uint16, uint32, uint64, gen.Int:
 	case map[string]any, []any, gen.Object, gen.Array, Keyed, Indexed:
-		locs = append(locs, rest[0].locate(cp, v, rest[1:], mx)...)
+		locs = append(locs, rest[0].locate(cp, v, root, rest[1:], mx)...)
 	default:
 		if rt := reflect.TypeOf(v); rt != nil {
 			switch rt.Kind() {
 			case reflect.Ptr, reflect.Slice, reflect.Struct, reflect.Array, reflect.Map:
-				locs = append(locs, rest[0].locate(cp, v, rest[1:], mx)...)
+				locs = append(locs, rest[0].locate(cp, v, root, rest[1:], mx)...)
 			}
 		}
 	}
 	return locs
 }
+
+// pathFrag returns true if the fragment selects based on the path instead
+// of the value so it applies to leaf values as well as containers.
+func pathFrag(f Frag) bool {
+	switch f.(type) {
+	case Parent, KeyName:
+		return true
+	}
+	return false
+}
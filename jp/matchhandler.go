@@ -10,21 +10,52 @@ import (
 // match search.
 type TargetRest struct {
 	Target Expr
-	// Rest is set when a Filter is included in the initializing target. Since
-	// Filters can only be evaluated when there is data for the evaluation a
-	// traget with a Filter is split with the pre-filter portion and the rest
-	// starting with the filter.
+	// Rest is set when a fragment that can only be evaluated with data is
+	// included in the initializing target. Those fragments are filters,
+	// negative indexes, slices, and unions that are not limited to keys and
+	// non-negative indexes. A target with one of those fragments is split
+	// with the portion before the fragment as the Target and the rest
+	// starting with the fragment.
 	Rest Expr
 }
 
 // PathHandler is a TokenHandler compatible with both the oj.TokenHandler and
 // the sen.TokenHandler. Fields are public to allow derived types to access
 // those fields.
+//
+// Values are only built for the elements that might match a target so that
+// documents much larger than memory can be searched. A filter is evaluated
+// on each candidate element once that element is complete. A negative index
+// or a slice that depends on the length of an array keeps only as many
+// elements as are needed to determine the match. Slices with a negative step
+// keep all the elements of the array. Since the root of the document is not
+// available when streaming, filters that reference the root ($) are
+// evaluated against a nil root. For the same reason Parent (^) fragments
+// never match.
 type MatchHandler struct {
 	Targets []*TargetRest
 	Path    Expr
 	Stack   []any
 	OnData  func(path Expr, data any)
+
+	states map[matchKey]*matchState
+}
+
+type matchKey struct {
+	target *TargetRest
+	depth  int
+}
+
+type matchEntry struct {
+	index int
+	path  Expr
+	value any
+}
+
+// matchState holds the candidate elements of an array for a target with a
+// negative index or slice.
+type matchState struct {
+	entries []*matchEntry
 }
 
 // NewMatchHandler creates a new MatchHandler.
@@ -36,7 +67,13 @@ func NewMatchHandler(onData func(path Expr, data any), targets ...Expr) *MatchHa
 	for _, target := range targets {
 		tr := TargetRest{Target: target}
 		for i, f := range target {
-			if _, ok := f.(*Filter); ok {
+			if i == 0 {
+				switch f.(type) {
+				case Root, At:
+					continue
+				}
+			}
+			if needsData(f) {
 				tr.Rest = target[i:]
 				tr.Target = target[:i]
 				break
@@ -113,9 +150,8 @@ func (h *MatchHandler) AddValue(v any) {
 		case []any:
 			h.Stack[len(h.Stack)-1] = append(ts, v)
 		}
-	} else if h.pathMatch(true) {
-		h.OnData(h.Path, v)
 	}
+	h.complete(v)
 	h.incNth()
 }
 
@@ -128,20 +164,29 @@ func (h *MatchHandler) objArrayStart(v any, frag Frag) {
 			h.Stack[len(h.Stack)-1] = append(ts, v)
 		}
 		h.Stack = append(h.Stack, v)
-	} else if h.pathMatch(false) {
+	} else if h.wanted() {
 		h.Stack = append(h.Stack, v)
 	}
+	if _, ok := frag.(Nth); ok {
+		for _, tr := range h.Targets {
+			if tr.Rest != nil && isIndexFrag(tr.Rest[0]) && exactPathMatch(tr.Target, h.Path) {
+				if h.states == nil {
+					h.states = map[matchKey]*matchState{}
+				}
+				h.states[matchKey{target: tr, depth: len(h.Path)}] = &matchState{}
+			}
+		}
+	}
 	h.Path = append(h.Path, frag)
 }
 
 func (h *MatchHandler) objArrayEnd() {
+	last := h.Path[len(h.Path)-1]
 	h.Path = h.Path[:len(h.Path)-1]
+	if size, ok := last.(Nth); ok {
+		h.finish(int(size))
+	}
 	if 0 < len(h.Stack) {
-		if len(h.Stack) == 1 {
-			if v, p, ok := h.checkRest(h.Stack[0]); ok {
-				h.OnData(p, v)
-			}
-		}
 		v := h.Stack[len(h.Stack)-1]
 		h.Stack = h.Stack[:len(h.Stack)-1]
 		if 0 < len(h.Stack) {
@@ -152,6 +197,7 @@ func (h *MatchHandler) objArrayEnd() {
 				ts[h.Path[len(h.Path)-1].(Nth)] = v
 			}
 		}
+		h.complete(v)
 	}
 	h.incNth()
 }
@@ -164,33 +210,290 @@ func (h *MatchHandler) incNth() {
 	}
 }
 
-func (h *MatchHandler) checkRest(v any) (any, Expr, bool) {
-	var tr *TargetRest
-	for _, t := range h.Targets {
-		if PathMatch(t.Target, h.Path) {
-			tr = t
-			break
+// wanted returns true if the value at the current path is needed to
+// evaluate any of the targets.
+func (h *MatchHandler) wanted() bool {
+	for _, tr := range h.Targets {
+		if tr.Rest == nil || !isElementFrag(tr.Rest[0]) {
+			if exactPathMatch(tr.Target, h.Path) {
+				return true
+			}
+		} else if h.candidate(tr) {
+			return true
+		}
+	}
+	return false
+}
+
+// candidate returns true if the value at the current path is an element
+// that could be selected by the first fragment of the target rest.
+func (h *MatchHandler) candidate(tr *TargetRest) bool {
+	last := len(h.Path) - 1
+	if last < 1 || !exactPathMatch(tr.Target, h.Path[:last]) {
+		return false
+	}
+	switch tf := tr.Rest[0].(type) {
+	case *Filter:
+		return true
+	case Nth:
+		_, ok := h.Path[last].(Nth)
+		return ok
+	case Slice:
+		i, ok := h.Path[last].(Nth)
+		if !ok {
+			return false
+		}
+		start, end, step := sliceParams(tf)
+		if 0 < step && 0 <= start && 0 <= end {
+			return start <= int(i) && int(i) < end && (int(i)-start)%step == 0
 		}
+		return step != 0
 	}
-	p := h.Path
-	if tr != nil && tr.Rest != nil {
-		locs := tr.Rest.Locate(v, 1)
-		if len(locs) == 0 {
-			return nil, p, false
+	return false
+}
+
+// complete is called when the value at the current path is complete.
+func (h *MatchHandler) complete(v any) {
+	var matched bool
+	for _, tr := range h.Targets {
+		switch {
+		case tr.Rest == nil:
+			if !matched && exactPathMatch(tr.Target, h.Path) {
+				h.OnData(h.Path, v)
+				matched = true
+			}
+		case !isElementFrag(tr.Rest[0]):
+			if exactPathMatch(tr.Target, h.Path) {
+				h.emitRest(h.Path, v, tr.Rest)
+			}
+		case h.candidate(tr):
+			h.pick(tr, v)
 		}
-		p = append(p, locs[0]...)
-		v = tr.Rest.First(v)
 	}
-	return v, p, true
 }
 
-func (h *MatchHandler) pathMatch(leaf bool) bool {
+// pick handles a completed candidate element.
+func (h *MatchHandler) pick(tr *TargetRest, v any) {
+	switch tf := tr.Rest[0].(type) {
+	case *Filter:
+		if ns, _ := tf.evalWithRoot([]any{}, []any{v}, nil); 0 < len(ns.([]any)) {
+			h.emitRest(h.Path, v, tr.Rest[1:])
+		}
+		return
+	case Slice:
+		start, end, step := sliceParams(tf)
+		if 0 < step && 0 <= start && 0 <= end {
+			// Already known to be in the slice so no need to wait.
+			h.emitRest(h.Path, v, tr.Rest[1:])
+			return
+		}
+	}
+	st := h.states[matchKey{target: tr, depth: len(h.Path) - 1}]
+	if st == nil {
+		return
+	}
+	i := int(h.Path[len(h.Path)-1].(Nth))
+	st.entries = append(st.entries, &matchEntry{index: i, path: append(Expr{}, h.Path...), value: v})
+	switch tf := tr.Rest[0].(type) {
+	case Nth:
+		if int(-tf) < len(st.entries) {
+			st.entries = st.entries[1:]
+		}
+	case Slice:
+		start, end, step := sliceParams(tf)
+		switch {
+		case step < 0:
+			// Keep all since the length is needed to pick the elements.
+		case start < 0:
+			if -start < len(st.entries) {
+				st.entries = st.entries[1:]
+			}
+		default: // 0 <= start and end < 0
+			// An element is known to be before the end of the slice once
+			// enough elements follow it.
+			for 0 < len(st.entries) && st.entries[0].index-end <= i {
+				e := st.entries[0]
+				st.entries = st.entries[1:]
+				if start <= e.index && (e.index-start)%step == 0 {
+					h.emitRest(e.path, e.value, tr.Rest[1:])
+				}
+			}
+		}
+	}
+}
+
+// finish is called when the array at the current path ends.
+func (h *MatchHandler) finish(size int) {
+	if len(h.states) == 0 {
+		return
+	}
 	for _, tr := range h.Targets {
-		if PathMatch(tr.Target, h.Path) {
-			if !leaf || tr.Rest == nil {
+		key := matchKey{target: tr, depth: len(h.Path)}
+		st := h.states[key]
+		if st == nil {
+			continue
+		}
+		delete(h.states, key)
+		byIndex := make(map[int]*matchEntry, len(st.entries))
+		for _, e := range st.entries {
+			byIndex[e.index] = e
+		}
+		var indexes []int
+		switch tf := tr.Rest[0].(type) {
+		case Nth:
+			indexes = append(indexes, size+int(tf))
+		case Slice:
+			start, end, step := tf.startEndStep(size)
+			if 0 < step {
+				for i := start; i < end; i += step {
+					indexes = append(indexes, i)
+				}
+			} else if step < 0 {
+				for i := start; end < i; i += step {
+					indexes = append(indexes, i)
+				}
+			}
+		}
+		for _, i := range indexes {
+			if e := byIndex[i]; e != nil {
+				h.emitRest(e.path, e.value, tr.Rest[1:])
+			}
+		}
+	}
+}
+
+func (h *MatchHandler) emitRest(path Expr, v any, rest Expr) {
+	if len(rest) == 0 {
+		h.OnData(path, v)
+		return
+	}
+	for _, loc := range rest.Locate(v, 0) {
+		p := make(Expr, 0, len(path)+len(loc))
+		p = append(p, path...)
+		p = append(p, loc...)
+		h.OnData(p, loc.First(v))
+	}
+}
+
+// needsData returns true if the fragment can not be evaluated with only
+// the path to a value.
+func needsData(f Frag) bool {
+	switch tf := f.(type) {
+	case Child, Wildcard, Descent, Bracket:
+		return false
+	case Nth:
+		return tf < 0
+	case Union:
+		for _, u := range tf {
+			switch tu := u.(type) {
+			case string:
+			case int64:
+				if tu < 0 {
+					return true
+				}
+			default:
 				return true
 			}
 		}
+		return false
+	}
+	return true
+}
+
+// isElementFrag returns true if the fragment is evaluated on each element
+// of a container as the elements are completed.
+func isElementFrag(f Frag) bool {
+	switch f.(type) {
+	case *Filter, Nth, Slice:
+		return true
 	}
 	return false
 }
+
+func isIndexFrag(f Frag) bool {
+	switch f.(type) {
+	case Nth, Slice:
+		return true
+	}
+	return false
+}
+
+func sliceParams(f Slice) (start, end, step int) {
+	end = maxEnd
+	step = 1
+	if 0 < len(f) {
+		start = f[0]
+	}
+	if 1 < len(f) {
+		end = f[1]
+	}
+	if 2 < len(f) {
+		step = f[2]
+	}
+	return
+}
+
+// exactPathMatch returns true if the path matches the whole target. Unlike
+// PathMatch() a path that extends beyond the target does not match.
+func exactPathMatch(target, path Expr) bool {
+	if 0 < len(target) {
+		switch target[0].(type) {
+		case Root, At:
+			target = target[1:]
+		}
+	}
+	if 0 < len(path) {
+		switch path[0].(type) {
+		case Root, At:
+			path = path[1:]
+		}
+	}
+	return pathMatchRest(target, path)
+}
+
+func pathMatchRest(target, path Expr) bool {
+	for i, f := range target {
+		switch f.(type) {
+		case Bracket:
+			continue
+		case Descent:
+			rest := target[i+1:]
+			for j := 0; j <= len(path); j++ {
+				if pathMatchRest(rest, path[j:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(path) == 0 {
+			return false
+		}
+		switch tf := f.(type) {
+		case Child, Nth:
+			if tf != path[0] {
+				return false
+			}
+		case Wildcard:
+		case Union:
+			var ok bool
+			for _, u := range tf {
+				switch tu := u.(type) {
+				case string:
+					ok = Child(tu) == path[0]
+				case int64:
+					ok = Nth(tu) == path[0]
+				}
+				if ok {
+					break
+				}
+			}
+			if !ok {
+				return false
+			}
+		default:
+			return false
+		}
+		path = path[1:]
+	}
+	return len(path) == 0
+}
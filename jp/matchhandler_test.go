@@ -4,6 +4,7 @@ package jp_test
 
 import (
 	"fmt"
+	"sort"
 	"testing"
 
 	"github.com/ohler55/ojg/jp"
@@ -84,3 +85,64 @@ func TestMatchHandlerFilter(t *testing.T) {
 		md.runTest(t, i)
 	}
 }
+
+func TestMatchHandlerSameAsLocate(t *testing.T) {
+	src := `{
+  a: [1 2 3 4 5 6]
+  b: [{x: 1 y: [1 2]} {x: 2 y: [3]} {x: 3 y: []}]
+  c: {d: {x: 2 e: [7 8 9]} f: {x: 1}}
+}`
+	doc := sen.MustParse([]byte(src))
+	for _, target := range []string{
+		"$.a[-1]",
+		"$.a[-2]",
+		"$.a[-7]",
+		"$.a[1:3]",
+		"$.a[::2]",
+		"$.a[1:-2]",
+		"$.a[-3:]",
+		"$.a[-4:-1:2]",
+		"$.a[4:1:-2]",
+		"$.b[?(@.x > 1)]",
+		"$.b[?(@.x > 1)].y[0]",
+		"$.b[?(@.x > 1)].y[-1]",
+		"$.b[-1].x",
+		"$.b[*].y[-1]",
+		"$.c[?(@.x == 2)].e[1:]",
+		"$..[?(@.x == 1)]",
+		"$..e[-1]",
+		"$..x",
+		"$.a[0,-1]",
+		"$.c.*",
+	} {
+		x := jp.MustParseString(target)
+		var got []string
+		h := jp.NewMatchHandler(func(path jp.Expr, data any) {
+			got = append(got, fmt.Sprintf("%s: %s", path, pretty.SEN(data)))
+		}, x)
+		tt.Nil(t, sen.TokenizeString(src, h), target)
+
+		var expect []string
+		for _, loc := range x.Locate(doc, 0) {
+			expect = append(expect, fmt.Sprintf("%s: %s", loc, pretty.SEN(loc.First(doc))))
+		}
+		sort.Strings(got)
+		sort.Strings(expect)
+		tt.Equal(t, expect, got, target)
+	}
+}
+
+func TestMatchHandlerEarly(t *testing.T) {
+	var events []string
+	h := jp.NewMatchHandler(func(path jp.Expr, data any) {
+		events = append(events, fmt.Sprintf("%s: %v", path, data))
+	}, jp.MustParseString("$[1:-1]"))
+	h.ArrayStart()
+	for i := int64(0); i < 4; i++ {
+		h.Int(i)
+		events = append(events, fmt.Sprintf("read %d", i))
+	}
+	h.ArrayEnd()
+	// Elements are emitted as soon as an element after them is read.
+	tt.Equal(t, []string{"read 0", "read 1", "$[1]: 1", "read 2", "$[2]: 2", "read 3"}, events)
+}
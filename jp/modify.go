@@ -82,6 +82,17 @@ func (x Expr) modify(data any, modifier func(element any) (altered any, changed
 	if len(x) == 0 {
 		panic("can not modify with an empty expression")
 	}
+	if x.editorOnly(false) {
+		max := 0
+		if one {
+			max = 1
+		}
+		result, _, err := (&Editor{}).modify(x, data, modifier, max)
+		if err != nil {
+			panic(err)
+		}
+		return result
+	}
 	if _, ok := x[len(x)-1].(Descent); ok {
 		ta := strings.Split(fmt.Sprintf("%T", x[len(x)-1]), ".")
 		panic(fmt.Sprintf("can not modify with an expression where the last fragment is a %s",
@@ -736,7 +747,7 @@ done:
 						}
 					}
 				} else {
-					for _, v = range reflectGetSlice(tv, start, end, step) {
+					for _, v = range reflectGetSlice(tv, start, end, step, x.rfc()) {
 						stack = stackAddValue(stack, v)
 					}
 				}
@@ -823,6 +834,7 @@ done:
 			di, _ := stack[len(stack)-1].(fragIndex)
 			// first pass expands, second continues evaluation
 			if (di & descentFlag) == 0 {
+				keepSiblingIndex(&stack)
 				switch tv := prev.(type) {
 				case map[string]any:
 					// Put prev back and slide fi.
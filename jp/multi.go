@@ -0,0 +1,210 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp
+
+import (
+	"fmt"
+
+	"github.com/ohler55/ojg/gen"
+)
+
+// MultiQuery evaluates a set of expressions against data in a single
+// pass. Expressions are merged into a trie so that fragments shared by
+// several expressions, such as a common prefix, are evaluated once no
+// matter how many expressions share them.
+type MultiQuery struct {
+	exprs []Expr
+	root  *mqNode
+	// whole are the indexes of expressions that are evaluated on their own
+	// since they include fragments such as Parent that depend on the path.
+	whole []int
+}
+
+type mqNode struct {
+	frag     Frag
+	key      string
+	children []*mqNode
+	ends     []int
+}
+
+// Compile a set of expressions into a MultiQuery. The results of Get() on
+// the MultiQuery are indexed in the same order as the expressions.
+func Compile(exprs ...Expr) *MultiQuery {
+	mq := MultiQuery{exprs: exprs, root: &mqNode{}}
+	for i, x := range exprs {
+		if len(x) == 0 {
+			continue
+		}
+		if x.hasPathFrag() {
+			mq.whole = append(mq.whole, i)
+			continue
+		}
+		switch x[0].(type) {
+		case Root, At:
+			x = x[1:]
+		}
+		n := mq.root
+		for _, f := range x {
+			if _, ok := f.(Bracket); ok {
+				continue
+			}
+			n = n.child(f)
+		}
+		n.ends = append(n.ends, i)
+	}
+	return &mq
+}
+
+// Exprs returns the expressions the MultiQuery was compiled from.
+func (mq *MultiQuery) Exprs() []Expr {
+	return mq.exprs
+}
+
+// Get the elements of the data identified by each expression. The
+// returned slice has one entry for each expression and each entry holds the
+// same values that would be returned by calling Get() on the expression
+// alone. The order of the values in an entry is unspecified and may differ
+// from the order returned by Get().
+func (mq *MultiQuery) Get(data any) [][]any {
+	results := make([][]any, len(mq.exprs))
+	mq.root.eval([]any{data}, data, results)
+	for _, i := range mq.whole {
+		results[i] = mq.exprs[i].Get(data)
+	}
+
+	return results
+}
+
+func (n *mqNode) child(f Frag) *mqNode {
+	key := fmt.Sprintf("%T:%s", f, Expr{f})
+	for _, c := range n.children {
+		if c.key == key {
+			return c
+		}
+	}
+	c := &mqNode{frag: f, key: key}
+	n.children = append(n.children, c)
+
+	return c
+}
+
+// eval collects the values for expressions that end at the node and then
+// continues with the values for each child.
+func (n *mqNode) eval(values []any, root any, results [][]any) {
+	for _, i := range n.ends {
+		results[i] = append(results[i], values...)
+	}
+	if len(values) == 0 {
+		return
+	}
+	for _, c := range n.children {
+		var next []any
+		switch tf := c.frag.(type) {
+		case Child:
+			for _, v := range values {
+				next = mqChild(next, v, tf)
+			}
+		case Nth:
+			for _, v := range values {
+				next = mqNth(next, v, int(tf))
+			}
+		case Wildcard:
+			for _, v := range values {
+				next = mqWild(next, v)
+			}
+		case Descent:
+			// Like Get(), a descent includes the current element.
+			for _, v := range values {
+				next = append(next, v)
+				next = mqDescend(next, v)
+			}
+		case *Filter:
+			for _, v := range values {
+				ns, _ := tf.evalWithRoot([]any{}, v, root)
+				matches, _ := ns.([]any)
+				for i := len(matches) - 1; 0 <= i; i-- {
+					next = append(next, matches[i])
+				}
+			}
+		default:
+			x := Expr{c.frag}
+			for _, v := range values {
+				next = append(next, x.Get(v)...)
+			}
+		}
+		c.eval(next, root, results)
+	}
+}
+
+func mqChild(next []any, v any, key Child) []any {
+	switch tv := v.(type) {
+	case map[string]any:
+		if cv, has := tv[string(key)]; has {
+			next = append(next, cv)
+		}
+	case gen.Object:
+		if cv, has := tv[string(key)]; has {
+			next = append(next, cv)
+		}
+	case nil, bool, string, int64, float64, []any, gen.Array:
+	default:
+		next = append(next, Expr{key}.Get(v)...)
+	}
+	return next
+}
+
+func mqNth(next []any, v any, i int) []any {
+	switch tv := v.(type) {
+	case []any:
+		if i < 0 {
+			i += len(tv)
+		}
+		if 0 <= i && i < len(tv) {
+			next = append(next, tv[i])
+		}
+	case gen.Array:
+		if i < 0 {
+			i += len(tv)
+		}
+		if 0 <= i && i < len(tv) {
+			next = append(next, tv[i])
+		}
+	case nil, bool, string, int64, float64, map[string]any, gen.Object:
+	default:
+		next = append(next, Expr{Nth(i)}.Get(v)...)
+	}
+	return next
+}
+
+func mqWild(next []any, v any) []any {
+	switch tv := v.(type) {
+	case map[string]any:
+		for _, mv := range tv {
+			next = append(next, mv)
+		}
+	case []any:
+		next = append(next, tv...)
+	case gen.Object:
+		for _, mv := range tv {
+			next = append(next, mv)
+		}
+	case gen.Array:
+		for _, av := range tv {
+			next = append(next, av)
+		}
+	case nil, bool, string, int64, float64:
+	default:
+		next = append(next, Expr{Wildcard('*')}.Get(v)...)
+	}
+	return next
+}
+
+// mqDescend appends all the descendants of v with each element followed
+// by its own descendants.
+func mqDescend(next []any, v any) []any {
+	for _, cv := range mqWild(nil, v) {
+		next = append(next, cv)
+		next = mqDescend(next, cv)
+	}
+	return next
+}
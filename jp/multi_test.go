@@ -0,0 +1,113 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/alt"
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/oj"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+var multiPaths = []string{
+	"$",
+	"$.a",
+	"$.a.b",
+	"$.a.c[1]",
+	"$.a.c[-1]",
+	"$.a.c[*]",
+	"$.a.*",
+	"$.a.c[1:3]",
+	"$.a.c[0,2]",
+	"$['a','x']",
+	"$..b",
+	"$..c[0]",
+	"$..",
+	"$.x[?(@.y > 1)].y",
+	"$.x[?(@.y == $.a.b)]",
+	"$.x[*].y",
+	"$.missing.b",
+	"a.b",
+	"@.x[0]",
+	"$.a.c[1].b^",
+	"$.x[*]~",
+}
+
+// sameValues checks that expect and actual hold the same values, each the
+// same number of times, in any order since the order of MultiQuery results
+// is unspecified.
+func sameValues(t *testing.T, expect, actual []any, x jp.Expr) {
+	t.Helper()
+	counts := map[string]int{}
+	for _, v := range expect {
+		counts[oj.JSON(v, &oj.Options{Sort: true})]++
+	}
+	for _, v := range actual {
+		counts[oj.JSON(v, &oj.Options{Sort: true})]--
+	}
+	for k, n := range counts {
+		tt.Equal(t, 0, n, "%s count of %s", x, k)
+	}
+	tt.Equal(t, len(expect), len(actual), "%s", x)
+}
+
+func TestMultiQueryGet(t *testing.T) {
+	data := sen.MustParse([]byte(`{a: {b: 2 c: [1 {b: 3} 5]} x: [{y: 1} {y: 2} {y: 3 b: 4}]}`))
+	exprs := make([]jp.Expr, len(multiPaths))
+	for i, p := range multiPaths {
+		exprs[i] = jp.MustParseString(p)
+	}
+	mq := jp.Compile(exprs...)
+	tt.Equal(t, len(exprs), len(mq.Exprs()))
+
+	for _, d := range []any{data, alt.Generify(data)} {
+		results := mq.Get(d)
+		tt.Equal(t, len(exprs), len(results))
+		for i, x := range exprs {
+			sameValues(t, x.Get(d), results[i], x)
+		}
+	}
+}
+
+func TestMultiQueryShared(t *testing.T) {
+	data := map[string]any{"a": map[string]any{"b": []any{1, 2, 3}}}
+	mq := jp.Compile(jp.C("a").C("b").N(0), jp.C("a").C("b").N(2), jp.R().C("a").C("b").N(0), jp.Expr{})
+	results := mq.Get(data)
+	tt.Equal(t, [][]any{{1}, {3}, {1}, nil}, results)
+
+	type sample struct {
+		A []int
+	}
+	results = jp.Compile(jp.C("A").N(1), jp.C("A").W()).Get(&sample{A: []int{4, 5}})
+	tt.Equal(t, [][]any{{5}, {4, 5}}, results)
+}
+
+func BenchmarkMultiQueryGet(b *testing.B) {
+	data := sen.MustParse([]byte(`{a: {b: 2 c: [1 {b: 3} 5]} x: [{y: 1} {y: 2} {y: 3 b: 4}]}`))
+	exprs := make([]jp.Expr, len(multiPaths))
+	for i, p := range multiPaths {
+		exprs[i] = jp.MustParseString(p)
+	}
+	mq := jp.Compile(exprs...)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_ = mq.Get(data)
+	}
+}
+
+func BenchmarkMultiExprGet(b *testing.B) {
+	data := sen.MustParse([]byte(`{a: {b: 2 c: [1 {b: 3} 5]} x: [{y: 1} {y: 2} {y: 3 b: 4}]}`))
+	exprs := make([]jp.Expr, len(multiPaths))
+	for i, p := range multiPaths {
+		exprs[i] = jp.MustParseString(p)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, x := range exprs {
+			_ = x.Get(data)
+		}
+	}
+}
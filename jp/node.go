@@ -37,6 +37,15 @@ func (i index) Empty() bool {
 	return false
 }
 
+// keepSiblingNodeIndex is the gen.Node version of keepSiblingIndex.
+func keepSiblingNodeIndex(stack *[]gen.Node) {
+	if 1 < len(*stack) {
+		if _, ok := (*stack)[len(*stack)-2].(index); !ok {
+			*stack = append(*stack, (*stack)[len(*stack)-1])
+		}
+	}
+}
+
 // GetNodes the elements of the data identified by the path.
 func (x Expr) GetNodes(n gen.Node) (results []gen.Node) {
 	if len(x) == 0 {
@@ -54,6 +63,9 @@ func (x Expr) GetNodes(n gen.Node) (results []gen.Node) {
 		_ = i.String()
 		return
 	}
+	if x.hasPathFrag() {
+		return x.pathGetNodes(n, 0)
+	}
 	var v gen.Node
 	var prev gen.Node
 	var has bool
@@ -142,6 +154,7 @@ func (x Expr) GetNodes(n gen.Node) (results []gen.Node) {
 			top := (di & descentChildFlag) == 0
 			// first pass expands, second continues evaluation
 			if (int64(di) & descentFlag) == 0 {
+				keepSiblingNodeIndex(&stack)
 				switch tv := prev.(type) {
 				case gen.Object:
 					// Put prev back and slide fi.
@@ -354,6 +367,12 @@ func (x Expr) FirstNode(n gen.Node) (result gen.Node) {
 	if len(x) == 0 {
 		return nil
 	}
+	if x.hasPathFrag() {
+		if results := x.pathGetNodes(n, 1); 0 < len(results) {
+			result = results[0]
+		}
+		return
+	}
 	var v gen.Node
 	var prev gen.Node
 	var has bool
@@ -444,6 +463,7 @@ func (x Expr) FirstNode(n gen.Node) (result gen.Node) {
 			di, _ := stack[len(stack)-1].(index)
 			// first pass expands, second continues evaluation
 			if (int64(di) & descentFlag) == 0 {
+				keepSiblingNodeIndex(&stack)
 				switch tv := prev.(type) {
 				case gen.Object:
 					// Put prev back and slide fi.
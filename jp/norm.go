@@ -10,9 +10,9 @@ func (f norm) Append(buf []byte, bracket, first bool) []byte {
 	return buf
 }
 
-func (f norm) locate(pp Expr, data any, rest Expr, max int) (locs []Expr) {
+func (f norm) locate(pp Expr, data, root any, rest Expr, max int) (locs []Expr) {
 	if 0 < len(rest) {
-		locs = rest[0].locate(pp, data, rest[1:], max)
+		locs = rest[0].locate(pp, data, root, rest[1:], max)
 	}
 	return
 }
@@ -0,0 +1,117 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp
+
+import (
+	"sync"
+)
+
+// GetParallel gets the elements of the data identified by the path using up
+// to workers goroutines. The members selected by the first Wildcard, Slice,
+// or Filter fragment are split into contiguous partitions and the rest of
+// the expression is evaluated on each partition concurrently. The results
+// are merged so they are in the same order as the results from Get().
+//
+// The data must not be modified while being evaluated and filter scripts
+// must not have side effects. Expressions that include a Proc, Parent, or
+// KeyName fragment, a Descent before the fragment to partition, or that do
+// not have a fragment to partition, are evaluated with Get() on the calling
+// goroutine as is a workers value of less than 2.
+func (x Expr) GetParallel(data any, workers int) []any {
+	fi := x.fanOutIndex()
+	if workers < 2 || fi < 0 || x.hasPathFrag() {
+		return x.Get(data)
+	}
+	for _, f := range x[:fi] {
+		if _, ok := f.(Descent); ok {
+			return x.Get(data)
+		}
+	}
+	parents := []any{data}
+	if 0 < fi {
+		parents = x[:fi].get(data, data)
+	}
+	var members []any
+	for _, p := range parents {
+		if _, ok := x[fi].(*Filter); ok {
+			members = append(members, Expr{Wildcard('*')}.get(p, data)...)
+		} else {
+			members = append(members, x.sub(fi, fi+1).get(p, data)...)
+		}
+	}
+	size := (len(members) + workers - 1) / workers
+	if size == 0 {
+		return nil
+	}
+	parts := make([][]any, 0, workers)
+	for start := 0; start < len(members); start += size {
+		end := start + size
+		if len(members) < end {
+			end = len(members)
+		}
+		parts = append(parts, members[start:end])
+	}
+	results := make([][]any, len(parts))
+	var wg sync.WaitGroup
+	for i, part := range parts {
+		wg.Add(1)
+		go func(i int, part []any) {
+			defer wg.Done()
+			results[i] = x.getPart(fi, part, data)
+		}(i, part)
+	}
+	wg.Wait()
+
+	var all []any
+	for _, r := range results {
+		all = append(all, r...)
+	}
+	return all
+}
+
+// getPart evaluates the fragments after the fan out fragment at fi on each
+// member of part. If the fan out fragment is a filter the members are
+// filtered first.
+func (x Expr) getPart(fi int, part []any, root any) (results []any) {
+	if _, ok := x[fi].(*Filter); ok {
+		part = x.sub(fi, fi+1).get(part, root)
+	}
+	if len(x) == fi+1 {
+		return part
+	}
+	rest := x.sub(fi+1, len(x))
+	for _, v := range part {
+		if isContainer(v) {
+			results = append(results, rest.get(v, root)...)
+		}
+	}
+	return
+}
+
+// sub returns the fragments from start up to end. The fragments follow an
+// RFC 9535 @ if x was parsed with ParseRFC9535() so they are evaluated the
+// same way.
+func (x Expr) sub(start, end int) Expr {
+	if x.rfc() {
+		return append(Expr{rfcAt}, x[start:end]...)
+	}
+	return x[start:end]
+}
+
+// fanOutIndex returns the index of the first Wildcard, Slice, or Filter
+// fragment or -1 if there is no such fragment or the expression includes a
+// Proc fragment.
+func (x Expr) fanOutIndex() int {
+	fi := -1
+	for i, f := range x {
+		switch f.(type) {
+		case Wildcard, Slice, *Filter:
+			if fi < 0 {
+				fi = i
+			}
+		case *Proc:
+			return -1
+		}
+	}
+	return fi
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ohler55/ojg/alt"
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/oj"
+	"github.com/ohler55/ojg/tt"
+)
+
+func parallelData(n int) any {
+	items := make([]any, n)
+	for i := range items {
+		items[i] = map[string]any{
+			"id":   int64(i),
+			"tags": []any{fmt.Sprintf("t%d", i%3), i % 2},
+			"sub":  map[string]any{"v": int64(i * 10)},
+		}
+	}
+	return map[string]any{"items": items, "min": int64(40), "scalar": 3}
+}
+
+func TestExprGetParallel(t *testing.T) {
+	data := parallelData(25)
+	for _, src := range []string{
+		"$.items[*].id",
+		"$.items[*]",
+		"$.items[2:20:3].sub.v",
+		"$.items[-3:].id",
+		"$.items[?(@.tags[1] == 1)].id",
+		"$.items[?(@.sub.v > $.min)].tags[0]",
+		"$.items[?(@.id < 3)].tags..",
+		"$.items[*].tags[*]",
+		"$.items[*].tags[?(@ == 1)]",
+		"$..sub[*]",
+		"$[*][3].id",
+		"$.items[1].id",
+		"$.nothing[*]",
+		"$.scalar[*]",
+		"$.items[*].id^.sub.v",
+	} {
+		x := jp.MustParseString(src)
+		expect := oj.JSON(x.Get(data), &oj.Options{Sort: true})
+		for _, workers := range []int{0, 1, 2, 3, 8, 100} {
+			result := x.GetParallel(data, workers)
+			tt.Equal(t, expect, oj.JSON(result, &oj.Options{Sort: true}), "%s with %d workers", src, workers)
+		}
+		gd := alt.Generify(data)
+		tt.Equal(t, expect, oj.JSON(x.GetParallel(gd, 4), &oj.Options{Sort: true}), "gen %s", src)
+	}
+}
+
+func TestExprGetParallelProc(t *testing.T) {
+	x := jp.MustParseString("$.items[*].tags")
+	x = append(x, &jp.Proc{Procedure: &mathProc{op: '+', left: 0, right: 1}})
+	data := parallelData(3)
+	tt.Equal(t, oj.JSON(x.Get(data)), oj.JSON(x.GetParallel(data, 4)))
+}
+
+func TestExprGetParallelDescent(t *testing.T) {
+	data := []any{
+		[]any{map[string]any{"x": 1}, []any{map[string]any{"x": 2}, map[string]any{"x": 3}}},
+		map[string]any{"x": 4},
+		[]any{[]any{map[string]any{"x": 5}}, map[string]any{"x": 6}},
+	}
+	for _, d := range []struct {
+		src    string
+		expect string
+		rfc    bool
+	}{
+		{src: "$..[*].x", expect: "[2,3,1,5,6,4]"},
+		{src: "$..[1:].x", expect: "[3,6,4]"},
+		{src: "$[*]..x", expect: "[1,2,3,4,5,6]"},
+		{src: "$..[*].x", expect: "[4,1,2,3,6,5]", rfc: true},
+		{src: "$..[1:].x", expect: "[4,3,6]", rfc: true},
+		{src: "$[*]..x", expect: "[1,2,3,4,5,6]", rfc: true},
+	} {
+		x := jp.MustParseString(d.src)
+		if d.rfc {
+			var err error
+			x, err = jp.ParseRFC9535String(d.src)
+			tt.Nil(t, err, d.src)
+		}
+		tt.Equal(t, d.expect, oj.JSON(x.Get(data)), "get %s", d.src)
+		for _, workers := range []int{2, 3, 8} {
+			tt.Equal(t, d.expect, oj.JSON(x.GetParallel(data, workers)), "%s with %d workers", d.src, workers)
+		}
+	}
+}
+
+func TestExprGetParallelRFC9535(t *testing.T) {
+	data := map[string]any{"a": []any{
+		map[string]any{"b": []any{1, 2, 3, 4, 5}},
+		map[string]any{"b": []any{6, 7}},
+	}}
+	for _, d := range []struct {
+		src    string
+		expect string
+	}{
+		{src: "$.a[*].b[4:0:-2]", expect: "[5,3,7]"},
+		{src: "$.a[?@.b[1] == 7].b", expect: "[[6,7]]"},
+		{src: "$.a[*]..[*]", expect: "[[1,2,3,4,5],1,2,3,4,5,[6,7],6,7]"},
+	} {
+		x, err := jp.ParseRFC9535String(d.src)
+		tt.Nil(t, err, d.src)
+		tt.Equal(t, d.expect, oj.JSON(x.Get(data)), "get %s", d.src)
+		for _, workers := range []int{2, 3, 8} {
+			tt.Equal(t, d.expect, oj.JSON(x.GetParallel(data, workers)), "%s with %d workers", d.src, workers)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp
+
+import (
+	"strconv"
+)
+
+// Param is a named parameter that is replaced by a value when an
+// expression is bound with Bind() or evaluated with GetWith(). A Param can
+// be used as a value in a filter such as [?(@.age > $min)], as a bracketed
+// fragment such as [$i], or as a member of a Union. An unbound Param never
+// matches and has no value in a filter.
+type Param string
+
+// ParamSlice is a slice fragment that includes one or more parameters such
+// as [$start:$end]. Each member is either an int or a Param. Once all the
+// parameters are bound it becomes a Slice.
+type ParamSlice []any
+
+// Append a fragment string representation of the fragment to the buffer
+// then returning the expanded buffer.
+func (f Param) Append(buf []byte, _, _ bool) []byte {
+	buf = append(buf, '[', '$')
+	buf = append(buf, f...)
+
+	return append(buf, ']')
+}
+
+func (f Param) locate(pp Expr, data, root any, rest Expr, max int) (locs []Expr) {
+	return nil
+}
+
+// Walk does nothing as an unbound parameter never matches.
+func (f Param) Walk(rest, path Expr, nodes []any, cb func(path Expr, nodes []any)) {
+}
+
+// Append a fragment string representation of the fragment to the buffer
+// then returning the expanded buffer.
+func (f ParamSlice) Append(buf []byte, _, _ bool) []byte {
+	buf = append(buf, '[')
+	for i, v := range f {
+		if 0 < i {
+			buf = append(buf, ':')
+		}
+		switch tv := v.(type) {
+		case Param:
+			buf = append(buf, '$')
+			buf = append(buf, tv...)
+		case int:
+			if (i != 0 || tv != 0) && (i != 1 || tv != maxEnd) {
+				buf = append(buf, strconv.Itoa(tv)...)
+			}
+		}
+	}
+	if len(f) == 1 {
+		buf = append(buf, ':')
+	}
+	return append(buf, ']')
+}
+
+func (f ParamSlice) locate(pp Expr, data, root any, rest Expr, max int) (locs []Expr) {
+	return nil
+}
+
+// Walk does nothing as an unbound parameter never matches.
+func (f ParamSlice) Walk(rest, path Expr, nodes []any, cb func(path Expr, nodes []any)) {
+}
+
+// GetWith binds the parameters in the expression and then gets the
+// elements of the data identified by the bound expression.
+func (x Expr) GetWith(data any, params map[string]any) []any {
+	return x.Bind(params).Get(data)
+}
+
+// Bind returns a copy of the expression with parameters replaced by the
+// values in params. A parameter in a bracket is replaced by a Nth if the
+// value is an integer or a Child if the value is a string. Parameters in
+// filters are replaced by the value. Parameters without a value or with a
+// value of the wrong type are left unbound. The original expression is not
+// modified so an expression can be parsed once and bound concurrently.
+func (x Expr) Bind(params map[string]any) Expr {
+	bx := make(Expr, len(x))
+	for i, f := range x {
+		switch tf := f.(type) {
+		case Param:
+			bx[i] = f
+			switch tv := paramKey(params, tf).(type) {
+			case int64:
+				bx[i] = Nth(tv)
+			case string:
+				bx[i] = Child(tv)
+			}
+		case ParamSlice:
+			bx[i] = tf.bind(params)
+		case Union:
+			bu := make(Union, len(tf))
+			for j, k := range tf {
+				switch tk := k.(type) {
+				case Param:
+					bu[j] = tk
+					switch tv := paramKey(params, tk).(type) {
+					case int64, string:
+						bu[j] = tv
+					}
+				case ParamSlice:
+					bu[j] = tk.bind(params)
+				case *Filter:
+					bu[j] = tk.bind(params)
+				default:
+					bu[j] = k
+				}
+			}
+			bx[i] = bu
+		case *Filter:
+			bx[i] = tf.bind(params)
+		default:
+			bx[i] = f
+		}
+	}
+	return bx
+}
+
+func (f ParamSlice) bind(params map[string]any) Frag {
+	s := make(Slice, len(f))
+	for i, v := range f {
+		switch tv := v.(type) {
+		case int:
+			s[i] = tv
+		case Param:
+			n, ok := paramKey(params, tv).(int64)
+			if !ok {
+				return f
+			}
+			s[i] = int(n)
+		}
+	}
+	return s
+}
+
+func (f *Filter) bind(params map[string]any) *Filter {
+	return &Filter{Script: Script{template: bindTemplate(f.template, params)}}
+}
+
+func bindTemplate(template []any, params map[string]any) []any {
+	bound := make([]any, len(template))
+	for i, v := range template {
+		switch tv := v.(type) {
+		case Param:
+			if pv, has := params[string(tv)]; has {
+				bound[i] = normalizeDeep(pv)
+			} else {
+				bound[i] = tv
+			}
+		case Expr:
+			bound[i] = tv.Bind(params)
+		case []any:
+			bound[i] = bindTemplate(tv, params)
+		default:
+			bound[i] = v
+		}
+	}
+	return bound
+}
+
+// paramKey returns the value of a parameter as an int64, a string, or nil
+// if the value is missing or not a valid key.
+func paramKey(params map[string]any, p Param) any {
+	switch tv := normalize(params[string(p)]).(type) {
+	case int64, string:
+		return tv
+	case float64:
+		if tv == float64(int64(tv)) {
+			return int64(tv)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ohler55/ojg/alt"
+	"github.com/ohler55/ojg/gen"
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/oj"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+const paramData = `{
+  users: [
+    {name: ann age: 31 tags: [a b]}
+    {name: bob age: 17 tags: [b]}
+    {name: cal age: 45 tags: []}
+    {name: "o'hara" age: 52 tags: [c]}
+  ]
+  keys: {x: 1 y: 2 z: 3}
+}`
+
+func TestExprGetWith(t *testing.T) {
+	data := sen.MustParse([]byte(paramData))
+	for _, d := range []struct {
+		src    string
+		params map[string]any
+		expect string
+	}{
+		{
+			src:    "$.users[?(@.age > $min && @.name != $who)].name",
+			params: map[string]any{"min": 30, "who": "cal"},
+			expect: `["ann","o'hara"]`,
+		},
+		{
+			src:    "$.users[?(@.name == $who)].age",
+			params: map[string]any{"who": "o'hara"},
+			expect: "[52]",
+		},
+		{
+			src:    "$.users[?(@.name in [$a,$b])].age",
+			params: map[string]any{"a": "bob", "b": "cal"},
+			expect: "[17,45]",
+		},
+		{
+			src:    "$.users[?(@.tags[$i] == 'b')].name",
+			params: map[string]any{"i": 0},
+			expect: `["bob"]`,
+		},
+		{src: "$.users[$i].name", params: map[string]any{"i": 1}, expect: `["bob"]`},
+		{src: "$.users[$i].name", params: map[string]any{"i": -1}, expect: `["o'hara"]`},
+		{src: "$.users[$i].name", params: map[string]any{"i": 1.0}, expect: `["bob"]`},
+		{src: "$.keys[$k]", params: map[string]any{"k": "y"}, expect: "[2]"},
+		{src: "$.users[$i].name", params: map[string]any{"i": true}, expect: "[]"},
+		{src: "$.users[$i].name", params: nil, expect: "[]"},
+		{src: "$.users[$start:$end].name", params: map[string]any{"start": 1, "end": 3}, expect: `["bob","cal"]`},
+		{src: "$.users[1:$end].name", params: map[string]any{"end": -2}, expect: `["bob"]`},
+		{src: "$.users[$start:].name", params: map[string]any{"start": 2}, expect: `["cal","o'hara"]`},
+		{src: "$.users[::$step].name", params: map[string]any{"step": 2}, expect: `["ann","cal"]`},
+		{src: "$.users[$start:$end].name", params: map[string]any{"start": 1}, expect: "[]"},
+		{src: "$.keys[$a,'z',$b]", params: map[string]any{"a": "x", "b": "y"}, expect: "[1,3,2]"},
+		{src: "$.users[$a,2].age", params: map[string]any{"a": 0}, expect: "[31,45]"},
+		{src: "$.users[?(@.age in $ages)].name", params: map[string]any{"ages": []any{17, 45}}, expect: `["bob","cal"]`},
+		{src: "$.users[?(@.age in $ages)].name", params: map[string]any{"ages": gen.Array{gen.Int(31)}}, expect: `["ann"]`},
+		{src: "$.users[?(@.age > $min)].name", params: nil, expect: "[]"},
+		{src: "$.users[?(@.age != $min)].name", params: nil, expect: `["ann","bob","cal","o'hara"]`},
+	} {
+		x := jp.MustParseString(d.src)
+		tt.Equal(t, d.src, x.String())
+		tt.Equal(t, d.expect, oj.JSON(x.GetWith(data, d.params)), "%s %v", d.src, d.params)
+		tt.Equal(t, d.expect, oj.JSON(x.GetWith(alt.Generify(data), d.params)), "gen %s %v", d.src, d.params)
+	}
+}
+
+func TestExprBind(t *testing.T) {
+	x := jp.MustParseString("$.a[$i][$s:2][?(@.x == $v)]['b',$k]")
+	tt.Equal(t, "$.a[$i][$s:2][?(@.x == $v)]['b',$k]", x.String())
+
+	bx := x.Bind(map[string]any{"i": 3, "s": 1, "v": "o'x", "k": 7})
+	tt.Equal(t, `$.a[3][1:2][?(@.x == 'o\'x')]['b',7]`, bx.String())
+	// The original is not modified.
+	tt.Equal(t, "$.a[$i][$s:2][?(@.x == $v)]['b',$k]", x.String())
+
+	x = jp.R().C("a").P("i").Param("j").F(jp.Eq(jp.Get(jp.A().C("x")), jp.Parameter("v")))
+	tt.Equal(t, "$.a[$i][$j][?(@.x == $v)]", x.String())
+	tt.Equal(t, "$.a[1].j[?(@.x == 2)]", x.Bind(map[string]any{"i": 1, "j": "j", "v": 2}).String())
+
+	tt.Equal(t, "$['a',$b]", jp.R().U("a", jp.Param("b")).String())
+
+	for _, src := range []string{"$[$]", "$[$1]", "$[$a", "$[$a:", "$[$a:1", "$[$a;]"} {
+		_, err := jp.ParseString(src)
+		tt.NotNil(t, err, "%s", src)
+	}
+}
+
+func TestExprGetWithConcurrent(t *testing.T) {
+	data := sen.MustParse([]byte(paramData))
+	x := jp.MustParseString("$.users[?(@.age > $min)].name")
+	var wg sync.WaitGroup
+	results := make([]string, 4)
+	for i, min := range []int{0, 20, 40, 50} {
+		wg.Add(1)
+		go func(i, min int) {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				results[i] = oj.JSON(x.GetWith(data, map[string]any{"min": min}))
+			}
+		}(i, min)
+	}
+	wg.Wait()
+	tt.Equal(t, []string{`["ann","bob","cal","o'hara"]`, `["ann","cal","o'hara"]`, `["cal","o'hara"]`, `["o'hara"]`}, results)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp
+
+// Parent is a ^ in a JSON path representation that selects the parent of
+// the current element. The root element has no parent so the selection is
+// empty when applied to the root.
+type Parent byte
+
+// Append a fragment string representation of the fragment to the buffer
+// then returning the expanded buffer.
+func (f Parent) Append(buf []byte, bracket, first bool) []byte {
+	return append(buf, '^')
+}
+
+func (f Parent) locate(pp Expr, data, root any, rest Expr, max int) (locs []Expr) {
+	pp = parentPath(pp)
+	if pp == nil {
+		return nil
+	}
+	if len(rest) == 0 {
+		return []Expr{pp}
+	}
+	if v, has := locValue(pp, root); has {
+		locs = rest[0].locate(pp, v, root, rest[1:], max)
+	}
+	return
+}
+
+// Walk continues with the parent of the current element.
+func (f Parent) Walk(rest, path Expr, nodes []any, cb func(path Expr, nodes []any)) {
+	if path = parentPath(path); path == nil || len(nodes) < 2 {
+		return
+	}
+	nodes = append([]any{}, nodes[:len(nodes)-1]...)
+	if 0 < len(rest) {
+		rest[0].Walk(rest[1:], path, nodes, cb)
+	} else {
+		cb(path, nodes)
+	}
+}
+
+// parentPath returns a copy of the path to the parent of the element at
+// the end of path or nil if the path is to the root.
+func parentPath(path Expr) Expr {
+	for i := len(path) - 1; 0 <= i; i-- {
+		switch path[i].(type) {
+		case Child, Nth:
+			return append(Expr{}, path[:i]...)
+		case Root, At:
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/ohler55/ojg/alt"
+	"github.com/ohler55/ojg/gen"
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/oj"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+const parentData = `{
+  store: {
+    book: [
+      {title: a price: 8}
+      {title: b price: 12}
+      {title: c price: 5}
+    ]
+    bike: {color: red price: 20}
+  }
+}`
+
+func TestParentKeyName(t *testing.T) {
+	data := sen.MustParse([]byte(parentData))
+	for _, d := range []struct {
+		src    string
+		expect string
+		locs   string
+	}{
+		{src: "$.store.bike.color^", expect: `[{"color":"red","price":20}]`, locs: `["$.store.bike"]`},
+		{src: "$.store.bike.color^^.bike.price", expect: "[20]", locs: `["$.store.bike.price"]`},
+		{src: "$.store.book[?(@.price < 10)]^", expect: "", locs: `["$.store.book","$.store.book"]`},
+		{src: "$.store.book[?(@.price < 10)].title~", expect: `["title","title"]`, locs: `["$.store.book[0].title~","$.store.book[2].title~"]`},
+		{src: "$.store.book[?(@.price < 10)]~", expect: "[0,2]", locs: `["$.store.book[0]~","$.store.book[2]~"]`},
+		{src: "$.store.*~", expect: `["bike","book"]`, locs: `["$.store.bike~","$.store.book~"]`},
+		{src: "$..price^.title", expect: `["a","b","c"]`, locs: `["$.store.book[0].title","$.store.book[1].title","$.store.book[2].title"]`},
+		{src: "$.store.book[?(@.title == 'b')]^^.bike.color", expect: `["red"]`, locs: `["$.store.bike.color"]`},
+		{src: "$^", expect: "[]", locs: "[]"},
+		{src: "$~", expect: "[]", locs: "[]"},
+		{src: "$.store^", expect: "", locs: `["$"]`},
+		{src: "$.missing^", expect: "[]", locs: "[]"},
+		{src: "$.store~.x", expect: "[]", locs: "[]"},
+		{src: "store.bike.color^.price", expect: "[20]", locs: `["store.bike.price"]`},
+	} {
+		x := jp.MustParseString(d.src)
+		tt.Equal(t, d.src, x.String())
+		if 0 < len(d.expect) {
+			tt.Equal(t, d.expect, oj.JSON(sortedResults(x.Get(data)), &oj.Options{Sort: true}), "%s", d.src)
+			gd := alt.Generify(data)
+			nodes := x.GetNodes(gd)
+			values := make([]any, len(nodes))
+			for i, n := range nodes {
+				values[i] = n
+			}
+			tt.Equal(t, d.expect, oj.JSON(sortedResults(values), &oj.Options{Sort: true}), "gen %s", d.src)
+		}
+		locs := x.Locate(data, 0)
+		strs := make([]string, len(locs))
+		for i, loc := range locs {
+			strs[i] = loc.String()
+		}
+		sort.Strings(strs)
+		tt.Equal(t, d.locs, oj.JSON(strs), "locate %s", d.src)
+		tt.Equal(t, 0 < len(locs), x.Has(data), "has %s", d.src)
+	}
+}
+
+// sortedResults sorts strings and numbers so results that came from map
+// iteration can be compared.
+func sortedResults(values []any) []any {
+	sort.SliceStable(values, func(i, j int) bool {
+		return oj.JSON(values[i]) < oj.JSON(values[j])
+	})
+	return values
+}
+
+func TestParentKeyNameFirst(t *testing.T) {
+	data := sen.MustParse([]byte(parentData))
+	x := jp.MustParseString("$.store.book[1].price^")
+	tt.Equal(t, "b", jp.C("title").First(x.First(data)))
+	tt.Equal(t, int64(1), jp.MustParseString("$.store.book[1]~").First(data))
+	tt.Equal(t, "price", jp.MustParseString("$.store.bike.price~").First(data))
+	_, has := jp.MustParseString("$~").FirstFound(data)
+	tt.Equal(t, false, has)
+
+	gd := alt.Generify(data)
+	tt.Equal(t, gen.Int(1), jp.MustParseString("$.store.book[1]~").FirstNode(gd))
+	tt.Equal(t, gen.String("b"), jp.C("title").FirstNode(x.FirstNode(gd)))
+	tt.Nil(t, jp.MustParseString("$^").FirstNode(gd))
+}
+
+func TestParentKeyNameWalk(t *testing.T) {
+	data := sen.MustParse([]byte(parentData))
+	var result []string
+	jp.MustParseString("$.store.book[*].price^.title").Walk(data, func(path jp.Expr, nodes []any) {
+		result = append(result, path.String()+" "+oj.JSON(nodes[len(nodes)-1]))
+	})
+	tt.Equal(t, []string{`store.book[0].title "a"`, `store.book[1].title "b"`, `store.book[2].title "c"`}, result)
+
+	result = result[:0]
+	jp.MustParseString("$.store.book[*]~").Walk(data, func(path jp.Expr, nodes []any) {
+		result = append(result, path.String()+" "+oj.JSON(nodes[len(nodes)-1]))
+	})
+	tt.Equal(t, []string{"store.book[0]~ 0", "store.book[1]~ 1", "store.book[2]~ 2"}, result)
+
+	result = result[:0]
+	x := jp.MustParseString("$.store.book[-1]~")
+	x.Walk(data, func(path jp.Expr, nodes []any) {
+		result = append(result, path.String()+" "+oj.JSON(nodes[len(nodes)-1]))
+	})
+	tt.Equal(t, []string{"store.book[2]~ 2"}, result)
+	tt.Equal(t, []any{int64(2)}, x.Get(data))
+
+	result = result[:0]
+	jp.MustParseString("$^").Walk(data, func(path jp.Expr, nodes []any) {
+		result = append(result, path.String())
+	})
+	tt.Equal(t, 0, len(result))
+}
+
+func TestParentKeyNameParse(t *testing.T) {
+	tt.Equal(t, "['a^b']", jp.C("a^b").String())
+	tt.Equal(t, "$['a^b']", jp.R().C("a^b").String())
+	tt.Equal(t, "a^.b~", jp.Expr{jp.Child("a"), jp.Parent('^'), jp.Child("b"), jp.KeyName('~')}.String())
+
+	for _, src := range []string{"$..~", "$.a..~", "$..^", "$..^.b", "$.a..^~", "@..~"} {
+		x := jp.MustParseString(src)
+		tt.Equal(t, src, x.String())
+		tt.Equal(t, src, jp.MustParseString(x.String()).String())
+	}
+	tt.Equal(t, "$..^", jp.Expr{jp.Root('$'), jp.Descent('.'), jp.Parent('^')}.String())
+
+	x := jp.MustParseString("$.a[?(@.b ~= /x/)]")
+	tt.Equal(t, "$.a[?(@.b ~= /x/)]", x.String())
+	x = jp.MustParseString("$.a[?(@.b^.c == 1)]")
+	tt.Equal(t, "$.a[?(@.b^.c == 1)]", x.String())
+	tt.Equal(t, "[1]", oj.JSON(jp.MustParseString("$.a[?(@.b^.c == 1)].c").Get(map[string]any{
+		"a": []any{map[string]any{"b": 2, "c": 1}, map[string]any{"b": 2, "c": 3}},
+	})))
+}
+
+func TestWalkWithParent(t *testing.T) {
+	data := map[string]any{"a": []any{1, map[string]any{"b": true}}}
+	var result []string
+	jp.WalkWithParent(data, func(path jp.Expr, value, parent any) {
+		result = append(result, path.String()+" "+oj.JSON(parent))
+	}, true)
+	sort.Strings(result)
+	tt.Equal(t, []string{`$.a[0] [1,{"b":true}]`, `$.a[1].b {"b":true}`}, result)
+
+	result = result[:0]
+	jp.WalkWithParent(gen.Object{"x": gen.Int(1)}, func(path jp.Expr, value, parent any) {
+		result = append(result, path.String()+" "+oj.JSON(parent))
+	})
+	tt.Equal(t, []string{"$ null", `$.x {"x":1}`}, result)
+}
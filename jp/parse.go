@@ -17,8 +17,8 @@ const (
 	tokenMap = "" +
 		"................................" + // 0x00
 		"...o.o..........oooooooooo.o...o" + // 0x20
-		".oooooooooooooooooooooooooo...oo" + // 0x40
-		".oooooooooooooooooooooooooooooo." + // 0x60
+		".oooooooooooooooooooooooooo....o" + // 0x40
+		".ooooooooooooooooooooooooooooo.." + // 0x60
 		"oooooooooooooooooooooooooooooooo" + // 0x80
 		"oooooooooooooooooooooooooooooooo" + // 0xa0
 		"oooooooooooooooooooooooooooooooo" + // 0xc0
@@ -116,6 +116,15 @@ func (p *parser) nextFrag(first, lastDescent bool) (f Frag) {
 			f = p.afterDot()
 		case '*':
 			return Wildcard('*')
+		case '^':
+			f = Parent('^')
+		case '~':
+			if p.pos < len(p.buf) && p.buf[p.pos] == '=' {
+				// The start of a ~= operation in a script.
+				p.pos--
+			} else {
+				f = KeyName('~')
+			}
 		case '[':
 			f = p.afterBracket()
 		case ']':
@@ -210,6 +219,19 @@ func (p *parser) afterBracket() Frag {
 		}
 	case ':':
 		return p.readSlice(0)
+	case '$':
+		param := p.readParam()
+		b = p.skipSpace()
+		switch b {
+		case ']':
+			return param
+		case ',':
+			return p.readUnion(param, b)
+		case ':':
+			return p.readSlice(param)
+		default:
+			p.raise("invalid bracket fragment")
+		}
 	case '?':
 		return p.readFilter()
 	case '(':
@@ -346,16 +368,17 @@ func (p *parser) readNum(b byte) any {
 	return f
 }
 
-func (p *parser) readSlice(i int) Frag {
+func (p *parser) readSlice(first any) Frag {
 	if len(p.buf) <= p.pos {
 		p.raise("not terminated")
 	}
-	f := Slice{i}
+	f := []any{first}
+	var v any
 	b := p.buf[p.pos]
 	if b == ']' {
 		f = append(f, maxEnd)
 		p.pos++
-		return f
+		return sliceFrag(f)
 	}
 	b = p.skipSpace()
 	// read the end
@@ -367,12 +390,12 @@ func (p *parser) readSlice(i int) Frag {
 		b = p.buf[p.pos]
 		p.pos++
 		if b != ']' {
-			i, b = p.readInt(b)
-			f = append(f, i)
+			v, b = p.readSliceValue(b)
+			f = append(f, v)
 		}
 	} else {
-		i, b = p.readInt(b)
-		f = append(f, i)
+		v, b = p.readSliceValue(b)
+		f = append(f, v)
 		if b == ':' {
 			if len(p.buf) <= p.pos {
 				p.raise("not terminated")
@@ -380,15 +403,65 @@ func (p *parser) readSlice(i int) Frag {
 			b = p.buf[p.pos]
 			p.pos++
 			if b != ']' {
-				i, b = p.readInt(b)
-				f = append(f, i)
+				v, b = p.readSliceValue(b)
+				f = append(f, v)
 			}
 		}
 	}
 	if b != ']' {
 		p.raise("invalid slice syntax")
 	}
-	return f
+	return sliceFrag(f)
+}
+
+// readSliceValue reads an int or a parameter and returns it along with the
+// byte that followed it.
+func (p *parser) readSliceValue(b byte) (any, byte) {
+	if b == '$' {
+		param := p.readParam()
+		if len(p.buf) <= p.pos {
+			p.raise("not terminated")
+		}
+		b = p.buf[p.pos]
+		p.pos++
+		return param, b
+	}
+	return p.readInt(b)
+}
+
+// sliceFrag returns a Slice unless there are parameters in which case a
+// ParamSlice is returned.
+func sliceFrag(values []any) Frag {
+	s := make(Slice, len(values))
+	for i, v := range values {
+		n, ok := v.(int)
+		if !ok {
+			return ParamSlice(values)
+		}
+		s[i] = n
+	}
+	return s
+}
+
+// readParam reads the name of a parameter that starts with a letter or
+// underscore followed by letters, digits, or underscores. The position
+// must be just after the '$'.
+func (p *parser) readParam() Param {
+	start := p.pos
+	for ; p.pos < len(p.buf); p.pos++ {
+		b := p.buf[p.pos]
+		if !isParamByte(b) || (p.pos == start && '0' <= b && b <= '9') {
+			break
+		}
+	}
+	if p.pos == start {
+		p.raise("expected a parameter name")
+	}
+	return Param(p.buf[start:p.pos])
+}
+
+func isParamByte(b byte) bool {
+	return ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || ('0' <= b && b <= '9') || b == '_'
 }
 
 func (p *parser) readUnion(v any, b byte) Frag {
@@ -419,6 +492,9 @@ func (p *parser) readUnion(v any, b byte) Frag {
 			if b == ' ' {
 				b = p.skipSpace()
 			}
+		case '$':
+			f = append(f, p.readParam())
+			b = p.skipSpace()
 		default:
 			p.raise("invalid union syntax")
 		}
@@ -588,6 +664,11 @@ func (p *parser) readEq() (eq *Equation) {
 		s := p.readStr(b)
 		eq = &Equation{result: s}
 	case '@', '$':
+		if b == '$' && p.pos+1 < len(p.buf) && isParamByte(p.buf[p.pos+1]) {
+			p.pos++
+			eq = &Equation{result: p.readParam()}
+			break
+		}
 		x := p.readExpr()
 		eq = &Equation{result: x}
 	case '(':
@@ -640,12 +721,12 @@ func (p *parser) readEq() (eq *Equation) {
 	return
 }
 
-// reads just lower case alpha characters (a-z)
+// reads just lower case alpha characters (a-z) and underscores
 func (p *parser) readToken() []byte {
 	start := p.pos
 	for ; p.pos < len(p.buf); p.pos++ {
 		b := p.buf[p.pos]
-		if b < 'a' || 'z' < b {
+		if (b < 'a' || 'z' < b) && b != '_' {
 			break
 		}
 	}
@@ -656,6 +737,9 @@ func (p *parser) readOpArgs(o *op) (eq *Equation) {
 	if p.buf[p.pos] != '(' {
 		p.raise("expected a %s function", o.name)
 	}
+	if o.varFun != nil {
+		return p.readVarArgs(o)
+	}
 	eq = &Equation{o: o}
 	p.pos++
 	eq.left = p.readEq()
@@ -672,6 +756,35 @@ func (p *parser) readOpArgs(o *op) (eq *Equation) {
 	return
 }
 
+func (p *parser) readVarArgs(o *op) (eq *Equation) {
+	eq = &Equation{}
+	p.pos++
+	if p.nextNonSpace() == ')' {
+		p.pos++
+	} else {
+		for {
+			eq.args = append(eq.args, reduceGroups(precedentCorrect(p.readEq()), nil))
+			b := p.nextNonSpace()
+			p.pos++
+			if b == ')' {
+				break
+			}
+			if b != ',' {
+				p.raise("not terminated")
+			}
+		}
+	}
+	if len(eq.args) < o.minArgs || (0 <= o.maxArgs && o.maxArgs < len(eq.args)) || 255 < len(eq.args) {
+		p.raise("wrong number of arguments to %s", o.name)
+	}
+	// The argument count can vary so each use gets its own op.
+	vo := *o
+	vo.cnt = byte(len(eq.args))
+	eq.o = &vo
+
+	return
+}
+
 func (p *parser) readEqToken(token []byte) {
 	for _, t := range token {
 		if len(p.buf) <= p.pos || p.buf[p.pos] != t {
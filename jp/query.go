@@ -0,0 +1,417 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ohler55/ojg/alt"
+	"github.com/ohler55/ojg/gen"
+)
+
+// Query is an expression followed by stages that order, trim, and shape
+// the results of the expression. Stages are applied in the order they were
+// added so a limit before a sort is not the same as a limit after a sort.
+//
+//	q := jp.NewQuery(jp.MustParseString("$.items[*]")).
+//		SortBy(jp.MustParseString("@.price"), true).
+//		Limit(10).
+//		Project(map[string]*jp.Equation{
+//			"name":  jp.MustParseEquation("@.name"),
+//			"total": jp.MustParseEquation("@.price * @.count"),
+//		})
+type Query struct {
+	// Expr is the expression that provides the values for the stages.
+	Expr   Expr
+	stages []stage
+}
+
+type stage interface {
+	apply(values []any, root any, nodes bool) []any
+	append(buf []byte) []byte
+}
+
+type sortKey struct {
+	by   Expr
+	desc bool
+}
+
+type sortStage []sortKey
+
+type offsetStage int
+
+type limitStage int
+
+type distinctStage struct{}
+
+type projectStage struct {
+	fields  map[string]*Equation
+	scripts map[string]*Script
+}
+
+// NewQuery creates a Query with no stages for the expression.
+func NewQuery(x Expr) *Query {
+	return &Query{Expr: x}
+}
+
+// SortBy adds a sort stage that orders values by the first value found at
+// by relative to each value. Calling SortBy more than once in a row adds
+// secondary keys to the same stage with the first call being the primary
+// key. Values are ordered by type first with missing values before null,
+// then booleans, numbers, strings, arrays, and objects. Values of the same
+// type are ordered by value except for arrays and objects which are
+// ordered by size. The sort is stable so values that compare as equal
+// retain the order of the expression results.
+func (q *Query) SortBy(by Expr, descending bool) *Query {
+	if 0 < len(q.stages) {
+		if ss, ok := q.stages[len(q.stages)-1].(sortStage); ok {
+			q.stages[len(q.stages)-1] = append(ss, sortKey{by: by, desc: descending})
+			return q
+		}
+	}
+	q.stages = append(q.stages, sortStage{{by: by, desc: descending}})
+
+	return q
+}
+
+// Offset adds a stage that skips the first n values.
+func (q *Query) Offset(n int) *Query {
+	q.stages = append(q.stages, offsetStage(n))
+
+	return q
+}
+
+// Limit adds a stage that drops all but the first n values.
+func (q *Query) Limit(n int) *Query {
+	q.stages = append(q.stages, limitStage(n))
+
+	return q
+}
+
+// Distinct adds a stage that removes values equal to an earlier value.
+// Numbers are equal if they have the same value regardless of type.
+func (q *Query) Distinct() *Query {
+	q.stages = append(q.stages, distinctStage{})
+
+	return q
+}
+
+// Project adds a stage that replaces each value with an object that has a
+// member for each field. The member value is the result of evaluating the
+// field equation with @ as the value being replaced. Fields that do not
+// evaluate to a value are left out of the object.
+func (q *Query) Project(fields map[string]*Equation) *Query {
+	ps := projectStage{fields: fields, scripts: make(map[string]*Script, len(fields))}
+	for k, eq := range fields {
+		ps.scripts[k] = &Script{template: eq.buildScript([]any{})}
+	}
+	q.stages = append(q.stages, ps)
+
+	return q
+}
+
+// Get the values identified by the expression after they have passed
+// through each stage of the query.
+func (q *Query) Get(data any) []any {
+	values := q.Expr.Get(data)
+	for _, s := range q.stages {
+		values = s.apply(values, data, false)
+	}
+	return values
+}
+
+// GetNodes the values identified by the expression after they have passed
+// through each stage of the query.
+func (q *Query) GetNodes(data gen.Node) (results []gen.Node) {
+	nodes := q.Expr.GetNodes(data)
+	values := make([]any, len(nodes))
+	for i, n := range nodes {
+		values[i] = n
+	}
+	for _, s := range q.stages {
+		values = s.apply(values, data, true)
+	}
+	results = make([]gen.Node, len(values))
+	for i, v := range values {
+		results[i], _ = v.(gen.Node)
+	}
+	return
+}
+
+// String returns a string representation of the query with each stage
+// following the expression and separated by a '|'.
+func (q *Query) String() string {
+	buf := q.Expr.Append(nil)
+	for _, s := range q.stages {
+		buf = append(buf, " | "...)
+		buf = s.append(buf)
+	}
+	return string(buf)
+}
+
+func (ss sortStage) apply(values []any, root any, nodes bool) []any {
+	keys := make([][]any, len(values))
+	for i, v := range values {
+		keys[i] = make([]any, len(ss))
+		for j, sk := range ss {
+			if kv, has := sk.by.FirstFound(v); has {
+				keys[i][j] = normalize(kv)
+			} else {
+				keys[i][j] = Nothing
+			}
+		}
+	}
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		for k, sk := range ss {
+			c := compareValues(keys[order[i]][k], keys[order[j]][k])
+			if c == 0 {
+				continue
+			}
+			if sk.desc {
+				return 0 < c
+			}
+			return c < 0
+		}
+		return false
+	})
+	sorted := make([]any, len(values))
+	for i, oi := range order {
+		sorted[i] = values[oi]
+	}
+	return sorted
+}
+
+func (ss sortStage) append(buf []byte) []byte {
+	buf = append(buf, "sort("...)
+	for i, sk := range ss {
+		if 0 < i {
+			buf = append(buf, ", "...)
+		}
+		buf = sk.by.Append(buf)
+		if sk.desc {
+			buf = append(buf, " desc"...)
+		}
+	}
+	return append(buf, ')')
+}
+
+func (os offsetStage) apply(values []any, root any, nodes bool) []any {
+	switch {
+	case len(values) <= int(os):
+		return values[:0]
+	case 0 < os:
+		return values[os:]
+	}
+	return values
+}
+
+func (os offsetStage) append(buf []byte) []byte {
+	return fmt.Appendf(buf, "offset(%d)", int(os))
+}
+
+func (ls limitStage) apply(values []any, root any, nodes bool) []any {
+	switch {
+	case ls < 0:
+		return values[:0]
+	case int(ls) < len(values):
+		return values[:ls]
+	}
+	return values
+}
+
+func (ls limitStage) append(buf []byte) []byte {
+	return fmt.Appendf(buf, "limit(%d)", int(ls))
+}
+
+func (distinctStage) apply(values []any, root any, nodes bool) []any {
+	seen := map[string]bool{}
+	unique := make([]any, 0, len(values))
+	for _, v := range values {
+		key := distinctKey(v)
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+func (distinctStage) append(buf []byte) []byte {
+	return append(buf, "distinct"...)
+}
+
+func (ps projectStage) apply(values []any, root any, nodes bool) []any {
+	projected := make([]any, len(values))
+	for i, v := range values {
+		if nodes {
+			obj := gen.Object{}
+			for k, s := range ps.scripts {
+				if fv := s.value(v, root); fv != Nothing {
+					obj[k] = alt.Generify(fv)
+				}
+			}
+			projected[i] = obj
+			continue
+		}
+		obj := map[string]any{}
+		for k, s := range ps.scripts {
+			if fv := s.value(v, root); fv != Nothing {
+				obj[k] = fv
+			}
+		}
+		projected[i] = obj
+	}
+	return projected
+}
+
+func (ps projectStage) append(buf []byte) []byte {
+	keys := make([]string, 0, len(ps.fields))
+	for k := range ps.fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	buf = append(buf, '{')
+	for i, k := range keys {
+		if 0 < i {
+			buf = append(buf, ", "...)
+		}
+		buf = append(buf, k...)
+		buf = append(buf, ": "...)
+		buf = ps.fields[k].Append(buf, false)
+	}
+	return append(buf, '}')
+}
+
+// distinctKey returns a string that is the same for values that are
+// equal. Maps are printed with sorted keys so member order does not matter.
+func distinctKey(v any) string {
+	return fmt.Sprintf("%#v", normalizeDeep(v))
+}
+
+func normalizeDeep(v any) any {
+	switch tv := v.(type) {
+	case []any:
+		list := make([]any, len(tv))
+		for i, m := range tv {
+			list[i] = normalizeDeep(m)
+		}
+		return list
+	case gen.Array:
+		list := make([]any, len(tv))
+		for i, m := range tv {
+			list[i] = normalizeDeep(m)
+		}
+		return list
+	case map[string]any:
+		obj := make(map[string]any, len(tv))
+		for k, m := range tv {
+			obj[k] = normalizeDeep(m)
+		}
+		return obj
+	case gen.Object:
+		obj := make(map[string]any, len(tv))
+		for k, m := range tv {
+			obj[k] = normalizeDeep(m)
+		}
+		return obj
+	}
+	if f, ok := normalize(v).(float64); ok && f == float64(int64(f)) {
+		return int64(f)
+	}
+	return normalize(v)
+}
+
+// compareValues returns -1, 0, or 1 depending on whether left is less
+// than, equal to, or greater than right. Both values must be normalized.
+func compareValues(left, right any) int {
+	lr := valueRank(left)
+	rr := valueRank(right)
+	if lr != rr {
+		if lr < rr {
+			return -1
+		}
+		return 1
+	}
+	switch tl := left.(type) {
+	case bool:
+		if tl == right.(bool) {
+			return 0
+		}
+		if tl {
+			return 1
+		}
+		return -1
+	case int64:
+		switch tr := right.(type) {
+		case int64:
+			return compareOrdered(tl, tr)
+		case float64:
+			return compareOrdered(float64(tl), tr)
+		}
+	case float64:
+		switch tr := right.(type) {
+		case int64:
+			return compareOrdered(tl, float64(tr))
+		case float64:
+			return compareOrdered(tl, tr)
+		}
+	case string:
+		return strings.Compare(tl, right.(string))
+	case []any, gen.Array, map[string]any, gen.Object:
+		return compareOrdered(containerSize(left), containerSize(right))
+	}
+	if lr == 7 {
+		return strings.Compare(fmt.Sprintf("%v", left), fmt.Sprintf("%v", right))
+	}
+	return 0
+}
+
+func compareOrdered[T int | int64 | float64](left, right T) int {
+	switch {
+	case left < right:
+		return -1
+	case right < left:
+		return 1
+	}
+	return 0
+}
+
+func valueRank(v any) int {
+	switch v.(type) {
+	case nothing:
+		return 0
+	case nil:
+		return 1
+	case bool:
+		return 2
+	case int64, float64:
+		return 3
+	case string:
+		return 4
+	case []any, gen.Array:
+		return 5
+	case map[string]any, gen.Object:
+		return 6
+	}
+	return 7
+}
+
+func containerSize(v any) int {
+	switch tv := v.(type) {
+	case []any:
+		return len(tv)
+	case gen.Array:
+		return len(tv)
+	case map[string]any:
+		return len(tv)
+	case gen.Object:
+		return len(tv)
+	}
+	return 0
+}
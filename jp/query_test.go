@@ -0,0 +1,132 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp_test
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/alt"
+	"github.com/ohler55/ojg/gen"
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/oj"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+const queryData = `{
+  items: [
+    {name: pear price: 3 count: 2}
+    {name: apple price: 1.5 count: 4}
+    {name: fig price: 3 count: 1}
+    {name: kiwi count: 5}
+    {name: plum price: 2 count: 3}
+  ]
+}`
+
+func queryJSON(values any) string {
+	return oj.JSON(values, &oj.Options{Sort: true})
+}
+
+func TestQuerySort(t *testing.T) {
+	data := sen.MustParse([]byte(queryData))
+	q := jp.NewQuery(jp.MustParseString("$.items[*]")).SortBy(jp.MustParseString("@.price"), false)
+	tt.Equal(t, "$.items[*] | sort(@.price)", q.String())
+
+	var result []string
+	for _, v := range q.Get(data) {
+		result = append(result, jp.C("name").First(v).(string))
+	}
+	tt.Equal(t, []string{"kiwi", "apple", "plum", "pear", "fig"}, result)
+
+	q = jp.NewQuery(jp.MustParseString("$.items[*]")).
+		SortBy(jp.MustParseString("@.price"), true).
+		SortBy(jp.MustParseString("@.name"), false)
+	tt.Equal(t, "$.items[*] | sort(@.price desc, @.name)", q.String())
+	result = result[:0]
+	for _, v := range q.Get(data) {
+		result = append(result, jp.C("name").First(v).(string))
+	}
+	tt.Equal(t, []string{"fig", "pear", "plum", "apple", "kiwi"}, result)
+}
+
+func TestQuerySortMixed(t *testing.T) {
+	data := []any{"b", 2, nil, map[string]any{"x": 1}, true, []any{1, 2}, 1.5, false, "a", []any{}}
+	q := jp.NewQuery(jp.MustParseString("$[*]")).SortBy(jp.MustParseString("@"), false)
+	tt.Equal(t, `[null,false,true,1.5,2,"a","b",[],[1,2],{"x":1}]`, queryJSON(q.Get(data)))
+}
+
+func TestQueryLimitOffset(t *testing.T) {
+	data := []any{1, 2, 3, 4, 5}
+	for _, d := range []struct {
+		q      *jp.Query
+		expect string
+		str    string
+	}{
+		{q: jp.NewQuery(jp.R().W()).Limit(2), expect: "[1,2]", str: "$.* | limit(2)"},
+		{q: jp.NewQuery(jp.R().W()).Offset(3), expect: "[4,5]", str: "$.* | offset(3)"},
+		{q: jp.NewQuery(jp.R().W()).Offset(1).Limit(2), expect: "[2,3]", str: "$.* | offset(1) | limit(2)"},
+		{q: jp.NewQuery(jp.R().W()).Offset(9), expect: "[]", str: "$.* | offset(9)"},
+		{q: jp.NewQuery(jp.R().W()).Limit(9), expect: "[1,2,3,4,5]", str: "$.* | limit(9)"},
+		{q: jp.NewQuery(jp.R().W()).Limit(-1), expect: "[]", str: "$.* | limit(-1)"},
+		{
+			q:      jp.NewQuery(jp.R().W()).SortBy(jp.MustParseString("@"), true).Limit(2),
+			expect: "[5,4]",
+			str:    "$.* | sort(@ desc) | limit(2)",
+		},
+	} {
+		tt.Equal(t, d.str, d.q.String())
+		tt.Equal(t, d.expect, queryJSON(d.q.Get(data)), "%s", d.str)
+	}
+}
+
+func TestQueryDistinct(t *testing.T) {
+	data := sen.MustParse([]byte(`[1 2 1.0 "1" {a: 1 b: 2} {b: 2 a: 1} [1 2] [1 2] null null]`))
+	q := jp.NewQuery(jp.MustParseString("$[*]")).Distinct()
+	tt.Equal(t, "$[*] | distinct", q.String())
+	tt.Equal(t, `[1,2,"1",{"a":1,"b":2},[1,2],null]`, queryJSON(q.Get(data)))
+
+	nodes := q.GetNodes(alt.Generify(data))
+	tt.Equal(t, `[1,2,"1",{"a":1,"b":2},[1,2],null]`, queryJSON(nodes))
+}
+
+func TestQueryProject(t *testing.T) {
+	data := sen.MustParse([]byte(queryData))
+	q := jp.NewQuery(jp.MustParseString("$.items[?(@.count > 2)]")).
+		Project(map[string]*jp.Equation{
+			"name":  jp.MustParseEquation("@.name"),
+			"total": jp.MustParseEquation("@.price * @.count"),
+			"max":   jp.MustParseEquation("$.items[0].count"),
+		})
+	tt.Equal(t, `$.items[?(@.count > 2)] | {max: $.items[0].count, name: @.name, total: @.price * @.count}`, q.String())
+	tt.Equal(t,
+		`[{"max":2,"name":"apple","total":6},{"max":2,"name":"kiwi"},{"max":2,"name":"plum","total":6}]`,
+		queryJSON(q.Get(data)))
+
+	// Filters in GetNodes() do not return matches in the same order as Get()
+	// so sort before projecting.
+	q = jp.NewQuery(jp.MustParseString("$.items[?(@.count > 2)]")).
+		SortBy(jp.MustParseString("@.name"), false).
+		Project(map[string]*jp.Equation{
+			"name":  jp.MustParseEquation("@.name"),
+			"total": jp.MustParseEquation("@.price * @.count"),
+			"max":   jp.MustParseEquation("$.items[0].count"),
+		})
+	nodes := q.GetNodes(alt.Generify(data))
+	tt.Equal(t,
+		`[{"max":2,"name":"apple","total":6},{"max":2,"name":"kiwi"},{"max":2,"name":"plum","total":6}]`,
+		queryJSON(nodes))
+	_, ok := nodes[0].(gen.Object)
+	tt.Equal(t, true, ok)
+}
+
+func TestQueryPipeline(t *testing.T) {
+	data := sen.MustParse([]byte(queryData))
+	q := jp.NewQuery(jp.MustParseString("$.items[*]")).
+		SortBy(jp.MustParseString("@.count"), true).
+		Offset(1).
+		Limit(2).
+		Project(map[string]*jp.Equation{"n": jp.MustParseEquation("@.name")})
+
+	tt.Equal(t, `[{"n":"apple"},{"n":"plum"}]`, queryJSON(q.Get(data)))
+	tt.Equal(t, `[{"n":"apple"},{"n":"plum"}]`, queryJSON(q.GetNodes(alt.Generify(data))))
+}
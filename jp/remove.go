@@ -25,6 +25,13 @@ func (x Expr) MustRemove(data any) any {
 	if len(x) == 0 {
 		panic("can not remove with an empty expression")
 	}
+	if x.editorOnly(false) || x[:len(x)-1].editorOnly(false) {
+		result, _, err := (&Editor{}).remove(x, data, 0)
+		if err != nil {
+			panic(err)
+		}
+		return result
+	}
 	last := x[len(x)-1]
 
 	sx := x[:len(x)-1]
@@ -46,6 +53,13 @@ func (x Expr) MustRemoveOne(data any) any {
 	if len(x) == 0 {
 		panic("can not remove with an empty expression")
 	}
+	if x.editorOnly(false) || x[:len(x)-1].editorOnly(false) {
+		result, _, err := (&Editor{}).remove(x, data, 1)
+		if err != nil {
+			panic(err)
+		}
+		return result
+	}
 	last := x[len(x)-1]
 
 	sx := x[:len(x)-1]
@@ -227,12 +227,13 @@ func TestExprRemoveOneMap(t *testing.T) {
 	tt.Equal(t, 1, len(x.Get(data)))
 }
 
-func TestExprRemoveOneFail(t *testing.T) {
+func TestExprRemoveOneDescent(t *testing.T) {
 	x, err := jp.ParseString("..")
 	tt.Nil(t, err)
 	data := sen.MustParse([]byte("{one:[0,1,2] two:[3,2,1]}"))
-	_, err = x.RemoveOne(data)
-	tt.NotNil(t, err)
+	result, err := x.RemoveOne(data)
+	tt.Nil(t, err)
+	tt.Equal(t, 1, len(result.(map[string]any)))
 }
 
 func TestExprRemoveFail(t *testing.T) {
@@ -437,11 +438,13 @@ func TestExprRemoveDescent(t *testing.T) {
 	x, err := jp.ParseString("..[1]")
 	tt.Nil(t, err)
 	data := sen.MustParse([]byte(`[[1,2,[1,2,3,4]]]`))
-	tt.Panic(t, func() { _ = x.MustRemove(data) })
+	result := x.MustRemove(data)
+	tt.Equal(t, "[[1 [1 3 4]]]", string(sen.String(result)))
 
 	x, err = jp.ParseString("$..")
 	tt.Nil(t, err)
-	tt.Panic(t, func() { _ = x.MustRemove(data) })
+	result = x.MustRemove(data)
+	tt.Equal(t, "[]", string(sen.String(result)))
 }
 
 func TestExprRemoveEmptyExpr(t *testing.T) {
@@ -0,0 +1,752 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp
+
+import (
+	"regexp"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/ohler55/ojg"
+)
+
+// maxSafeInt is the largest integer allowed by RFC 9535 for indices and
+// slice parameters.
+const maxSafeInt = 1<<53 - 1
+
+// Kinds of filter operands used to check that filter expressions are well
+// typed as required by RFC 9535.
+const (
+	literalKind = iota
+	singularKind
+	queryKind
+	valueFunKind
+	logicalFunKind
+)
+
+// The RFC 9535 comparisons and functions have the same names as the default
+// operations but compare structured values member by member, count
+// characters instead of bytes, and use I-Regexp patterns.
+var (
+	rfcEq     = &op{prec: 3, code: 'E', name: "==", cnt: 2}
+	rfcNeq    = &op{prec: 3, code: 'N', name: "!=", cnt: 2}
+	rfcLte    = &op{prec: 3, code: 'P', name: "<=", cnt: 2}
+	rfcGte    = &op{prec: 3, code: 'Q', name: ">=", cnt: 2}
+	rfcLength = &op{prec: 0, code: 'R', name: "length", cnt: 1}
+	rfcMatch  = &op{prec: 0, code: 'm', name: "match", cnt: 2}
+	rfcSearch = &op{prec: 0, code: 's', name: "search", cnt: 2}
+
+	rfcCompOps = map[string]*op{
+		"==": rfcEq,
+		"!=": rfcNeq,
+		"<":  lt,
+		">":  gt,
+		"<=": rfcLte,
+		">=": rfcGte,
+	}
+)
+
+// rfcRoot and rfcAt start expressions parsed by ParseRFC9535 so that
+// evaluation follows RFC 9535 where it differs from the default behavior.
+// They are written as '$' and '@'.
+const (
+	rfcRoot = Root('r')
+	rfcAt   = At('r')
+)
+
+// rfcParser parses the JSONPath syntax defined by RFC 9535. Unlike the
+// default parser the jp extensions such as scripts, procedures, regex
+// literals, and additional operators are rejected.
+type rfcParser struct {
+	parser
+}
+
+// ParseRFC9535String parses a string that must conform to RFC 9535 into an
+// Expr.
+func ParseRFC9535String(s string) (x Expr, err error) {
+	return ParseRFC9535([]byte(s))
+}
+
+// MustParseRFC9535String parses a string that must conform to RFC 9535 into
+// an Expr and panics on error.
+func MustParseRFC9535String(s string) (x Expr) {
+	return MustParseRFC9535([]byte(s))
+}
+
+// ParseRFC9535 parses a []byte that must conform to RFC 9535 into an Expr.
+// Only the standard syntax and the length(), count(), match(), search(), and
+// value() function extensions are accepted.
+func ParseRFC9535(buf []byte) (x Expr, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = ojg.NewError(r)
+		}
+	}()
+	x = MustParseRFC9535(buf)
+
+	return
+}
+
+// MustParseRFC9535 parses a []byte that must conform to RFC 9535 into an
+// Expr and panics on error.
+func MustParseRFC9535(buf []byte) (x Expr) {
+	p := &rfcParser{parser: parser{buf: buf}}
+	x = p.readQuery('$')
+	if p.pos < len(buf) {
+		p.raise("parse error")
+	}
+	return
+}
+
+// readQuery reads a query that starts with the identifier which must be
+// either '$' or '@'.
+func (p *rfcParser) readQuery(id byte) (x Expr) {
+	if len(p.buf) <= p.pos || p.buf[p.pos] != id {
+		p.raise("expected '%c'", id)
+	}
+	p.pos++
+	if id == '$' {
+		x = Expr{rfcRoot}
+	} else {
+		x = Expr{rfcAt}
+	}
+	for {
+		start := p.pos
+		p.skipBlank()
+		if len(p.buf) <= p.pos {
+			p.pos = start
+			return
+		}
+		switch p.buf[p.pos] {
+		case '[':
+			p.pos++
+			x = append(x, p.readBracket())
+		case '.':
+			p.pos++
+			if p.pos < len(p.buf) && p.buf[p.pos] == '.' {
+				p.pos++
+				x = append(x, Descent('.'))
+				if p.pos < len(p.buf) && p.buf[p.pos] == '[' {
+					p.pos++
+					x = append(x, p.readBracket())
+				} else {
+					x = append(x, p.readShorthand())
+				}
+			} else {
+				x = append(x, p.readShorthand())
+			}
+		default:
+			p.pos = start
+			return
+		}
+	}
+}
+
+func (p *rfcParser) readShorthand() Frag {
+	if len(p.buf) <= p.pos {
+		p.raise("not terminated")
+	}
+	if p.buf[p.pos] == '*' {
+		p.pos++
+		return Wildcard('*')
+	}
+	start := p.pos
+	for p.pos < len(p.buf) {
+		b := p.buf[p.pos]
+		switch {
+		case 'a' <= b && b <= 'z', 'A' <= b && b <= 'Z', b == '_':
+			p.pos++
+		case '0' <= b && b <= '9':
+			if p.pos == start {
+				p.raise("a name can not start with a digit")
+			}
+			p.pos++
+		case 0x80 <= b:
+			r, n := utf8.DecodeRune(p.buf[p.pos:])
+			if r == utf8.RuneError {
+				p.raise("invalid UTF-8")
+			}
+			p.pos += n
+		default:
+			if p.pos == start {
+				p.raise("expected a name")
+			}
+			return Child(p.buf[start:p.pos])
+		}
+	}
+	if p.pos == start {
+		p.raise("expected a name")
+	}
+	return Child(p.buf[start:p.pos])
+}
+
+func (p *rfcParser) readBracket() Frag {
+	var u Union
+	for {
+		p.skipBlank()
+		u = append(u, p.readSelector())
+		p.skipBlank()
+		if len(p.buf) <= p.pos {
+			p.raise("not terminated")
+		}
+		b := p.buf[p.pos]
+		p.pos++
+		if b == ']' {
+			break
+		}
+		if b != ',' {
+			p.pos--
+			p.raise("invalid bracket syntax")
+		}
+	}
+	if len(u) == 1 {
+		switch tu := u[0].(type) {
+		case string:
+			return Child(tu)
+		case int64:
+			return Nth(tu)
+		case Frag:
+			return tu
+		}
+	}
+	return u
+}
+
+func (p *rfcParser) readSelector() any {
+	if len(p.buf) <= p.pos {
+		p.raise("not terminated")
+	}
+	switch b := p.buf[p.pos]; b {
+	case '\'', '"':
+		p.pos++
+		return p.readString(b)
+	case '*':
+		p.pos++
+		return Wildcard('*')
+	case '?':
+		p.pos++
+		return p.readLogicalOr().Filter()
+	}
+	// An index or a slice.
+	var (
+		nums [3]int
+		set  [3]bool
+		i    int
+	)
+	for {
+		p.skipBlank()
+		if p.pos < len(p.buf) && (p.buf[p.pos] == '-' || ('0' <= p.buf[p.pos] && p.buf[p.pos] <= '9')) {
+			nums[i] = p.readInteger()
+			set[i] = true
+			p.skipBlank()
+		}
+		if i < 2 && p.pos < len(p.buf) && p.buf[p.pos] == ':' {
+			p.pos++
+			i++
+			continue
+		}
+		break
+	}
+	if i == 0 {
+		if !set[0] {
+			p.raise("invalid selector")
+		}
+		return int64(nums[0])
+	}
+	step := 1
+	if set[2] {
+		step = nums[2]
+	}
+	start := 0
+	end := maxEnd
+	if step < 0 {
+		start = maxEnd
+		end = -maxEnd
+	}
+	if set[0] {
+		start = nums[0]
+	}
+	if set[1] {
+		end = nums[1]
+	}
+	return Slice{start, end, step}
+}
+
+func (p *rfcParser) readInteger() int {
+	start := p.pos
+	if p.buf[p.pos] == '-' {
+		p.pos++
+	}
+	digits := p.pos
+	for p.pos < len(p.buf) && '0' <= p.buf[p.pos] && p.buf[p.pos] <= '9' {
+		p.pos++
+	}
+	switch {
+	case p.pos == digits:
+		p.raise("expected a digit")
+	case p.buf[digits] == '0' && (1 < p.pos-digits || digits != start):
+		p.raise("invalid integer")
+	}
+	i, err := strconv.ParseInt(string(p.buf[start:p.pos]), 10, 64)
+	if err != nil || i < -maxSafeInt || maxSafeInt < i {
+		p.raise("integer out of range")
+	}
+	return int(i)
+}
+
+func (p *rfcParser) readString(quote byte) string {
+	var buf []byte
+	for p.pos < len(p.buf) {
+		b := p.buf[p.pos]
+		p.pos++
+		switch {
+		case b == quote:
+			return string(buf)
+		case b < 0x20:
+			p.raise("invalid character in string")
+		case b == '\\':
+			buf = p.readEscape(buf, quote)
+		case b < 0x80:
+			buf = append(buf, b)
+		default:
+			r, n := utf8.DecodeRune(p.buf[p.pos-1:])
+			if r == utf8.RuneError {
+				p.raise("invalid UTF-8")
+			}
+			buf = append(buf, p.buf[p.pos-1:p.pos-1+n]...)
+			p.pos += n - 1
+		}
+	}
+	p.raise("not terminated")
+	return ""
+}
+
+func (p *rfcParser) readEscape(buf []byte, quote byte) []byte {
+	if len(p.buf) <= p.pos {
+		p.raise("not terminated")
+	}
+	b := p.buf[p.pos]
+	p.pos++
+	switch b {
+	case 'b':
+		buf = append(buf, '\b')
+	case 'f':
+		buf = append(buf, '\f')
+	case 'n':
+		buf = append(buf, '\n')
+	case 'r':
+		buf = append(buf, '\r')
+	case 't':
+		buf = append(buf, '\t')
+	case '/', '\\':
+		buf = append(buf, b)
+	case '\'', '"':
+		if b != quote {
+			p.raise("invalid escape")
+		}
+		buf = append(buf, b)
+	case 'u':
+		r := p.readHex4()
+		switch {
+		case 0xDC00 <= r && r <= 0xDFFF:
+			p.raise("lone surrogate")
+		case 0xD800 <= r && r <= 0xDBFF:
+			if len(p.buf) <= p.pos+1 || p.buf[p.pos] != '\\' || p.buf[p.pos+1] != 'u' {
+				p.raise("lone surrogate")
+			}
+			p.pos += 2
+			r2 := p.readHex4()
+			if r2 < 0xDC00 || 0xDFFF < r2 {
+				p.raise("invalid surrogate pair")
+			}
+			r = utf16.DecodeRune(r, r2)
+		}
+		buf = utf8.AppendRune(buf, r)
+	default:
+		p.raise("invalid escape")
+	}
+	return buf
+}
+
+func (p *rfcParser) readHex4() (r rune) {
+	if len(p.buf) < p.pos+4 {
+		p.raise("not terminated")
+	}
+	for _, b := range p.buf[p.pos : p.pos+4] {
+		switch {
+		case '0' <= b && b <= '9':
+			r = r<<4 | rune(b-'0')
+		case 'a' <= b && b <= 'f':
+			r = r<<4 | rune(b-'a'+10)
+		case 'A' <= b && b <= 'F':
+			r = r<<4 | rune(b-'A'+10)
+		default:
+			p.raise("invalid hexadecimal character")
+		}
+		p.pos++
+	}
+	return
+}
+
+func (p *rfcParser) readLogicalOr() *Equation {
+	e := p.readLogicalAnd()
+	for {
+		start := p.pos
+		p.skipBlank()
+		if !p.startsWith("||") {
+			p.pos = start
+			return e
+		}
+		p.pos += 2
+		e = Or(e, p.readLogicalAnd())
+	}
+}
+
+func (p *rfcParser) readLogicalAnd() *Equation {
+	e := p.readBasic()
+	for {
+		start := p.pos
+		p.skipBlank()
+		if !p.startsWith("&&") {
+			p.pos = start
+			return e
+		}
+		p.pos += 2
+		e = And(e, p.readBasic())
+	}
+}
+
+func (p *rfcParser) readBasic() *Equation {
+	p.skipBlank()
+	if p.startsWith("!") {
+		p.pos++
+		p.skipBlank()
+		if p.startsWith("(") {
+			return Not(p.readParen())
+		}
+		e, kind := p.readOperand()
+		if kind != singularKind && kind != queryKind && kind != logicalFunKind {
+			p.raise("expected a test expression")
+		}
+		return Not(e)
+	}
+	if p.startsWith("(") {
+		return p.readParen()
+	}
+	left, kind := p.readOperand()
+	start := p.pos
+	p.skipBlank()
+	for _, name := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if !p.startsWith(name) {
+			continue
+		}
+		if kind != literalKind && kind != singularKind && kind != valueFunKind {
+			p.raise("not comparable")
+		}
+		p.pos += len(name)
+		p.skipBlank()
+		right, rkind := p.readOperand()
+		if rkind != literalKind && rkind != singularKind && rkind != valueFunKind {
+			p.raise("not comparable")
+		}
+		return &Equation{o: rfcCompOps[name], left: left, right: right}
+	}
+	p.pos = start
+	if kind != singularKind && kind != queryKind && kind != logicalFunKind {
+		p.raise("expected a test expression")
+	}
+	return left
+}
+
+func (p *rfcParser) readParen() (e *Equation) {
+	p.pos++
+	e = p.readLogicalOr()
+	p.skipBlank()
+	if !p.startsWith(")") {
+		p.raise("expected ')'")
+	}
+	p.pos++
+
+	return
+}
+
+func (p *rfcParser) readOperand() (*Equation, int) {
+	if len(p.buf) <= p.pos {
+		p.raise("not terminated")
+	}
+	b := p.buf[p.pos]
+	switch {
+	case b == '@' || b == '$':
+		x := p.readQuery(b)
+		for _, f := range x[1:] {
+			switch f.(type) {
+			case Child, Nth:
+			default:
+				return &Equation{result: x}, queryKind
+			}
+		}
+		return &Equation{result: x}, singularKind
+	case b == '\'' || b == '"':
+		p.pos++
+		return &Equation{result: p.readString(b)}, literalKind
+	case b == '-' || ('0' <= b && b <= '9'):
+		return &Equation{result: p.readNumber()}, literalKind
+	case 'a' <= b && b <= 'z':
+		start := p.pos
+		for p.pos < len(p.buf) {
+			b = p.buf[p.pos]
+			if ('a' <= b && b <= 'z') || ('0' <= b && b <= '9') || b == '_' {
+				p.pos++
+				continue
+			}
+			break
+		}
+		name := string(p.buf[start:p.pos])
+		if p.startsWith("(") {
+			p.pos++
+			return p.readFunction(name)
+		}
+		switch name {
+		case "true":
+			return &Equation{result: true}, literalKind
+		case "false":
+			return &Equation{result: false}, literalKind
+		case "null":
+			return &Equation{result: nil}, literalKind
+		}
+		p.pos = start
+		p.raise("invalid literal")
+	}
+	p.raise("expected a literal, query, or function")
+	return nil, 0
+}
+
+// readFunction reads the arguments of one of the function extensions
+// defined by RFC 9535 and checks the argument types.
+func (p *rfcParser) readFunction(name string) (*Equation, int) {
+	var (
+		o    *op
+		args []int // literalKind for ValueType or queryKind for NodesType
+		kind = valueFunKind
+	)
+	switch name {
+	case "length":
+		o = rfcLength
+		args = []int{literalKind}
+	case "count":
+		o = count
+		args = []int{queryKind}
+	case "value":
+		o = value
+		args = []int{queryKind}
+	case "match":
+		o = rfcMatch
+		args = []int{literalKind, literalKind}
+		kind = logicalFunKind
+	case "search":
+		o = rfcSearch
+		args = []int{literalKind, literalKind}
+		kind = logicalFunKind
+	default:
+		p.raise("%s is not a function", name)
+	}
+	e := &Equation{o: o}
+	for i, at := range args {
+		p.skipBlank()
+		if 0 < i {
+			if !p.startsWith(",") {
+				p.raise("expected ','")
+			}
+			p.pos++
+			p.skipBlank()
+		}
+		arg, ak := p.readOperand()
+		switch at {
+		case literalKind:
+			if ak != literalKind && ak != singularKind && ak != valueFunKind {
+				p.raise("%s() argument must be a value", name)
+			}
+		case queryKind:
+			if ak != singularKind && ak != queryKind {
+				p.raise("%s() argument must be a query", name)
+			}
+		}
+		if i == 0 {
+			e.left = arg
+		} else {
+			e.right = arg
+		}
+	}
+	p.skipBlank()
+	if !p.startsWith(")") {
+		p.raise("expected ')'")
+	}
+	p.pos++
+
+	return e, kind
+}
+
+func (p *rfcParser) readNumber() any {
+	start := p.pos
+	if p.buf[p.pos] == '-' {
+		p.pos++
+	}
+	digits := p.pos
+	p.skipDigits()
+	if p.pos == digits || (p.buf[digits] == '0' && 1 < p.pos-digits) {
+		p.raise("invalid number")
+	}
+	isFloat := false
+	if p.startsWith(".") {
+		p.pos++
+		if p.skipDigits() == 0 {
+			p.raise("invalid number")
+		}
+		isFloat = true
+	}
+	if p.startsWith("e") || p.startsWith("E") {
+		p.pos++
+		if p.startsWith("-") || p.startsWith("+") {
+			p.pos++
+		}
+		if p.skipDigits() == 0 {
+			p.raise("invalid number")
+		}
+		isFloat = true
+	}
+	s := string(p.buf[start:p.pos])
+	if !isFloat {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i
+		}
+	}
+	f, _ := strconv.ParseFloat(s, 64)
+
+	return f
+}
+
+func (p *rfcParser) skipDigits() (cnt int) {
+	for p.pos < len(p.buf) && '0' <= p.buf[p.pos] && p.buf[p.pos] <= '9' {
+		p.pos++
+		cnt++
+	}
+	return
+}
+
+// skipBlank skips the blank space allowed by RFC 9535 which is a space, tab,
+// newline, or carriage return.
+func (p *rfcParser) skipBlank() {
+	for p.pos < len(p.buf) {
+		switch p.buf[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *rfcParser) startsWith(s string) bool {
+	return len(s) <= len(p.buf)-p.pos && string(p.buf[p.pos:p.pos+len(s)]) == s
+}
+
+// compileIRegexp compiles an I-Regexp (RFC 9485) pattern as used by the
+// match() and search() functions. A '.' does not match a line terminator
+// and '^' and '$' are ordinary characters. If full is true the pattern must
+// match the whole string.
+func compileIRegexp(pattern string, full bool) (*regexp.Regexp, error) {
+	buf := make([]byte, 0, len(pattern)+16)
+	if full {
+		buf = append(buf, `\A(?:`...)
+	}
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		b := pattern[i]
+		switch {
+		case b == '\\' && i+1 < len(pattern):
+			i++
+			buf = append(buf, b, pattern[i])
+		case inClass:
+			if b == ']' {
+				inClass = false
+			}
+			buf = append(buf, b)
+		case b == '[':
+			inClass = true
+			buf = append(buf, b)
+			if i+1 < len(pattern) && pattern[i+1] == '^' {
+				i++
+				buf = append(buf, '^')
+			}
+		case b == '.':
+			buf = append(buf, `[^\n\r]`...)
+		case b == '^' || b == '$':
+			buf = append(buf, '\\', b)
+		default:
+			buf = append(buf, b)
+		}
+	}
+	if full {
+		buf = append(buf, `)\z`...)
+	}
+	return regexp.Compile(string(buf))
+}
+
+// rfc returns true if the expression was parsed by ParseRFC9535.
+func (x Expr) rfc() bool {
+	return 0 < len(x) && (x[0] == rfcRoot || x[0] == rfcAt)
+}
+
+// NormalizedString returns the normalized path of the expression as defined
+// by RFC 9535 such as $['a'][0]. Fragments other than root, child, and nth
+// are written in bracket notation.
+func (x Expr) NormalizedString() string {
+	var buf []byte
+	for i, f := range x {
+		switch tf := f.(type) {
+		case Child:
+			buf = appendNormalName(append(buf, '['), string(tf))
+			buf = append(buf, ']')
+		case Nth:
+			buf = append(buf, '[')
+			buf = strconv.AppendInt(buf, int64(tf), 10)
+			buf = append(buf, ']')
+		case Bracket:
+		default:
+			buf = f.Append(buf, true, i == 0)
+		}
+	}
+	return string(buf)
+}
+
+func appendNormalName(buf []byte, s string) []byte {
+	buf = append(buf, '\'')
+	for i := 0; i < len(s); i++ {
+		b := s[i]
+		switch b {
+		case '\b':
+			buf = append(buf, `\b`...)
+		case '\f':
+			buf = append(buf, `\f`...)
+		case '\n':
+			buf = append(buf, `\n`...)
+		case '\r':
+			buf = append(buf, `\r`...)
+		case '\t':
+			buf = append(buf, `\t`...)
+		case '\'':
+			buf = append(buf, `\'`...)
+		case '\\':
+			buf = append(buf, `\\`...)
+		default:
+			if b < 0x20 {
+				buf = append(buf, `\u00`...)
+				buf = append(buf, hex[b>>4], hex[b&0x0f])
+			} else {
+				buf = append(buf, b)
+			}
+		}
+	}
+	return append(buf, '\'')
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package jp_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/oj"
+	"github.com/ohler55/ojg/tt"
+)
+
+const ctsPath = "testdata/jsonpath-compliance-test-suite/cts.json"
+
+// rfc9535KnownFailures are the names of the compliance test suite cases that
+// are known to fail.
+var rfc9535KnownFailures = map[string]bool{}
+
+func TestRFC9535Compliance(t *testing.T) {
+	buf, err := os.ReadFile(ctsPath)
+	if os.IsNotExist(err) {
+		t.Skipf("%s not found, see testdata/jsonpath-compliance-test-suite/README.md", ctsPath)
+	}
+	tt.Nil(t, err)
+	runRFC9535Cases(t, buf, rfc9535KnownFailures)
+}
+
+func TestRFC9535Cases(t *testing.T) {
+	buf, err := os.ReadFile("testdata/rfc9535.json")
+	tt.Nil(t, err)
+	runRFC9535Cases(t, buf, nil)
+}
+
+func runRFC9535Cases(t *testing.T, buf []byte, knownFailures map[string]bool) {
+	suite, err := oj.Parse(buf)
+	tt.Nil(t, err)
+	for _, tc := range jp.C("tests").W().Get(suite) {
+		c := tc.(map[string]any)
+		name, _ := c["name"].(string)
+		selector, _ := c["selector"].(string)
+		msg := checkRFC9535Case(c)
+		switch {
+		case knownFailures[name] && len(msg) == 0:
+			t.Errorf("%s: %s passes but is listed as a known failure", name, selector)
+		case !knownFailures[name] && 0 < len(msg):
+			t.Errorf("%s: %s %s", name, selector, msg)
+		}
+	}
+}
+
+// checkRFC9535Case returns a description of the failure or an empty string
+// if the case passes.
+func checkRFC9535Case(c map[string]any) string {
+	x, err := jp.ParseRFC9535String(c["selector"].(string))
+	if invalid, _ := c["invalid_selector"].(bool); invalid {
+		if err == nil {
+			return "is not rejected"
+		}
+		return ""
+	}
+	if err != nil {
+		return err.Error()
+	}
+	results := c["results"]
+	paths := c["results_paths"]
+	if r, has := c["result"]; has {
+		results = []any{r}
+		paths = []any{c["result_paths"]}
+	}
+	got := oj.JSON(x.Get(c["document"]), &oj.Options{Sort: true})
+	match := false
+	for _, r := range results.([]any) {
+		if got == oj.JSON(r, &oj.Options{Sort: true}) {
+			match = true
+			break
+		}
+	}
+	if !match {
+		return "got " + got
+	}
+	if paths == nil {
+		return ""
+	}
+	locs := []any{}
+	for _, loc := range x.Locate(c["document"], 0) {
+		locs = append(locs, loc.NormalizedString())
+	}
+	for _, p := range paths.([]any) {
+		if oj.JSON(locs) == oj.JSON(p) {
+			return ""
+		}
+	}
+	return "located " + oj.JSON(locs)
+}
+
+func TestParseRFC9535Errors(t *testing.T) {
+	for _, d := range []struct {
+		src    string
+		expect string
+	}{
+		{src: "a", expect: "expected '$' at 1 in a"},
+		{src: "$.a.", expect: "not terminated at 5 in $.a."},
+		{src: "$[1 2]", expect: "invalid bracket syntax at 5 in $[1 2]"},
+		{src: "$[?length(@.*)==1]", expect: "length() argument must be a value at 14 in $[?length(@.*)==1]"},
+		{src: "$[?@.a=~'x']", expect: "invalid bracket syntax at 7 in $[?@.a=~'x']"},
+		{src: "$[?x(@)]", expect: "x is not a function at 6 in $[?x(@)]"},
+	} {
+		_, err := jp.ParseRFC9535String(d.src)
+		tt.NotNil(t, err, d.src)
+		tt.Equal(t, d.expect, err.Error(), d.src)
+	}
+}
+
+func TestExprNormalizedString(t *testing.T) {
+	x := jp.R().C("a").N(2).C("it's").C("tab\there").C("\x01")
+	tt.Equal(t, `$['a'][2]['it\'s']['tab\there']['\u0001']`, x.NormalizedString())
+
+	x = jp.MustParseRFC9535String("$[?@.x>1,'y']")
+	tt.Equal(t, "$[?(@.x > 1),'y']", x.String())
+}
+
+func TestRFC9535Semantics(t *testing.T) {
+	data := map[string]any{
+		"a": []any{
+			map[string]any{"s": "abc123", "v": []any{1, 2}, "w": "ü"},
+			map[string]any{"s": "x^abc$", "v": map[string]any{"k": 1}, "w": "u"},
+			map[string]any{"s": true, "v": []any{1, 2}, "w": []any{1}},
+		},
+	}
+	for _, d := range []struct {
+		src     string
+		expect  string // default parser
+		rfc     string // RFC 9535 parser
+		useData any
+	}{
+		{src: "$.a[?search(@.s,'^abc')].s", expect: `["abc123"]`, rfc: `["x^abc$"]`},
+		{src: "$.a[?search(@.s,'3$')].s", expect: `["abc123"]`, rfc: `[]`},
+		{src: "$.a[?match(@.s,'^abc.*$')].s", expect: `["abc123"]`, rfc: `[]`},
+		{src: "$.a[?match(@.s,'x.abc.')].s", expect: `["x^abc$"]`, rfc: `["x^abc$"]`},
+		{src: "$.a[?@.v==$.a[2].v].s", expect: `[]`, rfc: `["abc123",true]`},
+		{src: "$.a[?@.v!=$.a[1].v].s", expect: `["abc123","x^abc$",true]`, rfc: `["abc123",true]`},
+		{src: "$.a[?@.s<=true].s", expect: `[]`, rfc: `[true]`},
+		{src: "$.a[?@.s>=true].s", expect: `[]`, rfc: `[true]`},
+		{src: "$.a[?length(@.w)==1].s", expect: `["x^abc$",true]`, rfc: `["abc123","x^abc$",true]`},
+		{src: "$[4:0:-2]", expect: `[]`, rfc: `[3]`, useData: []any{1, 2, 3}},
+		{src: "$..[?@.x]", expect: `[{"x":2},{"x":1,"y":[{"x":2}]}]`, rfc: `[{"x":1,"y":[{"x":2}]},{"x":2}]`,
+			useData: []any{map[string]any{"x": 1, "y": []any{map[string]any{"x": 2}}}}},
+		{src: "$..x", expect: `[2,1,3]`, rfc: `[1,2,3]`,
+			useData: []any{map[string]any{"y": []any{map[string]any{"x": 2}}, "x": 1}, map[string]any{"x": 3}}},
+	} {
+		doc := d.useData
+		if doc == nil {
+			doc = data
+		}
+		x := jp.MustParseString(d.src)
+		tt.Equal(t, d.expect, oj.JSON(x.Get(doc), &oj.Options{Sort: true}), "default: %s", d.src)
+		x = jp.MustParseRFC9535String(d.src)
+		tt.Equal(t, d.rfc, oj.JSON(x.Get(doc), &oj.Options{Sort: true}), "rfc: %s", d.src)
+	}
+}
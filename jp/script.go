@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/ohler55/ojg"
 	"github.com/ohler55/ojg/gen"
@@ -44,6 +45,7 @@ var (
 	count  = &op{prec: 0, code: 'C', name: "count", cnt: 1, getLeft: true}
 	match  = &op{prec: 0, code: 'M', name: "match", cnt: 2}
 	search = &op{prec: 0, code: 'S', name: "search", cnt: 2}
+	value  = &op{prec: 0, code: 'V', name: "value", cnt: 1, getLeft: true}
 
 	// group is for an equation inside () so it represents the (). It should
 	// not be in the opMap.
@@ -74,6 +76,7 @@ var (
 		count.name:  count,
 		match.name:  match,
 		search.name: search,
+		value.name:  value,
 	}
 	// Nothing can be used in scripts to indicate no value as in a script such
 	// as [?(@.x == Nothing)] this indicates there was no value as @.x. It is
@@ -85,6 +88,9 @@ type op struct {
 	name     string
 	uniFun   func(arg any) any
 	duoFun   func(left, right any) any
+	varFun   func(args []any) any
+	minArgs  int
+	maxArgs  int
 	prec     byte
 	cnt      byte
 	code     byte
@@ -140,6 +146,13 @@ func (s *Script) Append(buf []byte) []byte {
 				}
 				continue
 			}
+			if o.varFun != nil {
+				bstack[i] = s.appendFunc(o, bstack[i+1:i+int(o.cnt)+1])
+				if i+int(o.cnt)+1 <= len(bstack) {
+					copy(bstack[i+1:], bstack[i+int(o.cnt)+1:])
+				}
+				continue
+			}
 			var (
 				left  any
 				right any
@@ -229,6 +242,9 @@ func (s *Script) evalWithRoot(stack, data, root any) (any, Expr) {
 		data = da
 	default:
 		rv := reflect.ValueOf(td)
+		if !rv.IsValid() {
+			return stack, locs
+		}
 		if rt := rv.Type(); rt.Kind() == reflect.Ptr {
 			rv = rv.Elem()
 		}
@@ -257,73 +273,8 @@ func (s *Script) evalWithRoot(stack, data, root any) (any, Expr) {
 		}
 		// Eval script for each member of the list.
 		copy(sstack, s.template)
-		var (
-			match bool
-			multi bool
-		)
-		for i, ev := range sstack {
-			if 0 < i {
-				// Check for functions like 'count'.
-				if o, ok := sstack[i-1].(*op); ok && o.getLeft {
-					var x Expr
-					if x, ok = ev.(Expr); ok {
-						ev = x.Get(v)
-					} else {
-						ev = nil
-					}
-					sstack[i] = ev
-				}
-				// TBD one more for getRight once function extensions are supported
-			}
-			if x, ok := ev.(Expr); ok {
-				var has bool
-				dv := v
-				switch x[0].(type) {
-				case At:
-					// The most common pattern is [?(@.child == value)] where
-					// the operation and value vary but the @.child is the
-					// most widely used. For that reason an optimization is
-					// included for that condition of a one level child lookup
-					// path.
-					if m, ok := v.(map[string]any); ok && len(x) == 2 {
-						var c Child
-						if c, ok = x[1].(Child); ok {
-							if ev, has = m[string(c)]; has {
-								sstack[i] = &got{value: normalize(ev)}
-							} else {
-								sstack[i] = Nothing
-							}
-							continue
-						}
-					}
-				case Root:
-					dv = root
-				}
-				if _, ok := x[0].(norm); ok {
-					x = x[1:]
-					if ev, has = x.FirstFound(dv); has {
-						sstack[i] = &got{value: normalize(ev)}
-					} else {
-						sstack[i] = Nothing
-					}
-				} else {
-					values := x.Get(dv)
-					switch len(values) {
-					case 0:
-						sstack[i] = Nothing
-					case 1:
-						sstack[i] = &got{value: normalize(values[0])}
-					default:
-						multi = true
-						mval := make(multivalue, len(values))
-						for gi, gv := range values {
-							mval[gi] = &got{value: normalize(gv)}
-						}
-						sstack[i] = mval
-					}
-				}
-			}
-		}
+		var match bool
+		multi := s.fill(sstack, v, root)
 		if multi {
 			max := 1
 			for _, v := range sstack {
@@ -464,6 +415,158 @@ Start:
 	return v
 }
 
+// fill replaces the expressions in a copy of the template with the values
+// they evaluate to for v. If any expression evaluates to more than one
+// value then multi is returned as true.
+func (s *Script) fill(sstack []any, v, root any) (multi bool) {
+	for i, ev := range sstack {
+		if 0 < i {
+			// Check for functions like 'count'.
+			if o, ok := sstack[i-1].(*op); ok && o.getLeft {
+				var x Expr
+				if x, ok = ev.(Expr); ok {
+					if _, ok = x[0].(Root); ok {
+						ev = x.Get(root)
+					} else {
+						ev = x.Get(v)
+					}
+				} else {
+					ev = nil
+				}
+				sstack[i] = ev
+			}
+			// TBD one more for getRight once function extensions are supported
+		}
+		if _, ok := ev.(Param); ok {
+			// An unbound parameter has no value.
+			sstack[i] = Nothing
+			continue
+		}
+		if x, ok := ev.(Expr); ok {
+			var has bool
+			dv := v
+			switch x[0].(type) {
+			case At:
+				// The most common pattern is [?(@.child == value)] where
+				// the operation and value vary but the @.child is the
+				// most widely used. For that reason an optimization is
+				// included for that condition of a one level child lookup
+				// path.
+				if m, ok := v.(map[string]any); ok && len(x) == 2 {
+					var c Child
+					if c, ok = x[1].(Child); ok {
+						if ev, has = m[string(c)]; has {
+							sstack[i] = &got{value: normalize(ev)}
+						} else {
+							sstack[i] = Nothing
+						}
+						continue
+					}
+				}
+			case Root:
+				dv = root
+			}
+			if _, ok := x[0].(norm); ok {
+				x = x[1:]
+				if ev, has = x.FirstFound(dv); has {
+					sstack[i] = &got{value: normalize(ev)}
+				} else {
+					sstack[i] = Nothing
+				}
+			} else {
+				values := x.Get(dv)
+				switch len(values) {
+				case 0:
+					sstack[i] = Nothing
+				case 1:
+					sstack[i] = &got{value: normalize(values[0])}
+				default:
+					multi = true
+					mval := make(multivalue, len(values))
+					for gi, gv := range values {
+						mval[gi] = &got{value: normalize(gv)}
+					}
+					sstack[i] = mval
+				}
+			}
+		}
+	}
+	return
+}
+
+// value evaluates the script for data and returns the result of the
+// evaluation instead of a match indicator. When an expression in the script
+// matches more than one value only the first is used. Nothing is returned
+// if the evaluation does not produce a value.
+func (s *Script) value(data, root any) any {
+	sstack := make([]any, len(s.template))
+	copy(sstack, s.template)
+	if s.fill(sstack, data, root) {
+		sstack = expandStack(sstack, 0)
+	}
+	sstack = evalStack(sstack)
+	if g, ok := sstack[0].(*got); ok {
+		return g.value
+	}
+	return sstack[0]
+}
+
+// sameValue returns true if the left and right values are equal. Numbers are
+// compared by value while lists and maps are compared member by member.
+func sameValue(left, right any) bool {
+	switch tl := left.(type) {
+	case int64:
+		switch tr := right.(type) {
+		case int64:
+			return tl == tr
+		case float64:
+			return float64(tl) == tr
+		}
+		return false
+	case float64:
+		switch tr := right.(type) {
+		case int64:
+			return tl == float64(tr)
+		case float64:
+			return tl == tr
+		}
+		return false
+	case []any:
+		tr, ok := right.([]any)
+		if !ok || len(tl) != len(tr) {
+			return false
+		}
+		for i, v := range tl {
+			if !sameValue(normalize(v), normalize(tr[i])) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		tr, ok := right.(map[string]any)
+		if !ok || len(tl) != len(tr) {
+			return false
+		}
+		for k, v := range tl {
+			rv, has := tr[k]
+			if !has || !sameValue(normalize(v), normalize(rv)) {
+				return false
+			}
+		}
+		return true
+	}
+	if !isComparable(left) {
+		return reflect.DeepEqual(left, right)
+	}
+	return left == right
+}
+
+// isComparable returns false if comparing the value with == would panic.
+func isComparable(v any) bool {
+	rt := reflect.TypeOf(v)
+	return rt == nil || rt.Comparable()
+}
+
 func expandStack(stack []any, mi int) []any {
 	nstack := make([]any, len(stack))
 	for i, v := range stack {
@@ -508,7 +611,7 @@ func evalStack(sstack []any) []any {
 		case group.code:
 			sstack[i] = left
 		case eq.code:
-			if left == right {
+			if isComparable(left) && left == right {
 				sstack[i] = true
 			} else {
 				sstack[i] = false
@@ -523,7 +626,7 @@ func evalStack(sstack []any) []any {
 				}
 			}
 		case neq.code:
-			if left == right {
+			if isComparable(left) && left == right {
 				sstack[i] = false
 			} else {
 				sstack[i] = true
@@ -537,6 +640,10 @@ func evalStack(sstack []any) []any {
 					sstack[i] = ok && tl != float64(tr)
 				}
 			}
+		case rfcEq.code:
+			sstack[i] = sameValue(left, right)
+		case rfcNeq.code:
+			sstack[i] = !sameValue(left, right)
 		case lt.code:
 			sstack[i] = false
 			switch tl := left.(type) {
@@ -579,8 +686,8 @@ func evalStack(sstack []any) []any {
 				tr, ok := right.(string)
 				sstack[i] = ok && tl > tr
 			}
-		case lte.code:
-			sstack[i] = false
+		case lte.code, rfcLte.code:
+			sstack[i] = o == rfcLte && sameValue(left, right)
 			switch tl := left.(type) {
 			case int64:
 				switch tr := right.(type) {
@@ -600,8 +707,8 @@ func evalStack(sstack []any) []any {
 				tr, ok := right.(string)
 				sstack[i] = ok && tl <= tr
 			}
-		case gte.code:
-			sstack[i] = false
+		case gte.code, rfcGte.code:
+			sstack[i] = o == rfcGte && sameValue(left, right)
 			switch tl := left.(type) {
 			case int64:
 				switch tr := right.(type) {
@@ -774,11 +881,15 @@ func evalStack(sstack []any) []any {
 			case *regexp.Regexp:
 				sstack[i] = tr.MatchString(ls)
 			}
-		case length.code:
+		case length.code, rfcLength.code:
 			sstack[i] = Nothing
 			switch tl := left.(type) {
 			case string:
-				sstack[i] = int64(len(tl))
+				if o == rfcLength {
+					sstack[i] = int64(utf8.RuneCountInString(tl))
+				} else {
+					sstack[i] = int64(len(tl))
+				}
 			case []any:
 				sstack[i] = int64(len(tl))
 			case map[string]any:
@@ -789,6 +900,11 @@ func evalStack(sstack []any) []any {
 			if nl, ok := left.([]any); ok {
 				sstack[i] = int64(len(nl))
 			}
+		case value.code:
+			sstack[i] = Nothing
+			if nl, ok := left.([]any); ok && len(nl) == 1 {
+				sstack[i] = normalize(nl[0])
+			}
 		case match.code:
 			sstack[i] = Nothing
 			if ls, ok := left.(string); ok {
@@ -813,11 +929,32 @@ func evalStack(sstack []any) []any {
 					}
 				}
 			}
+		case rfcMatch.code, rfcSearch.code:
+			sstack[i] = Nothing
+			if ls, ok := left.(string); ok {
+				if rs, ok := right.(string); ok {
+					if rx, err := compileIRegexp(rs, o == rfcMatch); err == nil {
+						sstack[i] = rx.MatchString(ls)
+					}
+				}
+			}
 		default:
-			if o.uniFun != nil {
+			switch {
+			case o.uniFun != nil:
 				sstack[i] = o.uniFun(left)
-			} else if o.duoFun != nil {
+			case o.duoFun != nil:
 				sstack[i] = o.duoFun(left, right)
+			case o.varFun != nil:
+				args := make([]any, o.cnt)
+				for ai := range args {
+					if i+ai+1 < len(sstack) {
+						args[ai] = sstack[i+ai+1]
+						if g, ok := args[ai].(*got); ok {
+							args[ai] = g.value
+						}
+					}
+				}
+				sstack[i] = o.varFun(args)
 			}
 		}
 		if i+int(o.cnt)+1 <= len(sstack) {
@@ -841,6 +978,13 @@ func nextForm(st []any) (any, []any) {
 		st = st[1:]
 		if ov, ok := v.(*op); ok {
 			f := Form{Op: ov.name}
+			if ov.varFun != nil {
+				f.Args = make([]any, ov.cnt)
+				for i := range f.Args {
+					f.Args[i], st = nextForm(st)
+				}
+				return &f, st
+			}
 			f.Left, st = nextForm(st)
 			f.Right, st = nextForm(st)
 			v = &f
@@ -857,12 +1001,12 @@ func (s *Script) appendOp(o *op, left, right any) (pb *precBuf) {
 		pb.buf = s.appendValue(pb.buf, left, o.prec)
 	case group.code:
 		pb.buf = s.appendValue(pb.buf, left, o.prec)
-	case length.code, count.code:
+	case length.code, rfcLength.code, count.code, value.code:
 		pb.buf = append(pb.buf, o.name...)
 		pb.buf = append(pb.buf, '(')
 		pb.buf = s.appendValue(pb.buf, left, o.prec)
 		pb.buf = append(pb.buf, ')')
-	case match.code, search.code:
+	case match.code, rfcMatch.code, search.code, rfcSearch.code:
 		pb.buf = append(pb.buf, o.name...)
 		pb.buf = append(pb.buf, '(')
 		pb.buf = s.appendValue(pb.buf, left, o.prec)
@@ -888,6 +1032,21 @@ func (s *Script) appendOp(o *op, left, right any) (pb *precBuf) {
 	return
 }
 
+func (s *Script) appendFunc(o *op, args []any) (pb *precBuf) {
+	pb = &precBuf{prec: o.prec}
+	pb.buf = append(pb.buf, o.name...)
+	pb.buf = append(pb.buf, '(')
+	for i, arg := range args {
+		if 0 < i {
+			pb.buf = append(pb.buf, ',', ' ')
+		}
+		pb.buf = s.appendValue(pb.buf, arg, o.prec)
+	}
+	pb.buf = append(pb.buf, ')')
+
+	return
+}
+
 func (s *Script) appendValue(buf []byte, v any, prec byte) []byte {
 	switch tv := v.(type) {
 	case nil:
@@ -917,6 +1076,9 @@ func (s *Script) appendValue(buf []byte, v any, prec byte) []byte {
 		buf = append(buf, ']')
 	case Expr:
 		buf = tv.Append(buf)
+	case Param:
+		buf = append(buf, '$')
+		buf = append(buf, tv...)
 	case *regexp.Regexp:
 		buf = AppendString(buf, tv.String(), '/')
 	case *precBuf:
@@ -956,6 +1118,7 @@ var builtInNames = map[string]bool{
 	"count":  true,
 	"match":  true,
 	"search": true,
+	"value":  true,
 	"true":   true,
 	"false":  true,
 	"null":   true,
@@ -963,8 +1126,8 @@ var builtInNames = map[string]bool{
 
 // RegisterUnaryFunction registers a unary function for scripts. The 'get'
 // argument if true indicates a get operation to provide the argument to the
-// provided function otherwise the first match is used. Names must be alpha
-// characters only.
+// provided function otherwise the first match is used. Names must be lowercase
+// alpha characters or underscores only.
 func RegisterUnaryFunction(name string, get bool, f func(arg any) any) {
 	name = strings.ToLower(name)
 	if builtInNames[name] {
@@ -982,7 +1145,8 @@ func RegisterUnaryFunction(name string, get bool, f func(arg any) any) {
 // RegisterBinaryFunction registers a function that takes two argument for
 // scripts. The 'getLeft' and 'getRight' arguments if true indicates a get
 // operation to provide the argument to the provided function otherwise the
-// first match is used. Names must be alpha characters only.
+// first match is used. Names must be lowercase alpha characters or
+// underscores only.
 func RegisterBinaryFunction(name string, getLeft, getRight bool, f func(left, right any) any) {
 	name = strings.ToLower(name)
 	if builtInNames[name] {
@@ -997,3 +1161,22 @@ func RegisterBinaryFunction(name string, getLeft, getRight bool, f func(left, ri
 		getRight: getRight,
 	}
 }
+
+// RegisterVariadicFunction registers a function for scripts that takes
+// between minArgs and maxArgs arguments. A negative maxArgs indicates there
+// is no upper limit on the number of arguments. Arguments are provided to
+// the function in the same way as for a binary function with false get
+// flags. Names must be lowercase alpha characters or underscores only.
+func RegisterVariadicFunction(name string, minArgs, maxArgs int, f func(args []any) any) {
+	name = strings.ToLower(name)
+	if builtInNames[name] {
+		panic(fmt.Errorf("operation %s can not be replaced", name))
+	}
+	opMap[name] = &op{
+		name:    name,
+		varFun:  f,
+		code:    userOpCode,
+		minArgs: minArgs,
+		maxArgs: maxArgs,
+	}
+}
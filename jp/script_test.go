@@ -121,6 +121,7 @@ func TestScriptParse(t *testing.T) {
 		{src: "(3 == count(@.xyz))", expect: "(3 == count(@.xyz))"},
 		{src: "(count(@.xyz) == 3)", expect: "(count(@.xyz) == 3)"},
 		{src: "(count(7) == 3)", expect: "(count(7) == 3)"},
+		{src: "(value(@.xyz) == 3)", expect: "(value(@.xyz) == 3)"},
 		{src: "(count(@.xyz == 3)", err: "not terminated at 19 in (count(@.xyz == 3)"},
 		{src: "(coun(@.xyz) == 3)", err: "'coun' is not a value or function at 2 in (coun(@.xyz) == 3)"},
 
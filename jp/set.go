@@ -49,7 +49,8 @@ func (x Expr) MustSet(data, value any) {
 
 // Set all matching child node values. An error is returned if it is not
 // possible. If the path to the child does not exist array and map elements
-// are added.
+// are added. Use an Editor to control the containers added or to get the
+// paths that were changed.
 func (x Expr) Set(data, value any) error {
 	return x.set(data, value, "set", false)
 }
@@ -72,6 +73,14 @@ func (x Expr) set(data, value any, fun string, one bool) error {
 	if len(x) == 0 {
 		return fmt.Errorf("can not %s with an empty expression", fun)
 	}
+	if x.editorOnly(true) {
+		max := 0
+		if one {
+			max = 1
+		}
+		_, err := (&Editor{}).set(x, data, value, fun, max)
+		return err
+	}
 	switch x[len(x)-1].(type) {
 	case Root, At, Bracket, Descent, Slice, *Filter:
 		ta := strings.Split(fmt.Sprintf("%T", x[len(x)-1]), ".")
@@ -629,6 +638,7 @@ func (x Expr) set(data, value any, fun string, one bool) error {
 			di, _ := stack[len(stack)-1].(fragIndex)
 			// first pass expands, second continues evaluation
 			if (di & descentFlag) == 0 {
+				keepSiblingIndex(&stack)
 				switch tv := prev.(type) {
 				case map[string]any:
 					// Put prev back and slide fi.
@@ -1125,7 +1135,7 @@ func (x Expr) set(data, value any, fun string, one bool) error {
 				}
 			default:
 				if int(fi) != len(x)-1 {
-					for _, v = range reflectGetSlice(tv, start, end, step) {
+					for _, v = range reflectGetSlice(tv, start, end, step, x.rfc()) {
 						switch v.(type) {
 						case nil, gen.Bool, gen.Int, gen.Float, gen.String,
 							bool, string, float64, float32, int, uint, int8, int16, int32, int64, uint8, uint16, uint32, uint64:
@@ -406,13 +406,17 @@ func (f Slice) startEndStep(size int) (start, end, step int) {
 	if start < 0 {
 		start = size + start
 	} else if size <= start {
-		start = size - 1
+		if 0 < step {
+			start = size
+		} else {
+			start = size - 1
+		}
 	}
 	if start < 0 {
 		start = 0
 	}
 	if end < 0 {
-		end = size + end + 1
+		end = size + end
 		if end < 0 && step < 0 {
 			end = -1
 		}
@@ -422,10 +426,90 @@ func (f Slice) startEndStep(size int) (start, end, step int) {
 	return
 }
 
-func (f Slice) locate(pp Expr, data any, rest Expr, max int) (locs []Expr) {
+// rfcStartEndStep is the same as startEndStep except the start and end are
+// clamped to the array for a negative step as described in RFC 9535.
+func (f Slice) rfcStartEndStep(size int) (start, end, step int) {
+	start = 0
+	end = maxEnd
+	step = 1
+	if 0 < len(f) {
+		start = f[0]
+	}
+	if 1 < len(f) {
+		end = f[1]
+	}
+	if 2 < len(f) {
+		step = f[2]
+		if step == 0 {
+			return
+		}
+	}
+	if start < 0 {
+		start = size + start
+	} else if size <= start {
+		if 0 < step {
+			start = size
+		} else {
+			start = size - 1
+		}
+	}
+	if start < 0 {
+		if 0 < step {
+			start = 0
+		} else {
+			start = -1
+		}
+	}
+	if end < 0 {
+		end = size + end
+		if end < 0 && step < 0 {
+			end = -1
+		}
+	} else if step < 0 && size <= end {
+		end = size - 1
+	} else if size < end {
+		end = size
+	}
+	return
+}
+
+// sliceBounds returns the start and end of a slice of an array of size as
+// used when getting values. Nothing is selected if the start is past the end
+// of the array unless rfc is true in which case the RFC 9535 bounds are used.
+func sliceBounds(start, end, step, size int, rfc bool) (int, int) {
+	if rfc {
+		start, end, _ = Slice{start, end, step}.rfcStartEndStep(size)
+		return start, end
+	}
+	if start < 0 {
+		start = size + start
+		if start < 0 {
+			start = 0
+		}
+	}
+	if end < 0 {
+		end = size + end
+	}
+	if size <= start {
+		return start, start
+	}
+	if size < end {
+		end = size
+	}
+	if step < 0 && end < -1 {
+		end = -1
+	}
+	return start, end
+}
+
+func (f Slice) locate(pp Expr, data, root any, rest Expr, max int) (locs []Expr) {
+	startEndStep := f.startEndStep
+	if pp.rfc() {
+		startEndStep = f.rfcStartEndStep
+	}
 	switch td := data.(type) {
 	case []any:
-		start, end, step := f.startEndStep(len(td))
+		start, end, step := startEndStep(len(td))
 		if step == 0 {
 			return
 		}
@@ -438,7 +522,7 @@ func (f Slice) locate(pp Expr, data any, rest Expr, max int) (locs []Expr) {
 				cp := append(pp, nil) // place holder
 				for i := start; i < end; i += step {
 					cp[len(pp)] = Nth(i)
-					locs = locateContinueFrag(locs, cp, td[i], rest, max)
+					locs = locateContinueFrag(locs, cp, td[i], root, rest, max)
 					if 0 < max && max <= len(locs) {
 						break
 					}
@@ -453,7 +537,7 @@ func (f Slice) locate(pp Expr, data any, rest Expr, max int) (locs []Expr) {
 				cp := append(pp, nil) // place holder
 				for i := start; end < i; i += step {
 					cp[len(pp)] = Nth(i)
-					locs = locateContinueFrag(locs, cp, td[i], rest, max)
+					locs = locateContinueFrag(locs, cp, td[i], root, rest, max)
 					if 0 < max && max <= len(locs) {
 						break
 					}
@@ -461,7 +545,7 @@ func (f Slice) locate(pp Expr, data any, rest Expr, max int) (locs []Expr) {
 			}
 		}
 	case gen.Array:
-		start, end, step := f.startEndStep(len(td))
+		start, end, step := startEndStep(len(td))
 		if step == 0 {
 			return
 		}
@@ -474,7 +558,7 @@ func (f Slice) locate(pp Expr, data any, rest Expr, max int) (locs []Expr) {
 				cp := append(pp, nil) // place holder
 				for i := start; i < end; i += step {
 					cp[len(pp)] = Nth(i)
-					locs = locateContinueFrag(locs, cp, td[i], rest, max)
+					locs = locateContinueFrag(locs, cp, td[i], root, rest, max)
 					if 0 < max && max <= len(locs) {
 						break
 					}
@@ -489,7 +573,7 @@ func (f Slice) locate(pp Expr, data any, rest Expr, max int) (locs []Expr) {
 				cp := append(pp, nil) // place holder
 				for i := start; end < i; i += step {
 					cp[len(pp)] = Nth(i)
-					locs = locateContinueFrag(locs, cp, td[i], rest, max)
+					locs = locateContinueFrag(locs, cp, td[i], root, rest, max)
 					if 0 < max && max <= len(locs) {
 						break
 					}
@@ -497,7 +581,7 @@ func (f Slice) locate(pp Expr, data any, rest Expr, max int) (locs []Expr) {
 			}
 		}
 	case Indexed:
-		start, end, step := f.startEndStep(td.Size())
+		start, end, step := startEndStep(td.Size())
 		if step == 0 {
 			return
 		}
@@ -510,7 +594,7 @@ func (f Slice) locate(pp Expr, data any, rest Expr, max int) (locs []Expr) {
 				cp := append(pp, nil) // place holder
 				for i := start; i < end; i += step {
 					cp[len(pp)] = Nth(i)
-					locs = locateContinueFrag(locs, cp, td.ValueAtIndex(i), rest, max)
+					locs = locateContinueFrag(locs, cp, td.ValueAtIndex(i), root, rest, max)
 					if 0 < max && max <= len(locs) {
 						break
 					}
@@ -525,7 +609,7 @@ func (f Slice) locate(pp Expr, data any, rest Expr, max int) (locs []Expr) {
 				cp := append(pp, nil) // place holder
 				for i := start; end < i; i += step {
 					cp[len(pp)] = Nth(i)
-					locs = locateContinueFrag(locs, cp, td.ValueAtIndex(i), rest, max)
+					locs = locateContinueFrag(locs, cp, td.ValueAtIndex(i), root, rest, max)
 					if 0 < max && max <= len(locs) {
 						break
 					}
@@ -539,7 +623,7 @@ func (f Slice) locate(pp Expr, data any, rest Expr, max int) (locs []Expr) {
 		rt := rd.Type()
 		switch rt.Kind() {
 		case reflect.Slice, reflect.Array:
-			start, end, step := f.startEndStep(rd.Len())
+			start, end, step := startEndStep(rd.Len())
 			if 0 < step {
 				if len(rest) == 0 { // last one
 					for i := start; i < end; i += step {
@@ -557,7 +641,7 @@ func (f Slice) locate(pp Expr, data any, rest Expr, max int) (locs []Expr) {
 						cp[len(pp)] = Nth(i)
 						rv := rd.Index(i)
 						if rv.CanInterface() {
-							locs = locateContinueFrag(locs, cp, rv.Interface(), rest, max)
+							locs = locateContinueFrag(locs, cp, rv.Interface(), root, rest, max)
 							if 0 < max && max <= len(locs) {
 								break
 							}
@@ -581,7 +665,7 @@ func (f Slice) locate(pp Expr, data any, rest Expr, max int) (locs []Expr) {
 						cp[len(pp)] = Nth(i)
 						rv := rd.Index(i)
 						if rv.CanInterface() {
-							locs = locateContinueFrag(locs, cp, rv.Interface(), rest, max)
+							locs = locateContinueFrag(locs, cp, rv.Interface(), root, rest, max)
 							if 0 < max && max <= len(locs) {
 								break
 							}
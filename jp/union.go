@@ -12,7 +12,9 @@ import (
 )
 
 // Union is a union operation for a JSON path expression which is a union of a
-// Child and Nth fragment.
+// Child and Nth fragment. A Union can also include Slice, Wildcard, and
+// Filter fragments as allowed by RFC 9535 along with Param and ParamSlice
+// members that are replaced when the expression is bound.
 type Union []any
 
 // Append a fragment string representation of the fragment to the buffer
@@ -30,6 +32,10 @@ func (f Union) Append(buf []byte, _, _ bool) []byte {
 			buf = append(buf, '\'')
 		case int64:
 			buf = append(buf, strconv.FormatInt(tx, 10)...)
+		case Frag:
+			// Drop the brackets of the fragment.
+			fb := tx.Append(nil, true, false)
+			buf = append(buf, fb[1:len(fb)-1]...)
 		}
 	}
 	buf = append(buf, ']')
@@ -47,6 +53,8 @@ func NewUnion(keys ...any) (u Union) {
 			u = append(u, int64(tk))
 		case int64:
 			u = append(u, tk)
+		case Slice, Wildcard, *Filter, Param, ParamSlice:
+			u = append(u, k)
 		}
 	}
 	return
@@ -295,7 +303,7 @@ func (f Union) remove(value any) (out any, changed bool) {
 	return
 }
 
-func (f Union) locate(pp Expr, data any, rest Expr, max int) (locs []Expr) {
+func (f Union) locate(pp Expr, data, root any, rest Expr, max int) (locs []Expr) {
 	var (
 		v   any
 		has bool
@@ -347,17 +355,23 @@ func (f Union) locate(pp Expr, data any, rest Expr, max int) (locs []Expr) {
 				v, has = reflectGetNth(td, i)
 			}
 			lf = Nth(i)
+		case Frag:
+			m := max
+			if 0 < max {
+				m = max - len(locs)
+			}
+			locs = append(locs, tu.locate(pp, data, root, rest, m)...)
 		}
 		if has {
 			if len(rest) == 0 { // last one
 				locs = locateAppendFrag(locs, pp, lf)
 			} else {
-				locs = locateContinueFrag(locs, append(pp, lf), v, rest, max)
-			}
-			if 0 < max && max <= len(locs) {
-				break
+				locs = locateContinueFrag(locs, append(pp, lf), v, root, rest, max)
 			}
 		}
+		if 0 < max && max <= len(locs) {
+			break
+		}
 	}
 	return
 }
@@ -370,6 +384,19 @@ func (f Union) Walk(rest, path Expr, nodes []any, cb func(path Expr, nodes []any
 			Nth(tu).Walk(rest, path, nodes, cb)
 		case string:
 			Child(tu).Walk(rest, path, nodes, cb)
+		case Frag:
+			tu.Walk(rest, path, nodes, cb)
 		}
 	}
 }
+
+// unionFragGet returns the values selected by a Slice, Wildcard, or Filter
+// member of a Union in the order they are located.
+func unionFragGet(f Frag, data, root any) (values []any) {
+	for _, loc := range f.locate(nil, data, root, nil, 0) {
+		if v, has := loc.FirstFound(data); has {
+			values = append(values, v)
+		}
+	}
+	return
+}
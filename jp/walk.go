@@ -13,62 +13,72 @@ import (
 // reused in each call so if the path needs to be save it should be copied.
 func Walk(data any, cb func(path Expr, value any), justLeaves ...bool) {
 	path := Expr{Root('$')}
-	walk(path, data, cb, 0 < len(justLeaves) && justLeaves[0])
+	walk(path, data, nil, func(path Expr, value, _ any) {
+		cb(path, value)
+	}, 0 < len(justLeaves) && justLeaves[0])
 }
 
-func walk(path Expr, data any, cb func(path Expr, value any), justLeaves bool) {
+// WalkWithParent is the same as Walk except the cb callback is also given
+// the array or object that contains the value. The parent of the root is
+// nil.
+func WalkWithParent(data any, cb func(path Expr, value, parent any), justLeaves ...bool) {
+	path := Expr{Root('$')}
+	walk(path, data, nil, cb, 0 < len(justLeaves) && justLeaves[0])
+}
+
+func walk(path Expr, data, parent any, cb func(path Expr, value, parent any), justLeaves bool) {
 top:
 	switch td := data.(type) {
 	case nil, bool, int64, float64, string,
 		int, int8, int16, int32, uint, uint8, uint16, uint32, uint64, float32,
 		[]byte, time.Time:
 		// leaf node
-		cb(path, data)
+		cb(path, data, parent)
 	case []any:
 		if !justLeaves {
-			cb(path, data)
+			cb(path, data, parent)
 		}
 		pi := len(path)
 		path = append(path, nil)
 		for i, v := range td {
 			path[pi] = Nth(i)
-			walk(path, v, cb, justLeaves)
+			walk(path, v, td, cb, justLeaves)
 		}
 	case map[string]any:
 		if !justLeaves {
-			cb(path, data)
+			cb(path, data, parent)
 		}
 		pi := len(path)
 		path = append(path, nil)
 		for k, v := range td {
 			path[pi] = Child(k)
-			walk(path, v, cb, justLeaves)
+			walk(path, v, td, cb, justLeaves)
 		}
 	case gen.Array:
 		if !justLeaves {
-			cb(path, data)
+			cb(path, data, parent)
 		}
 		pi := len(path)
 		path = append(path, nil)
 		for i, v := range td {
 			path[pi] = Nth(i)
-			walk(path, v, cb, justLeaves)
+			walk(path, v, td, cb, justLeaves)
 		}
 	case gen.Object:
 		if !justLeaves {
-			cb(path, data)
+			cb(path, data, parent)
 		}
 		pi := len(path)
 		path = append(path, nil)
 		for k, v := range td {
 			path[pi] = Child(k)
-			walk(path, v, cb, justLeaves)
+			walk(path, v, td, cb, justLeaves)
 		}
 	case alt.Simplifier:
 		data = td.Simplify()
 		goto top
 	default:
-		cb(path, data)
+		cb(path, data, parent)
 	}
 }
 
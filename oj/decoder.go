@@ -0,0 +1,1081 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package oj
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+
+	"github.com/ohler55/ojg"
+	"github.com/ohler55/ojg/alt"
+	"github.com/ohler55/ojg/gen"
+)
+
+const (
+	skipFrame = iota
+	genFrame
+	structFrame
+	mapFrame
+	sliceFrame
+	arrayFrame
+)
+
+// dframe is a container being decoded into.
+type dframe struct {
+	rv       reflect.Value // container being filled
+	di       *dinfo
+	fv       reflect.Value // field or element the next value goes into
+	fi       *dinfo
+	mkey     reflect.Value
+	dst      reflect.Value // where a generic value is stored when closed
+	dstInfo  *dinfo
+	list     []any
+	obj      map[string]any
+	key      string
+	n        int
+	kind     byte
+	isObj    bool
+	asString bool
+}
+
+// Decoder is a reusable JSON decoder that decodes directly into structs,
+// slices, maps, and other Go types without first building a generic
+// map[string]any or []any. The same parsing state machine as the Parser is
+// used but with values set through reflection as they are read. The json
+// struct tags are honored as are the json.Unmarshaler,
+// encoding.TextUnmarshaler, and alt.AttrSetter interfaces. A Decoder can be
+// reused for multiple decodings which allows buffer reuse for a performance
+// advantage.
+type Decoder struct {
+	tracker
+	tmp      []byte // used for numbers and strings
+	frames   []dframe
+	root     reflect.Value
+	rootInfo *dinfo
+	ri       int // read index for null, false, and true
+	num      gen.Number
+	rn       rune
+	sur      surrogate
+	mode     string
+	nextMode string
+	keyMode  int
+
+	// UseTags if true results in only the json tag name of a field, or the
+	// field name if there is no tag, being used to match object keys to
+	// struct fields. This is the same as the UseTags field in ojg.Options.
+	UseTags bool
+
+	// KeyExact if true results in only the exact field name being used to
+	// match object keys to struct fields. UseTags takes precedence. This is
+	// the same as the KeyExact field in ojg.Options.
+	//
+	// If neither UseTags or KeyExact is true then the json tag name, the
+	// field name, the field name with a lowercase first letter, and the all
+	// lowercase field name will match as with alt.Recompose.
+	KeyExact bool
+}
+
+// Unmarshal decodes the JSON directly into the value pointed to by vp.
+func (d *Decoder) Unmarshal(data []byte, vp any) (err error) {
+	if err = d.start(vp); err != nil {
+		return
+	}
+	// Skip BOM if present.
+	if 3 < len(data) && data[0] == 0xEF {
+		if data[1] == 0xBB && data[2] == 0xBF {
+			err = d.decodeBuffer(data[3:], true)
+		} else {
+			err = fmt.Errorf("expected BOM at 1:3")
+		}
+	} else {
+		err = d.decodeBuffer(data, true)
+	}
+	d.finish()
+
+	return
+}
+
+// UnmarshalReader reads and decodes the JSON from an io.Reader directly into
+// the value pointed to by vp.
+func (d *Decoder) UnmarshalReader(r io.Reader, vp any) (err error) {
+	if err = d.start(vp); err != nil {
+		return
+	}
+	defer d.finish()
+
+	buf := make([]byte, readBufSize)
+	eof := false
+	var cnt int
+	cnt, err = r.Read(buf)
+	buf = buf[:cnt]
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			return
+		}
+		eof = true
+	}
+	var skip int
+	// Skip BOM if present.
+	if 3 < len(buf) && buf[0] == 0xEF && buf[1] == 0xBB && buf[2] == 0xBF {
+		skip = 3
+	}
+	for {
+		if err = d.decodeBuffer(buf[skip:], eof); err != nil {
+			return
+		}
+		d.noff -= len(buf) - skip
+		skip = 0
+		if eof {
+			break
+		}
+		buf = buf[:cap(buf)]
+		cnt, err = r.Read(buf)
+		buf = buf[:cnt]
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return
+			}
+			eof = true
+		}
+	}
+	return nil
+}
+
+func (d *Decoder) start(vp any) error {
+	rv := reflect.ValueOf(vp)
+	switch {
+	case rv.Kind() == reflect.Ptr && !rv.IsNil():
+		rv = rv.Elem()
+	case rv.Kind() == reflect.Map && !rv.IsNil():
+		// A map can be filled in directly.
+	default:
+		return fmt.Errorf("can only decode into a non-nil pointer or map, not a %T", vp)
+	}
+	d.root = rv
+	d.rootInfo = getDinfo(rv.Type())
+	switch {
+	case d.UseTags:
+		d.keyMode = dkeyTag
+	case d.KeyExact:
+		d.keyMode = dkeyExact
+	default:
+		d.keyMode = dkeyLoose
+	}
+	if d.frames == nil {
+		d.tmp = make([]byte, 0, tmpInitSize)
+		d.frames = make([]dframe, 0, stackInitSize)
+	} else {
+		d.tmp = d.tmp[:0]
+		d.frames = d.frames[:0]
+	}
+	d.num.ForceFloat = true
+	d.num.Conv = ojg.DefaultNumConvMethod
+	d.noff = -1
+	d.line = 1
+	d.sur.hi = 0
+	d.mode = valueMap
+
+	return nil
+}
+
+// finish releases references to values decoded into.
+func (d *Decoder) finish() {
+	d.frames = d.frames[:cap(d.frames)]
+	for i := len(d.frames) - 1; 0 <= i; i-- {
+		d.frames[i] = dframe{}
+	}
+	d.frames = d.frames[:0]
+	d.root = reflect.Value{}
+	d.rootInfo = nil
+}
+
+func (d *Decoder) decodeBuffer(buf []byte, last bool) (err error) {
+	var b byte
+	var i int
+	var off int
+	depth := len(d.frames)
+	for off = 0; off < len(buf); off++ {
+		b = buf[off]
+		switch d.mode[b] {
+		case skipNewline:
+			d.line++
+			d.noff = off
+			for i, b = range buf[off+1:] {
+				if spaceMap[b] != skipChar {
+					break
+				}
+			}
+			off += i
+			continue
+		case colonColon:
+			d.mode = valueMap
+			continue
+		case skipChar: // skip and continue
+			continue
+		case strOk:
+			d.tmp = append(d.tmp, b)
+		case keyQuote:
+			start := off + 1
+			if len(buf) <= start {
+				d.tmp = d.tmp[:0]
+				d.mode = stringMap
+				d.nextMode = colonMap
+				continue
+			}
+			for i, b = range buf[off+1:] {
+				if stringMap[b] != strOk {
+					break
+				}
+			}
+			off += i
+			if b == '"' {
+				off++
+				if err = d.key(buf[start:off], off); err != nil {
+					return
+				}
+				d.mode = colonMap
+			} else {
+				d.tmp = d.tmp[:0]
+				d.tmp = append(d.tmp, buf[start:off+1]...)
+				d.mode = stringMap
+				d.nextMode = colonMap
+			}
+			continue
+		case afterComma:
+			if 0 < len(d.frames) && d.frames[len(d.frames)-1].isObj {
+				d.mode = keyMap
+			} else {
+				d.mode = commaMap
+			}
+			continue
+		case valQuote:
+			start := off + 1
+			if len(buf) <= start {
+				d.tmp = d.tmp[:0]
+				d.mode = stringMap
+				d.nextMode = afterMap
+				continue
+			}
+			for i, b = range buf[off+1:] {
+				if stringMap[b] != strOk {
+					break
+				}
+			}
+			off += i
+			if b == '"' {
+				off++
+				if err = d.setString(buf[start:off], off); err != nil {
+					return
+				}
+				d.mode = afterMap
+			} else {
+				d.tmp = d.tmp[:0]
+				d.tmp = append(d.tmp, buf[start:off+1]...)
+				d.mode = stringMap
+				d.nextMode = afterMap
+				continue
+			}
+		case numComma:
+			if err = d.setNum(off); err != nil {
+				return
+			}
+			if 0 < len(d.frames) {
+				if d.frames[len(d.frames)-1].isObj {
+					d.mode = keyMap
+				} else {
+					d.mode = commaMap
+				}
+			} else {
+				return d.newError(off, "unexpected comma")
+			}
+		case strSlash:
+			d.mode = escMap
+			continue
+		case escOk:
+			d.tmp = append(d.tmp, escByteMap[b])
+			d.mode = stringMap
+			continue
+		case openObject:
+			if err = d.openObject(off); err != nil {
+				return
+			}
+			d.mode = key1Map
+			depth++
+			continue
+		case closeObject:
+			depth--
+			if depth < 0 || !d.frames[depth].isObj {
+				return d.newError(off, "unexpected object close")
+			}
+			if 256 < len(d.mode) && d.mode[256] == 'n' {
+				if err = d.setNum(off); err != nil {
+					return
+				}
+			}
+			if err = d.close(off); err != nil {
+				return
+			}
+			d.mode = afterMap
+		case val0:
+			d.mode = zeroMap
+			d.num.Reset()
+		case valDigit:
+			d.num.Reset()
+			d.mode = digitMap
+			d.num.I = uint64(b - '0')
+			for i, b = range buf[off+1:] {
+				if digitMap[b] != numDigit {
+					break
+				}
+				if gen.BigLimit <= d.num.I {
+					d.num.FillBig()
+					d.num.AddDigit(b)
+					break
+				}
+				d.num.I = d.num.I*10 + uint64(b-'0')
+			}
+			if digitMap[b] == numDigit {
+				off++
+			}
+			off += i
+		case valNeg:
+			d.mode = negMap
+			d.num.Reset()
+			d.num.Neg = true
+			continue
+		case escU:
+			d.mode = uMap
+			d.rn = 0
+			d.ri = 0
+			continue
+		case openArray:
+			if err = d.openArray(off); err != nil {
+				return
+			}
+			d.mode = valueMap
+			depth++
+			continue
+		case closeArray:
+			depth--
+			if depth < 0 || d.frames[depth].isObj {
+				return d.newError(off, "unexpected array close")
+			}
+			// Only modes with a close array are value, after, and numbers
+			// which are all over 256 long.
+			if d.mode[256] == 'n' {
+				if err = d.setNum(off); err != nil {
+					return
+				}
+			}
+			if err = d.close(off); err != nil {
+				return
+			}
+			d.mode = afterMap
+		case valNull:
+			if off+4 <= len(buf) && string(buf[off:off+4]) == "null" {
+				off += 3
+				d.mode = afterMap
+				d.setNull()
+			} else {
+				d.mode = nullMap
+				d.ri = 0
+			}
+		case valTrue:
+			if off+4 <= len(buf) && string(buf[off:off+4]) == "true" {
+				off += 3
+				d.mode = afterMap
+				if err = d.setBool(true, off); err != nil {
+					return
+				}
+			} else {
+				d.mode = trueMap
+				d.ri = 0
+			}
+		case valFalse:
+			if off+5 <= len(buf) && string(buf[off:off+5]) == "false" {
+				off += 4
+				d.mode = afterMap
+				if err = d.setBool(false, off); err != nil {
+					return
+				}
+			} else {
+				d.mode = falseMap
+				d.ri = 0
+			}
+		case numDot:
+			if 0 < len(d.num.BigBuf) {
+				d.num.BigBuf = append(d.num.BigBuf, b)
+				d.mode = dotMap
+				continue
+			}
+			for i, b = range buf[off+1:] {
+				if digitMap[b] != numDigit {
+					break
+				}
+				d.num.Frac = d.num.Frac*10 + uint64(b-'0')
+				d.num.Div *= 10
+				if gen.DivLimit <= d.num.Div {
+					d.num.FillBig()
+					break
+				}
+			}
+			off += i
+			if digitMap[b] == numDigit {
+				off++
+			}
+			d.mode = fracMap
+		case numFrac:
+			d.num.AddFrac(b)
+			d.mode = fracMap
+		case fracE:
+			if 0 < len(d.num.BigBuf) {
+				d.num.BigBuf = append(d.num.BigBuf, b)
+			}
+			d.mode = expSignMap
+			continue
+		case strQuote:
+			d.sur.end()
+			d.mode = d.nextMode
+			if d.mode[':'] == colonColon {
+				err = d.key(d.tmp, off)
+			} else {
+				err = d.setString(d.tmp, off)
+			}
+			if err != nil {
+				return
+			}
+		case numZero:
+			d.mode = zeroMap
+		case numDigit:
+			d.num.AddDigit(b)
+		case negDigit:
+			d.num.AddDigit(b)
+			d.mode = digitMap
+		case numSpc:
+			if err = d.setNum(off); err != nil {
+				return
+			}
+			d.mode = afterMap
+		case numNewline:
+			if err = d.setNum(off); err != nil {
+				return
+			}
+			d.line++
+			d.noff = off
+			d.mode = afterMap
+			for i, b = range buf[off+1:] {
+				if spaceMap[b] != skipChar {
+					break
+				}
+			}
+			off += i
+		case expSign:
+			d.mode = expZeroMap
+			if b == '-' {
+				d.num.NegExp = true
+			}
+			continue
+		case expDigit:
+			d.num.AddExp(b)
+			d.mode = expMap
+		case uOk:
+			d.ri++
+			switch b {
+			case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+				d.rn = d.rn<<4 | rune(b-'0')
+			case 'a', 'b', 'c', 'd', 'e', 'f':
+				d.rn = d.rn<<4 | rune(b-'a'+10)
+			case 'A', 'B', 'C', 'D', 'E', 'F':
+				d.rn = d.rn<<4 | rune(b-'A'+10)
+			}
+			if d.ri == 4 {
+				d.tmp, _ = d.sur.appendRune(d.tmp, d.rn)
+				d.mode = stringMap
+			}
+			continue
+		case tokenOk:
+			switch {
+			case d.mode['r'] == tokenOk:
+				d.ri++
+				if "true"[d.ri] != b {
+					return d.newError(off, "expected true")
+				}
+				if 3 <= d.ri {
+					if err = d.setBool(true, off); err != nil {
+						return
+					}
+					d.mode = afterMap
+				}
+			case d.mode['a'] == tokenOk:
+				d.ri++
+				if "false"[d.ri] != b {
+					return d.newError(off, "expected false")
+				}
+				if 4 <= d.ri {
+					if err = d.setBool(false, off); err != nil {
+						return
+					}
+					d.mode = afterMap
+				}
+			case d.mode['u'] == tokenOk && d.mode['l'] == tokenOk:
+				d.ri++
+				if "null"[d.ri] != b {
+					return d.newError(off, "expected null")
+				}
+				if 3 <= d.ri {
+					d.setNull()
+					d.mode = afterMap
+				}
+			}
+		case charErr:
+			return d.byteError(off, d.mode, b, bytes.Runes(buf[off:])[0])
+		}
+		if depth == 0 && 256 < len(d.mode) && d.mode[256] == 'a' {
+			d.mode = spaceMap
+		}
+	}
+	if last {
+		if 0 < len(d.frames) || len(d.mode) == 256 { // valid finishing maps are one byte longer
+			return d.newError(off, "incomplete JSON")
+		}
+		if d.mode[256] == 'n' {
+			return d.setNum(off)
+		}
+	}
+	return nil
+}
+
+func (d *Decoder) top() *dframe {
+	if 0 < len(d.frames) {
+		return &d.frames[len(d.frames)-1]
+	}
+	return nil
+}
+
+// slot returns the value and type information the next value should be
+// decoded into. An invalid value indicates the value should be skipped.
+func (d *Decoder) slot() (reflect.Value, *dinfo, bool) {
+	if len(d.frames) == 0 {
+		return d.root, d.rootInfo, false
+	}
+	f := &d.frames[len(d.frames)-1]
+	switch f.kind {
+	case structFrame:
+		return f.fv, f.fi, f.asString
+	case mapFrame:
+		f.fv = reflect.New(f.di.elem.rt).Elem()
+		return f.fv, f.di.elem, false
+	case sliceFrame:
+		n := f.rv.Len()
+		if n == f.rv.Cap() {
+			grown := reflect.MakeSlice(f.di.rt, n, n*2+4)
+			reflect.Copy(grown, f.rv)
+			f.rv.Set(grown)
+		}
+		f.rv.SetLen(n + 1)
+		return f.rv.Index(n), f.di.elem, false
+	case arrayFrame:
+		if f.n < f.rv.Len() {
+			f.n++
+			return f.rv.Index(f.n - 1), f.di.elem, false
+		}
+	}
+	return reflect.Value{}, nil, false
+}
+
+// commit completes the setting of a value in the current container which is
+// only needed for maps since map elements are not addressable.
+func (d *Decoder) commit() {
+	if f := d.top(); f != nil && f.kind == mapFrame {
+		f.rv.SetMapIndex(f.mkey, f.fv)
+	}
+}
+
+// deref allocates any nil pointers and returns the value pointed to.
+func deref(rv reflect.Value, di *dinfo) (reflect.Value, *dinfo) {
+	for di.kind == reflect.Ptr {
+		if rv.IsNil() {
+			rv.Set(reflect.New(di.elem.rt))
+		}
+		rv = rv.Elem()
+		di = di.elem
+	}
+	return rv, di
+}
+
+func (d *Decoder) key(k []byte, off int) error {
+	f := d.top()
+	switch f.kind {
+	case structFrame:
+		df := f.di.fields[d.keyMode][string(k)]
+		if df == nil {
+			f.fv = reflect.Value{}
+			return nil
+		}
+		f.fi = df.di
+		f.asString = df.asString
+		if len(df.index) == 1 {
+			f.fv = f.rv.Field(df.index[0])
+		} else {
+			f.fv = fieldByIndex(f.rv, df.index)
+		}
+	case mapFrame:
+		kt := f.rv.Type().Key()
+		switch kt.Kind() {
+		case reflect.String:
+			f.mkey = reflect.ValueOf(string(k))
+			if kt != f.mkey.Type() {
+				f.mkey = f.mkey.Convert(kt)
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i, err := strconv.ParseInt(string(k), 10, 64)
+			f.mkey = reflect.New(kt).Elem()
+			if err != nil || f.mkey.OverflowInt(i) {
+				return d.newError(off, "invalid %s map key '%s'", kt, k)
+			}
+			f.mkey.SetInt(i)
+		default: // must be an unsigned int as checked when the object started
+			u, err := strconv.ParseUint(string(k), 10, 64)
+			f.mkey = reflect.New(kt).Elem()
+			if err != nil || f.mkey.OverflowUint(u) {
+				return d.newError(off, "invalid %s map key '%s'", kt, k)
+			}
+			f.mkey.SetUint(u)
+		}
+	case genFrame:
+		f.key = string(k)
+	}
+	return nil
+}
+
+// fieldByIndex is similar to reflect.Value.FieldByIndex except that nil
+// embedded struct pointers are allocated.
+func fieldByIndex(rv reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if 0 < i && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv
+}
+
+func (d *Decoder) addGeneric(f *dframe, v any) {
+	if f.isObj {
+		f.obj[f.key] = v
+	} else {
+		f.list = append(f.list, v)
+	}
+}
+
+func (d *Decoder) setNull() {
+	f := d.top()
+	if f != nil {
+		switch f.kind {
+		case skipFrame:
+			return
+		case genFrame:
+			d.addGeneric(f, nil)
+			return
+		}
+	}
+	rv, di, _ := d.slot()
+	if !rv.IsValid() {
+		return
+	}
+	switch di.kind {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		rv.Set(reflect.Zero(di.rt))
+	}
+	d.commit()
+}
+
+func (d *Decoder) setBool(b bool, off int) error {
+	f := d.top()
+	if f != nil {
+		switch f.kind {
+		case skipFrame:
+			return nil
+		case genFrame:
+			d.addGeneric(f, b)
+			return nil
+		}
+	}
+	rv, di, _ := d.slot()
+	if !rv.IsValid() {
+		return nil
+	}
+	rv, di = deref(rv, di)
+	switch {
+	case di.unmarshaler:
+		var err error
+		if b {
+			err = rv.Addr().Interface().(json.Unmarshaler).UnmarshalJSON([]byte("true"))
+		} else {
+			err = rv.Addr().Interface().(json.Unmarshaler).UnmarshalJSON([]byte("false"))
+		}
+		if err != nil {
+			return err
+		}
+	case di.kind == reflect.Bool:
+		rv.SetBool(b)
+	case di.kind == reflect.Interface && di.rt.NumMethod() == 0:
+		rv.Set(reflect.ValueOf(b))
+	default:
+		return d.typeError(off, "bool", di.rt)
+	}
+	d.commit()
+
+	return nil
+}
+
+func (d *Decoder) setString(s []byte, off int) error {
+	f := d.top()
+	if f != nil {
+		switch f.kind {
+		case skipFrame:
+			return nil
+		case genFrame:
+			d.addGeneric(f, string(s))
+			return nil
+		}
+	}
+	rv, di, asString := d.slot()
+	if !rv.IsValid() {
+		return nil
+	}
+	rv, di = deref(rv, di)
+	switch {
+	case di.unmarshaler:
+		js := ojg.AppendJSONString(nil, string(s), false)
+		if err := rv.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(js); err != nil {
+			return err
+		}
+	case di.textUnmarshaler:
+		if err := rv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(s); err != nil {
+			return err
+		}
+	default:
+		switch di.kind {
+		case reflect.String:
+			rv.SetString(string(s))
+		case reflect.Interface:
+			if di.rt.NumMethod() != 0 {
+				return d.typeError(off, "string", di.rt)
+			}
+			rv.Set(reflect.ValueOf(string(s)))
+		case reflect.Slice:
+			if di.elem.kind != reflect.Uint8 {
+				return d.typeError(off, "string", di.rt)
+			}
+			rv.SetBytes([]byte(string(s)))
+		case reflect.Bool:
+			if !asString {
+				return d.typeError(off, "string", di.rt)
+			}
+			v, err := strconv.ParseBool(string(s))
+			if err != nil {
+				return d.newError(off, "%s", err)
+			}
+			rv.SetBool(v)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if !asString {
+				return d.typeError(off, "string", di.rt)
+			}
+			i, err := strconv.ParseInt(string(s), 10, 64)
+			if err != nil || rv.OverflowInt(i) {
+				return d.newError(off, "can not convert '%s' to a %s", s, di.rt)
+			}
+			rv.SetInt(i)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if !asString {
+				return d.typeError(off, "string", di.rt)
+			}
+			u, err := strconv.ParseUint(string(s), 10, 64)
+			if err != nil || rv.OverflowUint(u) {
+				return d.newError(off, "can not convert '%s' to a %s", s, di.rt)
+			}
+			rv.SetUint(u)
+		case reflect.Float32, reflect.Float64:
+			if !asString {
+				return d.typeError(off, "string", di.rt)
+			}
+			fv, err := strconv.ParseFloat(string(s), 64)
+			if err != nil || rv.OverflowFloat(fv) {
+				return d.newError(off, "can not convert '%s' to a %s", s, di.rt)
+			}
+			rv.SetFloat(fv)
+		default:
+			return d.typeError(off, "string", di.rt)
+		}
+	}
+	d.commit()
+
+	return nil
+}
+
+func (d *Decoder) setNum(off int) error {
+	f := d.top()
+	if f != nil {
+		switch f.kind {
+		case skipFrame:
+			return nil
+		case genFrame:
+			d.addGeneric(f, d.num.AsNum())
+			return nil
+		}
+	}
+	rv, di, _ := d.slot()
+	if !rv.IsValid() {
+		return nil
+	}
+	rv, di = deref(rv, di)
+	if di.unmarshaler {
+		if err := rv.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(d.numBytes()); err != nil {
+			return err
+		}
+		d.commit()
+		return nil
+	}
+	switch di.kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		if len(d.num.BigBuf) == 0 && d.num.Div == 1 && d.num.Exp == 0 {
+			i = int64(d.num.I)
+			if d.num.Neg {
+				i = -i
+			}
+		} else if b := d.numBytes(); intLiteral(b) {
+			var err error
+			if i, err = strconv.ParseInt(string(b), 10, 64); err != nil {
+				return d.newError(off, "%s overflows a %s", b, di.rt)
+			}
+		} else {
+			fv, err := d.numFloat()
+			// The limits are powers of two so they are exact as floats.
+			if err != nil || fv < -(1<<63) || 1<<63 <= fv {
+				return d.newError(off, "%s overflows a %s", d.numBytes(), di.rt)
+			}
+			i = int64(fv)
+		}
+		if rv.OverflowInt(i) {
+			return d.newError(off, "%s overflows a %s", d.numBytes(), di.rt)
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		switch {
+		case d.num.Neg:
+			return d.newError(off, "%s overflows a %s", d.numBytes(), di.rt)
+		case len(d.num.BigBuf) == 0 && d.num.Div == 1 && d.num.Exp == 0:
+			u = d.num.I
+		case intLiteral(d.numBytes()):
+			var err error
+			if u, err = strconv.ParseUint(string(d.numBytes()), 10, 64); err != nil {
+				return d.newError(off, "%s overflows a %s", d.numBytes(), di.rt)
+			}
+		default:
+			fv, err := d.numFloat()
+			if err != nil || 1<<64 <= fv {
+				return d.newError(off, "%s overflows a %s", d.numBytes(), di.rt)
+			}
+			u = uint64(fv)
+		}
+		if rv.OverflowUint(u) {
+			return d.newError(off, "%s overflows a %s", d.numBytes(), di.rt)
+		}
+		rv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		fv, err := d.numFloat()
+		if err != nil || rv.OverflowFloat(fv) {
+			return d.newError(off, "%s overflows a %s", d.numBytes(), di.rt)
+		}
+		rv.SetFloat(fv)
+	case reflect.String:
+		if di.rt != jsonNumberType {
+			return d.typeError(off, "number", di.rt)
+		}
+		rv.SetString(string(d.numBytes()))
+	case reflect.Interface:
+		if di.rt.NumMethod() != 0 {
+			return d.typeError(off, "number", di.rt)
+		}
+		rv.Set(reflect.ValueOf(d.num.AsNum()))
+	default:
+		return d.typeError(off, "number", di.rt)
+	}
+	d.commit()
+
+	return nil
+}
+
+func (d *Decoder) numFloat() (float64, error) {
+	switch tn := d.num.AsNum().(type) {
+	case float64:
+		return tn, nil
+	case json.Number:
+		return tn.Float64()
+	case string:
+		return strconv.ParseFloat(tn, 64)
+	}
+	return 0.0, fmt.Errorf("not a number")
+}
+
+// intLiteral returns true if the number bytes have no fraction or exponent.
+func intLiteral(b []byte) bool {
+	return bytes.IndexAny(b, ".eE") < 0
+}
+
+// numBytes returns the JSON representation of the current number.
+func (d *Decoder) numBytes() []byte {
+	if len(d.num.BigBuf) == 0 {
+		d.num.FillBig()
+	}
+	return d.num.BigBuf
+}
+
+func (d *Decoder) openObject(off int) error {
+	f := d.top()
+	if f != nil {
+		switch f.kind {
+		case skipFrame:
+			d.frames = append(d.frames, dframe{kind: skipFrame, isObj: true})
+			return nil
+		case genFrame:
+			d.frames = append(d.frames, dframe{kind: genFrame, isObj: true, obj: map[string]any{}})
+			return nil
+		}
+	}
+	rv, di, _ := d.slot()
+	if !rv.IsValid() {
+		d.frames = append(d.frames, dframe{kind: skipFrame, isObj: true})
+		return nil
+	}
+	rv, di = deref(rv, di)
+	if di.unmarshaler || di.attrSetter {
+		d.frames = append(d.frames, dframe{kind: genFrame, isObj: true, obj: map[string]any{}, dst: rv, dstInfo: di})
+		return nil
+	}
+	switch di.kind {
+	case reflect.Struct:
+		d.frames = append(d.frames, dframe{kind: structFrame, isObj: true, rv: rv, di: di})
+	case reflect.Map:
+		switch di.rt.Key().Kind() {
+		case reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			return d.newError(off, "can not decode into a map with %s keys", di.rt.Key())
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.MakeMap(di.rt))
+		}
+		d.frames = append(d.frames, dframe{kind: mapFrame, isObj: true, rv: rv, di: di})
+	case reflect.Interface:
+		if di.rt.NumMethod() != 0 {
+			return d.typeError(off, "object", di.rt)
+		}
+		d.frames = append(d.frames, dframe{kind: genFrame, isObj: true, obj: map[string]any{}, dst: rv, dstInfo: di})
+	default:
+		return d.typeError(off, "object", di.rt)
+	}
+	return nil
+}
+
+func (d *Decoder) openArray(off int) error {
+	f := d.top()
+	if f != nil {
+		switch f.kind {
+		case skipFrame:
+			d.frames = append(d.frames, dframe{kind: skipFrame})
+			return nil
+		case genFrame:
+			d.frames = append(d.frames, dframe{kind: genFrame, list: []any{}})
+			return nil
+		}
+	}
+	rv, di, _ := d.slot()
+	if !rv.IsValid() {
+		d.frames = append(d.frames, dframe{kind: skipFrame})
+		return nil
+	}
+	rv, di = deref(rv, di)
+	if di.unmarshaler {
+		d.frames = append(d.frames, dframe{kind: genFrame, list: []any{}, dst: rv, dstInfo: di})
+		return nil
+	}
+	switch di.kind {
+	case reflect.Slice:
+		rv.Set(reflect.MakeSlice(di.rt, 0, 4))
+		d.frames = append(d.frames, dframe{kind: sliceFrame, rv: rv, di: di})
+	case reflect.Array:
+		d.frames = append(d.frames, dframe{kind: arrayFrame, rv: rv, di: di})
+	case reflect.Interface:
+		if di.rt.NumMethod() != 0 {
+			return d.typeError(off, "array", di.rt)
+		}
+		d.frames = append(d.frames, dframe{kind: genFrame, list: []any{}, dst: rv, dstInfo: di})
+	default:
+		return d.typeError(off, "array", di.rt)
+	}
+	return nil
+}
+
+func (d *Decoder) close(off int) error {
+	f := d.frames[len(d.frames)-1]
+	d.frames[len(d.frames)-1] = dframe{}
+	d.frames = d.frames[:len(d.frames)-1]
+	switch f.kind {
+	case skipFrame:
+		return nil
+	case genFrame:
+		var v any
+		if f.isObj {
+			v = f.obj
+		} else {
+			v = f.list
+		}
+		if !f.dst.IsValid() { // nested in another generic
+			d.addGeneric(d.top(), v)
+			return nil
+		}
+		switch {
+		case f.dstInfo.unmarshaler:
+			if err := f.dst.Addr().Interface().(json.Unmarshaler).UnmarshalJSON([]byte(JSON(v))); err != nil {
+				return err
+			}
+		case f.dstInfo.attrSetter:
+			as := f.dst.Addr().Interface().(alt.AttrSetter)
+			for k, m := range f.obj {
+				if err := as.SetAttr(k, m); err != nil {
+					return err
+				}
+			}
+		default:
+			f.dst.Set(reflect.ValueOf(v))
+		}
+	case arrayFrame:
+		if f.n < f.rv.Len() {
+			zero := reflect.Zero(f.di.elem.rt)
+			for i := f.rv.Len() - 1; f.n <= i; i-- {
+				f.rv.Index(i).Set(zero)
+			}
+		}
+	}
+	d.commit()
+
+	return nil
+}
+
+func (d *Decoder) typeError(off int, from string, rt reflect.Type) error {
+	return d.newError(off, "value of type %s cannot be converted to type %s", from, rt)
+}
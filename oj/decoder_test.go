@@ -0,0 +1,254 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package oj_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/ohler55/ojg/oj"
+	"github.com/ohler55/ojg/tt"
+)
+
+type decInner struct {
+	Name string
+	Tags []string
+}
+
+type DecEmbed struct {
+	Depth int
+	Name  string
+}
+
+type decOuter struct {
+	DecEmbed
+	ID       int64 `json:"id"`
+	Name     string
+	Ratio    float32
+	Flag     bool
+	Count    uint8
+	Quoted   int `json:",string"`
+	Inner    *decInner
+	List     []*decInner
+	Grid     [2][2]int
+	Lookup   map[string]int
+	ByID     map[int]string
+	Any      any
+	Raw      []byte
+	Num      json.Number
+	When     time.Time
+	Skip     string `json:"-"`
+	private  int
+	Children []decOuter
+}
+
+type decSetter struct {
+	keys []string
+}
+
+func (ds *decSetter) SetAttr(attr string, val any) error {
+	ds.keys = append(ds.keys, fmt.Sprintf("%s:%v", attr, val))
+	return nil
+}
+
+func TestDecoderStruct(t *testing.T) {
+	src := `{
+  "id": 12345678901,
+  "name": "outer",
+  "depth": 3,
+  "ratio": 1.5,
+  "flag": true,
+  "count": 200,
+  "Quoted": "42",
+  "inner": {"name": "in", "tags": ["a", "b"], "extra": {"x": [1, {"y": null}]}},
+  "list": [{"name": "one"}, null, {"name": "three"}],
+  "grid": [[1, 2], [3, 4, 5]],
+  "lookup": {"a": 1, "b": 2},
+  "byID": {"7": "seven"},
+  "any": {"x": [1, true, null, "s"]},
+  "raw": "bytes",
+  "num": 12.50,
+  "when": "2026-01-02T03:04:05Z",
+  "skip": "not me",
+  "private": 3,
+  "children": [{"id": 2, "children": []}]
+}`
+	var obj decOuter
+	err := oj.Unmarshal([]byte(src), &obj)
+	tt.Nil(t, err)
+	tt.Equal(t, 12345678901, obj.ID)
+	tt.Equal(t, "outer", obj.Name)
+	tt.Equal(t, "", obj.DecEmbed.Name)
+	tt.Equal(t, 3, obj.Depth)
+	tt.Equal(t, 1.5, obj.Ratio)
+	tt.Equal(t, true, obj.Flag)
+	tt.Equal(t, 200, obj.Count)
+	tt.Equal(t, 42, obj.Quoted)
+	tt.Equal(t, "in", obj.Inner.Name)
+	tt.Equal(t, []string{"a", "b"}, obj.Inner.Tags)
+	tt.Equal(t, 3, len(obj.List))
+	tt.Equal(t, "one", obj.List[0].Name)
+	tt.Nil(t, obj.List[1])
+	tt.Equal(t, "three", obj.List[2].Name)
+	tt.Equal(t, [2][2]int{{1, 2}, {3, 4}}, obj.Grid)
+	tt.Equal(t, map[string]int{"a": 1, "b": 2}, obj.Lookup)
+	tt.Equal(t, map[int]string{7: "seven"}, obj.ByID)
+	tt.Equal(t, map[string]any{"x": []any{1.0, true, nil, "s"}}, obj.Any)
+	tt.Equal(t, "bytes", string(obj.Raw))
+	tt.Equal(t, "12.50", string(obj.Num))
+	tt.Equal(t, "2026-01-02T03:04:05Z", obj.When.Format(time.RFC3339))
+	tt.Equal(t, "", obj.Skip)
+	tt.Equal(t, 0, obj.private)
+	tt.Equal(t, 1, len(obj.Children))
+	tt.Equal(t, 2, obj.Children[0].ID)
+	tt.Equal(t, 0, len(obj.Children[0].Children))
+}
+
+func TestDecoderKeyModes(t *testing.T) {
+	src := []byte(`{"id": 1, "ID": 2, "name": "a", "Name": "b"}`)
+
+	var obj decOuter
+	d := oj.Decoder{}
+	err := d.Unmarshal(src, &obj)
+	tt.Nil(t, err)
+	tt.Equal(t, 2, obj.ID)
+	tt.Equal(t, "b", obj.Name)
+
+	obj = decOuter{}
+	d.UseTags = true
+	err = d.Unmarshal(src, &obj)
+	tt.Nil(t, err)
+	tt.Equal(t, 1, obj.ID)
+	tt.Equal(t, "b", obj.Name)
+
+	obj = decOuter{}
+	d.UseTags = false
+	d.KeyExact = true
+	err = d.Unmarshal([]byte(`{"id": 1, "name": "a", "ID": 3}`), &obj)
+	tt.Nil(t, err)
+	tt.Equal(t, 3, obj.ID)
+	tt.Equal(t, "", obj.Name)
+}
+
+func TestDecoderGeneric(t *testing.T) {
+	for _, d := range []data{
+		{src: `[1, 2.5, "x", true, null, {"a": []}]`, expect: `[1,2.5,"x",true,null,{"a":[]}]`},
+		{src: `{"a": {"b": [1, {"c": false}]}}`, expect: `{"a":{"b":[1,{"c":false}]}}`},
+		{src: `12`, expect: `12`},
+		{src: "\xEF\xBB\xBF\"bom\"", expect: `"bom"`},
+	} {
+		var v any
+		err := oj.Unmarshal([]byte(d.src), &v)
+		tt.Nil(t, err, d.src)
+		tt.Equal(t, d.expect, oj.JSON(v, &oj.Options{Sort: true}), d.src)
+	}
+}
+
+func TestDecoderScalars(t *testing.T) {
+	var i int
+	tt.Nil(t, oj.Unmarshal([]byte(`-17`), &i))
+	tt.Equal(t, -17, i)
+
+	var f float64
+	tt.Nil(t, oj.Unmarshal([]byte(`1.25e2`), &f))
+	tt.Equal(t, 125.0, f)
+
+	var s string
+	tt.Nil(t, oj.Unmarshal([]byte(`"a\tbé"`), &s))
+	tt.Equal(t, "a\tbé", s)
+
+	var ps *string
+	tt.Nil(t, oj.Unmarshal([]byte(`"x"`), &ps))
+	tt.Equal(t, "x", *ps)
+	tt.Nil(t, oj.Unmarshal([]byte(`null`), &ps))
+	tt.Nil(t, ps)
+
+	m := map[string]any{"keep": 1}
+	tt.Nil(t, oj.Unmarshal([]byte(`{"add": 2}`), m))
+	tt.Equal(t, map[string]any{"keep": 1, "add": 2.0}, m)
+}
+
+func TestDecoderIntLimits(t *testing.T) {
+	var i int64
+	tt.Nil(t, oj.Unmarshal([]byte(`9223372036854775807`), &i))
+	tt.Equal(t, int64(math.MaxInt64), i)
+	tt.Nil(t, oj.Unmarshal([]byte(`-9223372036854775808`), &i))
+	tt.Equal(t, int64(math.MinInt64), i)
+	err := oj.Unmarshal([]byte(`9223372036854775808`), &i)
+	tt.NotNil(t, err)
+	tt.Equal(t, "9223372036854775808 overflows a int64 at 1:20", err.Error())
+	tt.NotNil(t, oj.Unmarshal([]byte(`-9223372036854775809`), &i))
+	tt.NotNil(t, oj.Unmarshal([]byte(`9.3e18`), &i))
+
+	var u uint64
+	tt.Nil(t, oj.Unmarshal([]byte(`18446744073709551615`), &u))
+	tt.Equal(t, uint64(math.MaxUint64), u)
+	err = oj.Unmarshal([]byte(`18446744073709551616`), &u)
+	tt.NotNil(t, err)
+	tt.Equal(t, "18446744073709551616 overflows a uint64 at 1:21", err.Error())
+	tt.NotNil(t, oj.Unmarshal([]byte(`1.9e19`), &u))
+
+	var obj struct{ I int64 }
+	tt.Nil(t, oj.Unmarshal([]byte(`{"I":9223372036854775807}`), &obj))
+	tt.Equal(t, int64(math.MaxInt64), obj.I)
+}
+
+func TestDecoderSetter(t *testing.T) {
+	var ds decSetter
+	err := oj.Unmarshal([]byte(`{"a": 1}`), &ds)
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"a:1"}, ds.keys)
+}
+
+func TestDecoderReader(t *testing.T) {
+	src := `{"id": 7, "name": "reader", "list": [{"name": "x", "tags": ["t1", "t2"]}], "num": 123456}`
+	var obj decOuter
+	d := oj.Decoder{}
+	err := d.UnmarshalReader(iotest.OneByteReader(strings.NewReader(src)), &obj)
+	tt.Nil(t, err)
+	tt.Equal(t, 7, obj.ID)
+	tt.Equal(t, "reader", obj.Name)
+	tt.Equal(t, []string{"t1", "t2"}, obj.List[0].Tags)
+	tt.Equal(t, "123456", string(obj.Num))
+}
+
+func TestDecoderErrors(t *testing.T) {
+	for _, d := range []struct {
+		src    string
+		target any
+		expect string
+	}{
+		{src: `{"id": true}`, target: &decOuter{}, expect: "value of type bool cannot be converted to type int64 at 1:11"},
+		{src: `{"name": 3}`, target: &decOuter{}, expect: "value of type number cannot be converted to type string at 1:11"},
+		{src: `{"count": 300}`, target: &decOuter{}, expect: "300 overflows a uint8 at 1:14"},
+		{src: `{"count": -1}`, target: &decOuter{}, expect: "-1 overflows a uint8 at 1:13"},
+		{src: `{"Quoted": "x"}`, target: &decOuter{}, expect: "can not convert 'x' to a int at 1:14"},
+		{src: `{"byID": {"x": "y"}}`, target: &decOuter{}, expect: "invalid int map key 'x' at 1:13"},
+		{src: `{"inner": []}`, target: &decOuter{}, expect: "value of type array cannot be converted to type oj_test.decInner at 1:11"},
+		{src: `{"list": {}}`, target: &decOuter{}, expect: "value of type object cannot be converted to type []*oj_test.decInner at 1:10"},
+		{src: `{"id": 1,}`, target: &decOuter{}, expect: "expected a string start, not '}' at 1:10"},
+		{src: `{"id": 1]`, target: &decOuter{}, expect: "unexpected array close at 1:9"},
+		{src: `[1, 2`, target: &[]int{}, expect: "incomplete JSON at 1:7"},
+		{src: `{}`, target: decOuter{}, expect: "can only decode into a non-nil pointer or map, not a oj_test.decOuter"},
+	} {
+		err := oj.Unmarshal([]byte(d.src), d.target)
+		tt.NotNil(t, err, d.src)
+		tt.Equal(t, d.expect, err.Error(), d.src)
+	}
+}
+
+func BenchmarkDecoderStruct(b *testing.B) {
+	src := []byte(`{"id": 1, "name": "bench", "inner": {"name": "in", "tags": ["a", "b"]}, "lookup": {"a": 1}}`)
+	d := oj.Decoder{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		var obj decOuter
+		_ = d.Unmarshal(src, &obj)
+	}
+}
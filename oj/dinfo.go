@@ -0,0 +1,171 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package oj
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ohler55/ojg/alt"
+)
+
+const (
+	dkeyLoose = iota // tag or name along with lower case variations
+	dkeyTag          // json tag or field name
+	dkeyExact        // exact field name
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	attrSetterType      = reflect.TypeOf((*alt.AttrSetter)(nil)).Elem()
+	jsonNumberType      = reflect.TypeOf(json.Number(""))
+
+	dinfoMut sync.Mutex
+	dinfoMap = map[reflect.Type]*dinfo{}
+)
+
+// dinfo is the decoding information for a type.
+type dinfo struct {
+	rt              reflect.Type
+	kind            reflect.Kind
+	elem            *dinfo                // pointer, slice, array, and map elements
+	fields          [3]map[string]*dfield // struct fields by key mode
+	unmarshaler     bool                  // pointer to type is a json.Unmarshaler
+	textUnmarshaler bool                  // pointer to type is a encoding.TextUnmarshaler
+	attrSetter      bool                  // pointer to type is an alt.AttrSetter
+}
+
+// dfield is the decoding information for a struct field.
+type dfield struct {
+	di       *dinfo
+	name     string
+	key      string
+	index    []int
+	asString bool
+	tagged   bool
+	depth    int
+}
+
+func getDinfo(rt reflect.Type) *dinfo {
+	dinfoMut.Lock()
+	defer dinfoMut.Unlock()
+
+	return buildDinfo(rt)
+}
+
+// Non-locking version used when building.
+func buildDinfo(rt reflect.Type) (di *dinfo) {
+	if di = dinfoMap[rt]; di != nil {
+		return
+	}
+	di = &dinfo{rt: rt, kind: rt.Kind()}
+	// Register before building elements and fields so that recursive types
+	// find the partially built info.
+	dinfoMap[rt] = di
+	if di.kind != reflect.Ptr && di.kind != reflect.Interface {
+		pt := reflect.PtrTo(rt)
+		di.unmarshaler = pt.Implements(jsonUnmarshalerType)
+		di.textUnmarshaler = pt.Implements(textUnmarshalerType)
+		di.attrSetter = pt.Implements(attrSetterType)
+	}
+	switch di.kind {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		di.elem = buildDinfo(rt.Elem())
+	case reflect.Struct:
+		fa := collectDfields(rt, nil, 0)
+		// Shallower fields take precedence over promoted fields so order
+		// by depth and let the first field for a key win.
+		sort.SliceStable(fa, func(i, j int) bool { return fa[i].depth < fa[j].depth })
+		for mode := range di.fields {
+			di.fields[mode] = buildDfieldMap(fa, mode)
+		}
+	}
+	return
+}
+
+// collectDfields collects the fields of a struct including the promoted
+// fields of embedded structs.
+func collectDfields(rt reflect.Type, index []int, depth int) (fa []*dfield) {
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		tag, tagged := f.Tag.Lookup("json")
+		parts := strings.Split(tag, ",")
+		if tagged && parts[0] == "-" && len(parts) == 1 {
+			continue
+		}
+		fi := append(append([]int{}, index...), i)
+		if f.Anonymous && (!tagged || len(parts[0]) == 0) {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr && len(f.PkgPath) == 0 {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fa = append(fa, collectDfields(ft, fi, depth+1)...)
+				continue
+			}
+		}
+		if 0 < len(f.PkgPath) || len(f.Name) == 0 || f.Name[0] == '_' {
+			continue
+		}
+		df := dfield{
+			di:     buildDinfo(f.Type),
+			name:   f.Name,
+			key:    f.Name,
+			index:  fi,
+			tagged: tagged && 0 < len(parts[0]),
+			depth:  depth,
+		}
+		if df.tagged {
+			df.key = parts[0]
+		}
+		for _, p := range parts[1:] {
+			if p == "string" {
+				df.asString = true
+			}
+		}
+		fa = append(fa, &df)
+	}
+	return
+}
+
+func buildDfieldMap(fa []*dfield, mode int) map[string]*dfield {
+	fm := map[string]*dfield{}
+	add := func(key string, df *dfield) {
+		if _, has := fm[key]; !has {
+			fm[key] = df
+		}
+	}
+	switch mode {
+	case dkeyTag:
+		for _, df := range fa {
+			add(df.key, df)
+		}
+	case dkeyExact:
+		for _, df := range fa {
+			add(df.name, df)
+		}
+	default:
+		// Matches the keys the alt.Recomposer accepts, the tag or name first
+		// followed by the name and then lower case variations.
+		for _, df := range fa {
+			add(df.key, df)
+		}
+		for _, df := range fa {
+			name := []byte(df.name)
+			if name[0] < 0x80 {
+				name[0] |= 0x20
+			}
+			for _, k := range []string{df.name, string(name), strings.ToLower(df.name)} {
+				if _, has := fm[k]; !has {
+					fm[k] = df
+				}
+			}
+		}
+	}
+	return fm
+}
@@ -2,16 +2,52 @@
 
 package oj
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// Rule violation errors. A *ParseError for one of these rules wraps the
+// matching error so errors.Is() can be used to determine which rule was
+// broken.
+var (
+	// ErrDuplicateKey indicates a key appeared more than once in an object.
+	ErrDuplicateKey = errors.New("duplicate key")
+
+	// ErrExtraData indicates more than one JSON document was found when
+	// only one is allowed.
+	ErrExtraData = errors.New("extra data")
+
+	// ErrInvalidUTF8 indicates a string was not valid UTF-8 which is only
+	// reported in Strict mode.
+	ErrInvalidUTF8 = errors.New("invalid UTF-8")
+
+	// ErrLoneSurrogate indicates a \u escape of a surrogate that was not
+	// part of a surrogate pair which is only reported in IJSON mode.
+	ErrLoneSurrogate = errors.New("lone surrogate")
+
+	// ErrNumberRange indicates a number outside the range of an IEEE-754
+	// double which is only reported in IJSON mode.
+	ErrNumberRange = errors.New("number out of range")
+)
 
 // ParseError represents a parse error.
 type ParseError struct {
 	Message string
 	Line    int
 	Column  int
+
+	// Err is the rule that was violated if the error is for a rule
+	// violation. It is nil for other syntax errors.
+	Err error
 }
 
 // Error returns a string representation of the error.
 func (err *ParseError) Error() string {
 	return fmt.Sprintf("%s at %d:%d", err.Message, err.Line, err.Column)
 }
+
+// Unwrap returns the rule violated or nil.
+func (err *ParseError) Unwrap() error {
+	return err.Err
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package oj
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ohler55/ojg"
+)
+
+// LineReader reads JSON Lines, also known as NDJSON, where each line is a
+// separate JSON document. Blank lines are skipped. Values are read one at a
+// time by calling Next() followed by Value() in a loop similar to a
+// bufio.Scanner.
+//
+//	lr := oj.NewLineReader(r)
+//	for lr.Next() {
+//	    fmt.Println(lr.Value())
+//	}
+//	if err := lr.Err(); err != nil {
+//	    // handle error
+//	}
+type LineReader struct {
+	// Parser is used to parse each line. It can be configured before the
+	// first call to Next().
+	Parser Parser
+
+	// OnError if not nil is called when a line can not be parsed. If it
+	// returns true the line is skipped and reading continues with the next
+	// line otherwise reading stops and the error is returned by Err(). Line
+	// errors are *ParseError with the line set to the line in the input.
+	OnError func(err error) bool
+
+	r     *bufio.Reader
+	buf   []byte
+	value any
+	err   error
+	line  int
+	done  bool
+}
+
+// NewLineReader returns a new LineReader that reads from r.
+func NewLineReader(r io.Reader) *LineReader {
+	return &LineReader{r: bufio.NewReaderSize(r, readBufSize)}
+}
+
+// Next advances to the next value which is then available with Value().
+// False is returned when there are no more values or on an error.
+func (lr *LineReader) Next() bool {
+	lr.value = nil
+	for !lr.done {
+		line, err := lr.readLine()
+		if err != nil {
+			lr.done = true
+			if !errors.Is(err, io.EOF) {
+				lr.err = err
+				return false
+			}
+		}
+		lr.line++
+		if line = bytes.TrimSpace(line); len(line) == 0 {
+			continue
+		}
+		v, err := lr.Parser.Parse(line)
+		if err != nil {
+			err = lr.lineError(err)
+			if lr.OnError != nil && lr.OnError(err) {
+				continue
+			}
+			lr.done = true
+			lr.err = err
+			return false
+		}
+		lr.value = v
+		return true
+	}
+	return false
+}
+
+// Value returns the most recent value read by Next().
+func (lr *LineReader) Value() any {
+	return lr.value
+}
+
+// Line returns the line number of the most recent line read.
+func (lr *LineReader) Line() int {
+	return lr.line
+}
+
+// Err returns the error that stopped reading or nil if the end of the input
+// was reached.
+func (lr *LineReader) Err() error {
+	return lr.err
+}
+
+// readLine reads a line without the newline. Lines longer than the reader
+// buffer are collected in lr.buf.
+func (lr *LineReader) readLine() ([]byte, error) {
+	line, err := lr.r.ReadSlice('\n')
+	if !errors.Is(err, bufio.ErrBufferFull) {
+		return bytes.TrimSuffix(line, []byte{'\n'}), err
+	}
+	lr.buf = append(lr.buf[:0], line...)
+	for errors.Is(err, bufio.ErrBufferFull) {
+		line, err = lr.r.ReadSlice('\n')
+		lr.buf = append(lr.buf, line...)
+	}
+	return bytes.TrimSuffix(lr.buf, []byte{'\n'}), err
+}
+
+func (lr *LineReader) lineError(err error) error {
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		le := *pe
+		le.Line = lr.line
+		return &le
+	}
+	return fmt.Errorf("line %d: %w", lr.line, err)
+}
+
+// LineWriter writes JSON Lines, also known as NDJSON, with each value
+// written as compact JSON followed by a newline. Records are buffered and
+// flushed according to FlushCount and FlushSize. If neither is set each
+// record is flushed as it is written. Flush() must be called after the last
+// record is written when buffering.
+type LineWriter struct {
+	// Writer is used to encode each record. Indentation options are ignored
+	// so that each record is on a single line.
+	Writer Writer
+
+	// FlushCount if greater than zero is the number of records buffered
+	// before being flushed.
+	FlushCount int
+
+	// FlushSize if greater than zero is the number of bytes buffered before
+	// being flushed.
+	FlushSize int
+
+	w     io.Writer
+	buf   []byte
+	count int
+}
+
+// NewLineWriter returns a new LineWriter that writes to w. An optional
+// *ojg.Options can be provided to control the encoding of records.
+func NewLineWriter(w io.Writer, options ...*ojg.Options) *LineWriter {
+	lw := LineWriter{w: w, Writer: Writer{Options: DefaultOptions}}
+	if 0 < len(options) && options[0] != nil {
+		lw.Writer.Options = *options[0]
+	}
+	return &lw
+}
+
+// Write a record followed by a newline.
+func (lw *LineWriter) Write(data any) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = ojg.NewError(r)
+		}
+	}()
+	lw.Writer.Indent = 0
+	lw.Writer.Tab = false
+	lw.buf = append(lw.buf, lw.Writer.MustJSON(data)...)
+	lw.buf = append(lw.buf, '\n')
+	lw.count++
+	switch {
+	case lw.FlushCount <= 0 && lw.FlushSize <= 0,
+		0 < lw.FlushCount && lw.FlushCount <= lw.count,
+		0 < lw.FlushSize && lw.FlushSize <= len(lw.buf):
+		err = lw.Flush()
+	}
+	return
+}
+
+// Flush writes any buffered records.
+func (lw *LineWriter) Flush() (err error) {
+	if 0 < len(lw.buf) {
+		_, err = lw.w.Write(lw.buf)
+		lw.buf = lw.buf[:0]
+	}
+	lw.count = 0
+
+	return
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package oj_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/ohler55/ojg"
+	"github.com/ohler55/ojg/oj"
+	"github.com/ohler55/ojg/tt"
+)
+
+func TestLineReader(t *testing.T) {
+	src := `{"a":1}
+[true,null]
+
+  "three"
+{"b":{"c":[1,2]}}`
+	lr := oj.NewLineReader(strings.NewReader(src))
+	var values []any
+	var lines []int
+	for lr.Next() {
+		values = append(values, lr.Value())
+		lines = append(lines, lr.Line())
+	}
+	tt.Nil(t, lr.Err())
+	tt.Equal(t, []any{
+		map[string]any{"a": 1},
+		[]any{true, nil},
+		"three",
+		map[string]any{"b": map[string]any{"c": []any{1, 2}}},
+	}, values)
+	tt.Equal(t, []int{1, 2, 4, 5}, lines)
+}
+
+func TestLineReaderCRLF(t *testing.T) {
+	lr := oj.NewLineReader(iotest.OneByteReader(strings.NewReader("1\r\n2\r\n")))
+	var values []any
+	for lr.Next() {
+		values = append(values, lr.Value())
+	}
+	tt.Nil(t, lr.Err())
+	tt.Equal(t, []any{1, 2}, values)
+}
+
+func TestLineReaderLong(t *testing.T) {
+	long := `"` + strings.Repeat("x", 10000) + `"`
+	lr := oj.NewLineReader(strings.NewReader(long + "\n" + long + "\n"))
+	cnt := 0
+	for lr.Next() {
+		tt.Equal(t, 10000, len(lr.Value().(string)))
+		cnt++
+	}
+	tt.Nil(t, lr.Err())
+	tt.Equal(t, 2, cnt)
+}
+
+func TestLineReaderError(t *testing.T) {
+	src := "1\n2\n{\"x\": tru}\n[4]\n5 6\n7\n"
+	lr := oj.NewLineReader(strings.NewReader(src))
+	var values []any
+	for lr.Next() {
+		values = append(values, lr.Value())
+	}
+	tt.Equal(t, []any{1, 2}, values)
+	tt.NotNil(t, lr.Err())
+	tt.Equal(t, "expected true at 3:10", lr.Err().Error())
+
+	lr = oj.NewLineReader(strings.NewReader(src))
+	var errs []string
+	lr.OnError = func(err error) bool {
+		errs = append(errs, err.Error())
+		return true
+	}
+	values = values[:0]
+	for lr.Next() {
+		values = append(values, lr.Value())
+	}
+	tt.Nil(t, lr.Err())
+	tt.Equal(t, []any{1, 2, []any{4}, 7}, values)
+	tt.Equal(t, []string{"expected true at 3:10", "extra characters after close, '6' at 5:3"}, errs)
+
+	lr = oj.NewLineReader(strings.NewReader("1\n{\"a\":1,\"a\":2}\n"))
+	lr.Parser.CheckDupKeys = true
+	for lr.Next() {
+	}
+	tt.Equal(t, true, errors.Is(lr.Err(), oj.ErrDuplicateKey))
+	tt.Equal(t, 2, lr.Line())
+
+	lr = oj.NewLineReader(iotest.ErrReader(errors.New("failed")))
+	tt.Equal(t, false, lr.Next())
+	tt.Equal(t, "failed", lr.Err().Error())
+}
+
+type countWriter struct {
+	bytes.Buffer
+	writes int
+	err    error
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	cw.writes++
+	return cw.Buffer.Write(p)
+}
+
+func TestLineWriter(t *testing.T) {
+	var cw countWriter
+	lw := oj.NewLineWriter(&cw, &ojg.Options{Indent: 2, Sort: true})
+	for _, v := range []any{
+		map[string]any{"b": 2, "a": []any{1, "x\ny"}},
+		nil,
+		"str",
+	} {
+		tt.Nil(t, lw.Write(v))
+	}
+	tt.Equal(t, "{\"a\":[1,\"x\\ny\"],\"b\":2}\nnull\n\"str\"\n", cw.String())
+	tt.Equal(t, 3, cw.writes)
+
+	cw = countWriter{}
+	lw = oj.NewLineWriter(&cw)
+	lw.FlushCount = 2
+	for i := 0; i < 5; i++ {
+		tt.Nil(t, lw.Write(i))
+	}
+	tt.Equal(t, 2, cw.writes)
+	tt.Equal(t, "0\n1\n2\n3\n", cw.String())
+	tt.Nil(t, lw.Flush())
+	tt.Equal(t, 3, cw.writes)
+	tt.Equal(t, "0\n1\n2\n3\n4\n", cw.String())
+
+	cw = countWriter{}
+	lw = oj.NewLineWriter(&cw)
+	lw.FlushSize = 8
+	for _, s := range []string{"abc", "def", "g"} {
+		tt.Nil(t, lw.Write(s))
+	}
+	tt.Equal(t, 1, cw.writes)
+	tt.Equal(t, "\"abc\"\n\"def\"\n", cw.String())
+
+	cw.err = errors.New("failed")
+	lw = oj.NewLineWriter(&cw)
+	err := lw.Write(1)
+	tt.NotNil(t, err)
+	tt.Equal(t, "failed", err.Error())
+}
+
+func TestLineRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	lw := oj.NewLineWriter(&buf)
+	lw.FlushCount = 100
+	in := []any{map[string]any{"x": 1}, []any{"a", "b"}, true}
+	for _, v := range in {
+		tt.Nil(t, lw.Write(v))
+	}
+	tt.Nil(t, lw.Flush())
+
+	lr := oj.NewLineReader(&buf)
+	var out []any
+	for lr.Next() {
+		out = append(out, lr.Value())
+	}
+	tt.Nil(t, lr.Err())
+	tt.Equal(t, in, out)
+}
@@ -143,8 +143,8 @@ const (
 	zeroMap = "" +
 		".........rs..r.................." + // 0x00
 		"r...........u.t................." + // 0x20
-		".............................m.." + // 0x40
-		".............................n.." + // 0x60
+		".....w.......................m.." + // 0x40
+		".....w.......................n.." + // 0x60
 		"................................" + // 0x80
 		"................................" + // 0xa0
 		"................................" + // 0xc0
@@ -42,6 +42,11 @@ var (
 			return &Parser{}
 		},
 	}
+	decoderPool = sync.Pool{
+		New: func() any {
+			return &Decoder{}
+		},
+	}
 )
 
 // Parse JSON into a simple type. Arguments are optional and can be a bool,
@@ -142,17 +147,20 @@ func ValidateReader(r io.Reader) error {
 }
 
 // Unmarshal parses the provided JSON and stores the result in the value
-// pointed to by vp.
+// pointed to by vp. If no recomposer is provided the JSON is decoded
+// directly into vp with a Decoder otherwise a generic value is built and
+// then recomposed with the recomposer.
 func Unmarshal(data []byte, vp any, recomposer ...*alt.Recomposer) (err error) {
+	if len(recomposer) == 0 {
+		d := decoderPool.Get().(*Decoder)
+		defer decoderPool.Put(d)
+		return d.Unmarshal(data, vp)
+	}
 	p := Parser{}
 	p.num.ForceFloat = true
 	var v any
 	if v, err = p.Parse(data); err == nil {
-		if 0 < len(recomposer) {
-			_, err = recomposer[0].Recompose(v, vp)
-		} else {
-			_, err = alt.Recompose(v, vp)
-		}
+		_, err = recomposer[0].Recompose(v, vp)
 	}
 	return
 }
@@ -4,9 +4,12 @@ package oj
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math"
+	"strconv"
 	"unicode/utf8"
 
 	"github.com/ohler55/ojg"
@@ -28,7 +31,6 @@ var emptySlice = []any{}
 type Parser struct {
 	tracker
 	tmp        []byte // used for numbers and strings
-	runeBytes  []byte
 	stack      []any
 	starts     []int
 	maps       []map[string]any
@@ -38,13 +40,37 @@ type Parser struct {
 	mi         int
 	num        gen.Number
 	rn         rune
+	sur        surrogate
 	result     any
 	mode       string
 	nextMode   string
+	dupKeys    bool
+	strict     bool
 
 	// Reuse maps. Previously returned maps will no longer be valid or rather
 	// could be modified during parsing.
 	Reuse bool
+
+	// CheckDupKeys if true causes a duplicate key in an object to be
+	// reported as a *ParseError at the line and column of the duplicate key.
+	CheckDupKeys bool
+
+	// OnDupKey if not nil is called for each duplicate key encountered
+	// instead of returning an error. The line and column are those of the
+	// duplicate key. Parsing continues and the last value wins. Setting
+	// OnDupKey also turns on duplicate key detection.
+	OnDupKey func(key string, line, column int)
+
+	// Strict if true rejects input that RFC 8259 does not allow but that is
+	// otherwise tolerated. Strings must be valid UTF-8, a decimal point must
+	// be followed by a digit, and only one JSON document is allowed so
+	// callbacks and channels can not be used.
+	Strict bool
+
+	// IJSON if true enforces the I-JSON profile of RFC 7493 in addition to
+	// the Strict rules. Duplicate keys, lone surrogates in \u escapes, and
+	// numbers outside the range of an IEEE-754 double are rejected.
+	IJSON bool
 }
 
 func recomposeToJSON(v any) (any, error) {
@@ -92,6 +118,10 @@ func (p *Parser) Parse(buf []byte, args ...any) (any, error) {
 			return nil, fmt.Errorf("a %T is not a valid option type", a)
 		}
 	}
+	p.strict = p.Strict || p.IJSON
+	if p.strict && !p.OnlyOne {
+		return nil, fmt.Errorf("only one JSON document is allowed in strict mode")
+	}
 	if p.stack == nil {
 		p.stack = make([]any, 0, stackInitSize)
 		p.tmp = make([]byte, 0, tmpInitSize)
@@ -105,6 +135,8 @@ func (p *Parser) Parse(buf []byte, args ...any) (any, error) {
 	p.result = nil
 	p.noff = -1
 	p.line = 1
+	p.dupKeys = p.CheckDupKeys || p.OnDupKey != nil || p.IJSON
+	p.sur.hi = 0
 	p.mode = valueMap
 	p.mi = 0
 	var err error
@@ -152,6 +184,10 @@ func (p *Parser) ParseReader(r io.Reader, args ...any) (data any, err error) {
 			return nil, fmt.Errorf("a %T is not a valid option type", a)
 		}
 	}
+	p.strict = p.Strict || p.IJSON
+	if p.strict && !p.OnlyOne {
+		return nil, fmt.Errorf("only one JSON document is allowed in strict mode")
+	}
 	if p.stack == nil {
 		p.stack = make([]any, 0, stackInitSize)
 		p.tmp = make([]byte, 0, tmpInitSize)
@@ -165,6 +201,8 @@ func (p *Parser) ParseReader(r io.Reader, args ...any) (data any, err error) {
 	p.result = nil
 	p.noff = -1
 	p.line = 1
+	p.dupKeys = p.CheckDupKeys || p.OnDupKey != nil || p.IJSON
+	p.sur.hi = 0
 	p.mi = 0
 	buf := make([]byte, readBufSize)
 	eof := false
@@ -198,6 +236,7 @@ func (p *Parser) ParseReader(r io.Reader, args ...any) (data any, err error) {
 
 			return
 		}
+		p.noff -= len(buf) - skip
 		skip = 0
 		if eof {
 			break
@@ -243,6 +282,10 @@ func (p *Parser) parseBuffer(buf []byte, last bool) error {
 		case strOk:
 			p.tmp = append(p.tmp, b)
 		case keyQuote:
+			if p.dupKeys {
+				p.kline = p.line
+				p.kcol = off - p.noff
+			}
 			start := off + 1
 			if len(buf) <= start {
 				p.tmp = p.tmp[:0]
@@ -258,8 +301,18 @@ func (p *Parser) parseBuffer(buf []byte, last bool) error {
 			off += i
 			if b == '"' {
 				off++
+				if p.strict {
+					if i := invalidUTF8(buf[start:off]); 0 <= i {
+						return p.utf8Error(start + i)
+					}
+				}
 				p.stack = append(p.stack, gen.Key(buf[start:off]))
 				p.mode = colonMap
+				if p.dupKeys {
+					if err := p.checkKey(); err != nil {
+						return err
+					}
+				}
 			} else {
 				p.tmp = p.tmp[:0]
 				p.tmp = append(p.tmp, buf[start:off+1]...)
@@ -290,6 +343,11 @@ func (p *Parser) parseBuffer(buf []byte, last bool) error {
 			off += i
 			if b == '"' {
 				off++
+				if p.strict {
+					if i := invalidUTF8(buf[start:off]); 0 <= i {
+						return p.utf8Error(start + i)
+					}
+				}
 				p.add(string(buf[start:off]))
 				p.mode = afterMap
 			} else {
@@ -300,7 +358,9 @@ func (p *Parser) parseBuffer(buf []byte, last bool) error {
 				continue
 			}
 		case numComma:
-			p.add(p.num.AsNum())
+			if err := p.addNum(off); err != nil {
+				return err
+			}
 			if 0 < len(p.starts) {
 				if p.starts[len(p.starts)-1] == -1 {
 					p.mode = keyMap
@@ -344,7 +404,9 @@ func (p *Parser) parseBuffer(buf []byte, last bool) error {
 				return p.newError(off, "unexpected object close")
 			}
 			if 256 < len(p.mode) && p.mode[256] == 'n' {
-				p.add(p.num.AsNum())
+				if err := p.addNum(off); err != nil {
+					return err
+				}
 			}
 			p.starts = p.starts[0:depth]
 			n := p.stack[len(p.stack)-1]
@@ -397,7 +459,9 @@ func (p *Parser) parseBuffer(buf []byte, last bool) error {
 			// Only modes with a close array are value, after, and numbers
 			// which are all over 256 long.
 			if p.mode[256] == 'n' {
-				p.add(p.num.AsNum())
+				if err := p.addNum(off); err != nil {
+					return err
+				}
 			}
 			start := p.starts[len(p.starts)-1] + 1
 			p.starts = p.starts[:len(p.starts)-1]
@@ -440,6 +504,11 @@ func (p *Parser) parseBuffer(buf []byte, last bool) error {
 				p.mode = dotMap
 				continue
 			}
+			if p.strict && (len(buf) <= off+1 || digitMap[buf[off+1]] != numDigit) {
+				// A digit must follow the decimal point.
+				p.mode = dotMap
+				continue
+			}
 			for i, b = range buf[off+1:] {
 				if digitMap[b] != numDigit {
 					break
@@ -466,9 +535,22 @@ func (p *Parser) parseBuffer(buf []byte, last bool) error {
 			p.mode = expSignMap
 			continue
 		case strQuote:
+			if p.sur.end() && p.IJSON {
+				return p.loneSurrogate(off)
+			}
+			if p.strict && 0 <= invalidUTF8(p.tmp) {
+				// The exact position is not known if the string spans
+				// buffers or includes escapes so use the closing quote.
+				return p.utf8Error(off)
+			}
 			p.mode = p.nextMode
 			if p.mode[':'] == colonColon {
 				p.stack = append(p.stack, gen.Key(p.tmp))
+				if p.dupKeys {
+					if err := p.checkKey(); err != nil {
+						return err
+					}
+				}
 			} else {
 				p.add(string(p.tmp))
 			}
@@ -480,10 +562,14 @@ func (p *Parser) parseBuffer(buf []byte, last bool) error {
 			p.num.AddDigit(b)
 			p.mode = digitMap
 		case numSpc:
-			p.add(p.num.AsNum())
+			if err := p.addNum(off); err != nil {
+				return err
+			}
 			p.mode = afterMap
 		case numNewline:
-			p.add(p.num.AsNum())
+			if err := p.addNum(off); err != nil {
+				return err
+			}
 			p.line++
 			p.noff = off
 			p.mode = afterMap
@@ -513,11 +599,10 @@ func (p *Parser) parseBuffer(buf []byte, last bool) error {
 				p.rn = p.rn<<4 | rune(b-'A'+10)
 			}
 			if p.ri == 4 {
-				if len(p.runeBytes) < 6 {
-					p.runeBytes = make([]byte, 6)
+				var lone bool
+				if p.tmp, lone = p.sur.appendRune(p.tmp, p.rn); lone && p.IJSON {
+					return p.loneSurrogate(off)
 				}
-				n := utf8.EncodeRune(p.runeBytes, p.rn)
-				p.tmp = append(p.tmp, p.runeBytes[:n]...)
 				p.mode = stringMap
 			}
 			continue
@@ -578,8 +663,13 @@ func (p *Parser) parseBuffer(buf []byte, last bool) error {
 		if 0 < len(p.starts) || len(p.mode) == 256 { // valid finishing maps are one byte longer
 			return p.newError(off, "incomplete JSON")
 		}
+		if p.strict && p.mode[256] == 'v' { // nothing but whitespace
+			return p.newError(off, "incomplete JSON")
+		}
 		if p.mode[256] == 'n' {
-			p.add(p.num.AsNum())
+			if err := p.addNum(off); err != nil {
+				return err
+			}
 			if p.cb == nil && p.resultChan == nil {
 				p.result = p.stack[0]
 			} else {
@@ -607,3 +697,87 @@ func (p *Parser) add(n any) {
 	}
 	p.stack = append(p.stack, n)
 }
+
+// checkKey checks the key on the top of the stack against the keys already
+// in the object it belongs to.
+func (p *Parser) checkKey() error {
+	k := p.stack[len(p.stack)-1].(gen.Key)
+	if obj, _ := p.stack[len(p.stack)-2].(map[string]any); obj != nil {
+		if _, has := obj[string(k)]; has {
+			return p.dupKey(string(k), p.OnDupKey)
+		}
+	}
+	return nil
+}
+
+// addNum adds the current number. In IJSON mode the number must be within
+// the range of a float64.
+func (p *Parser) addNum(off int) error {
+	n := p.num.AsNum()
+	if p.IJSON && !numInRange(n) {
+		if len(p.num.BigBuf) == 0 {
+			p.num.FillBig()
+		}
+		return &ParseError{
+			Message: fmt.Sprintf("number %s is out of range", p.num.BigBuf),
+			Line:    p.line,
+			Column:  off - p.noff,
+			Err:     ErrNumberRange,
+		}
+	}
+	p.add(n)
+
+	return nil
+}
+
+func (p *Parser) utf8Error(off int) error {
+	return &ParseError{
+		Message: "invalid UTF-8 in string",
+		Line:    p.line,
+		Column:  off - p.noff,
+		Err:     ErrInvalidUTF8,
+	}
+}
+
+func (p *Parser) loneSurrogate(off int) error {
+	return &ParseError{
+		Message: "lone surrogate in string",
+		Line:    p.line,
+		Column:  off - p.noff,
+		Err:     ErrLoneSurrogate,
+	}
+}
+
+// invalidUTF8 returns the index of the first invalid UTF-8 sequence in str
+// or -1 if str is valid UTF-8.
+func invalidUTF8(str []byte) int {
+	for i := 0; i < len(str); {
+		if str[i] < utf8.RuneSelf {
+			i++
+			continue
+		}
+		r, n := utf8.DecodeRune(str[i:])
+		if r == utf8.RuneError && n == 1 {
+			return i
+		}
+		i += n
+	}
+	return -1
+}
+
+// numInRange returns false if the number is too large to be represented as a
+// float64.
+func numInRange(n any) bool {
+	var f float64
+	switch tn := n.(type) {
+	case float64:
+		f = tn
+	case json.Number:
+		f, _ = strconv.ParseFloat(string(tn), 64)
+	case string:
+		f, _ = strconv.ParseFloat(tn, 64)
+	default:
+		return true
+	}
+	return !math.IsInf(f, 0)
+}
@@ -5,6 +5,7 @@ package oj_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -76,6 +77,9 @@ func TestParserParseString(t *testing.T) {
 		{src: "[[true]]", value: []any{[]any{true}}},
 		{src: `"x\t\n\"\b\f\r\u0041\\\/y"`, value: "x\t\n\"\b\f\r\u0041\\/y"},
 		{src: `"x\u004a\u004Ay"`, value: "xJJy"},
+		{src: `"\ud83d\ude00 \ud800x"`, value: "😀 \ufffdx"},
+		{src: "0e1", value: 0.0},
+		{src: "[0E-2]", value: []any{0.0}},
 
 		{src: `[1,"a\tb"]`, value: []any{1, "a\tb"}},
 		{src: `{"a\tb":1}`, value: map[string]any{"a\tb": 1}},
@@ -410,3 +414,60 @@ func TestParserDecimalSplit(t *testing.T) {
 	tt.Equal(t, true, ok)
 	tt.Equal(t, numStr, num.String())
 }
+
+func TestParserDupKeys(t *testing.T) {
+	p := oj.Parser{CheckDupKeys: true}
+	for i, d := range []data{
+		{src: `{"a":1,"b":2}`, value: map[string]any{"a": 1, "b": 2}},
+		{src: `{"a":{"a":1},"b":[{"a":2},{"a":3}]}`,
+			value: map[string]any{"a": map[string]any{"a": 1}, "b": []any{map[string]any{"a": 2}, map[string]any{"a": 3}}}},
+		{src: `{"a":1,"a":2}`, expect: "duplicate key 'a' at 1:8"},
+		{src: "{\n  \"a\": 1,\n  \"b\": {\"x\": true, \"x\": false}}", expect: "duplicate key 'x' at 3:20"},
+		{src: `{"a\tb":1,"a\u0009b":2}`, expect: "duplicate key 'a\tb' at 1:11"},
+		{src: `{"":1,"":2}`, expect: "duplicate key '' at 1:7"},
+	} {
+		v, err := p.Parse([]byte(d.src))
+		if 0 < len(d.expect) {
+			tt.NotNil(t, err, d.src)
+			tt.Equal(t, d.expect, err.Error(), i, ": ", d.src)
+			var pe *oj.ParseError
+			tt.Equal(t, true, errors.As(err, &pe))
+		} else {
+			tt.Nil(t, err, d.src)
+			tt.Equal(t, d.value, v, i, ": ", d.src)
+		}
+		r := strings.NewReader(strings.Repeat(" ", 4093) + d.src)
+		if _, err = p.ParseReader(r); 0 < len(d.expect) {
+			tt.NotNil(t, err, d.src)
+		} else {
+			tt.Nil(t, err, d.src)
+		}
+	}
+}
+
+func TestParserDupKeysCallback(t *testing.T) {
+	var dups []string
+	p := oj.Parser{
+		OnDupKey: func(key string, line, column int) {
+			dups = append(dups, fmt.Sprintf("%s@%d:%d", key, line, column))
+		},
+	}
+	v, err := p.Parse([]byte("{\"a\":1,\"a\":2,\n\"b\":{\"c\":3,\"c\":4},\"a\":5}"))
+	tt.Nil(t, err)
+	tt.Equal(t, map[string]any{"a": 5, "b": map[string]any{"c": 4}}, v)
+	tt.Equal(t, []string{"a@1:8", "c@2:12", "a@2:19"}, dups)
+
+	// Force the key to span a buffer read.
+	dups = dups[:0]
+	src := strings.Repeat(" ", 4090) + `{"abc":1,"abc":2}`
+	v, err = p.ParseReader(strings.NewReader(src))
+	tt.Nil(t, err)
+	tt.Equal(t, map[string]any{"abc": 2}, v)
+	tt.Equal(t, []string{"abc@1:4100"}, dups)
+
+	dups = dups[:0]
+	src = strings.Repeat(" ", 4098) + `{"abc":1,"abc":2}`
+	_, err = p.ParseReader(strings.NewReader(src))
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"abc@1:4108"}, dups)
+}
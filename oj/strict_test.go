@@ -0,0 +1,160 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package oj_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/ohler55/ojg/oj"
+	"github.com/ohler55/ojg/tt"
+)
+
+const suiteDir = "testdata/JSONTestSuite/test_parsing"
+
+// Implementation defined cases that are accepted in Strict mode. All others
+// are rejected.
+var strictAccepts = map[string]bool{
+	"i_number_double_huge_neg_exp.json":                   true,
+	"i_number_huge_exp.json":                              true,
+	"i_number_neg_int_huge_exp.json":                      true,
+	"i_number_pos_double_huge_exp.json":                   true,
+	"i_number_real_neg_overflow.json":                     true,
+	"i_number_real_pos_overflow.json":                     true,
+	"i_number_real_underflow.json":                        true,
+	"i_number_too_big_neg_int.json":                       true,
+	"i_number_too_big_pos_int.json":                       true,
+	"i_number_very_big_negative_int.json":                 true,
+	"i_object_key_lone_2nd_surrogate.json":                true,
+	"i_string_1st_surrogate_but_2nd_missing.json":         true,
+	"i_string_1st_valid_surrogate_2nd_invalid.json":       true,
+	"i_string_incomplete_surrogate_and_escape_valid.json": true,
+	"i_string_incomplete_surrogate_pair.json":             true,
+	"i_string_incomplete_surrogates_escape_valid.json":    true,
+	"i_string_invalid_lonely_surrogate.json":              true,
+	"i_string_invalid_surrogate.json":                     true,
+	"i_string_inverted_surrogates_U+1D11E.json":           true,
+	"i_string_lone_second_surrogate.json":                 true,
+	"i_structure_500_nested_arrays.json":                  true,
+	"i_structure_UTF-8_BOM_empty_object.json":             true,
+}
+
+// Cases that are accepted in Strict mode but rejected in IJSON mode along
+// with the rule that is violated.
+var ijsonRejects = map[string]error{
+	"y_object_duplicated_key.json":                        oj.ErrDuplicateKey,
+	"y_object_duplicated_key_and_value.json":              oj.ErrDuplicateKey,
+	"i_number_huge_exp.json":                              oj.ErrNumberRange,
+	"i_number_neg_int_huge_exp.json":                      oj.ErrNumberRange,
+	"i_number_pos_double_huge_exp.json":                   oj.ErrNumberRange,
+	"i_number_real_neg_overflow.json":                     oj.ErrNumberRange,
+	"i_number_real_pos_overflow.json":                     oj.ErrNumberRange,
+	"i_object_key_lone_2nd_surrogate.json":                oj.ErrLoneSurrogate,
+	"i_string_1st_surrogate_but_2nd_missing.json":         oj.ErrLoneSurrogate,
+	"i_string_1st_valid_surrogate_2nd_invalid.json":       oj.ErrLoneSurrogate,
+	"i_string_incomplete_surrogate_and_escape_valid.json": oj.ErrLoneSurrogate,
+	"i_string_incomplete_surrogate_pair.json":             oj.ErrLoneSurrogate,
+	"i_string_incomplete_surrogates_escape_valid.json":    oj.ErrLoneSurrogate,
+	"i_string_invalid_lonely_surrogate.json":              oj.ErrLoneSurrogate,
+	"i_string_invalid_surrogate.json":                     oj.ErrLoneSurrogate,
+	"i_string_inverted_surrogates_U+1D11E.json":           oj.ErrLoneSurrogate,
+	"i_string_lone_second_surrogate.json":                 oj.ErrLoneSurrogate,
+}
+
+func TestStrictJSONTestSuite(t *testing.T) {
+	files, err := os.ReadDir(suiteDir)
+	tt.Nil(t, err)
+	for _, f := range files {
+		name := f.Name()
+		src, err := os.ReadFile(filepath.Join(suiteDir, name))
+		tt.Nil(t, err, name)
+
+		accept := name[0] == 'y' || strictAccepts[name]
+		for _, ijson := range []bool{false, true} {
+			expect := accept
+			rule := ijsonRejects[name]
+			if ijson && rule != nil {
+				expect = false
+			}
+			p := oj.Parser{Strict: true, IJSON: ijson}
+			_, err = p.Parse(src)
+			if expect {
+				tt.Nil(t, err, name, " ijson: ", ijson)
+			} else {
+				tt.NotNil(t, err, name, " ijson: ", ijson)
+				if ijson && rule != nil {
+					tt.Equal(t, true, errors.Is(err, rule), name, " ", err)
+				}
+			}
+			_, err = p.ParseReader(bytes.NewReader(src))
+			if expect {
+				tt.Nil(t, err, name, " reader ijson: ", ijson)
+			} else {
+				tt.NotNil(t, err, name, " reader ijson: ", ijson)
+			}
+		}
+	}
+}
+
+func TestStrictRules(t *testing.T) {
+	for _, d := range []struct {
+		src    string
+		ijson  bool
+		rule   error
+		expect string
+	}{
+		{src: "[1.]", expect: "invalid number at 1:4"},
+		{src: "2.", expect: "incomplete JSON at 1:3"},
+		{src: `{"a":1} {"b":2}`, rule: oj.ErrExtraData, expect: "extra characters after close, '{' at 1:9"},
+		{src: "[\"ab\xffc\"]", rule: oj.ErrInvalidUTF8, expect: "invalid UTF-8 in string at 1:5"},
+		{src: "{\"k\xc0\":1}", rule: oj.ErrInvalidUTF8, expect: "invalid UTF-8 in string at 1:4"},
+		{src: "[\"\\n\xff\"]", rule: oj.ErrInvalidUTF8, expect: "invalid UTF-8 in string at 1:6"},
+		{src: `{"a":1,"a":2}`, ijson: true, rule: oj.ErrDuplicateKey, expect: "duplicate key 'a' at 1:8"},
+		{src: `["\ud800"]`, ijson: true, rule: oj.ErrLoneSurrogate, expect: "lone surrogate in string at 1:9"},
+		{src: `["\udc00"]`, ijson: true, rule: oj.ErrLoneSurrogate, expect: "lone surrogate in string at 1:8"},
+		{src: `[1e999]`, ijson: true, rule: oj.ErrNumberRange, expect: "number 1e999 is out of range at 1:7"},
+		{src: `[-123123123123123123123123e400]`, ijson: true, rule: oj.ErrNumberRange, expect: "number -123123123123123123123123e400 is out of range at 1:31"},
+	} {
+		p := oj.Parser{Strict: true, IJSON: d.ijson}
+		// Positions may differ when reading as strings span buffers so only
+		// check the rule.
+		_, err := p.ParseReader(iotest.OneByteReader(strings.NewReader(d.src)))
+		tt.NotNil(t, err, d.src)
+		if d.rule != nil {
+			tt.Equal(t, true, errors.Is(err, d.rule), d.src)
+		}
+		_, err = p.Parse([]byte(d.src))
+		tt.NotNil(t, err, d.src)
+		tt.Equal(t, d.expect, err.Error(), d.src)
+		var pe *oj.ParseError
+		tt.Equal(t, true, errors.As(err, &pe), d.src)
+		if d.rule != nil {
+			tt.Equal(t, true, errors.Is(err, d.rule), d.src)
+		} else {
+			tt.Nil(t, pe.Err, d.src)
+		}
+	}
+}
+
+func TestStrictValues(t *testing.T) {
+	p := oj.Parser{IJSON: true}
+	v, err := p.Parse([]byte(`{"emoji":"\ud83d\ude00","big":123456789012345678901234567890}`))
+	tt.Nil(t, err)
+	tt.Equal(t, "😀", v.(map[string]any)["emoji"])
+	tt.Equal(t, "123456789012345678901234567890", oj.JSON(v.(map[string]any)["big"]))
+
+	// Lone surrogates are replaced when not in IJSON mode.
+	p = oj.Parser{Strict: true}
+	v, err = p.Parse([]byte(`["\ud800x","\udc00"]`))
+	tt.Nil(t, err)
+	tt.Equal(t, []any{"\ufffdx", "\ufffd"}, v)
+
+	_, err = p.Parse([]byte(`[1] [2]`), func(any) {})
+	tt.NotNil(t, err)
+	tt.Equal(t, true, strings.Contains(err.Error(), "only one JSON document"))
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package oj
+
+import (
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// surrogate tracks a high surrogate from a \u escape so that it can be
+// combined with an immediately following low surrogate escape.
+type surrogate struct {
+	hi rune
+	at int // length of the string buffer after the high surrogate was added
+}
+
+// appendRune appends the rune from a \u escape to buf. A surrogate pair is
+// combined into a single rune. A lone surrogate is appended as U+FFFD and
+// lone is returned as true.
+func (s *surrogate) appendRune(buf []byte, r rune) (_ []byte, lone bool) {
+	switch {
+	case 0xD800 <= r && r < 0xDC00:
+		lone = s.hi != 0
+		s.hi = r
+		// Add a replacement character which is removed if a low surrogate
+		// follows.
+		buf = utf8.AppendRune(buf, utf8.RuneError)
+		s.at = len(buf)
+		return buf, lone
+	case 0xDC00 <= r && r < 0xE000:
+		if s.hi != 0 && s.at == len(buf) {
+			buf = buf[:len(buf)-3]
+			r = utf16.DecodeRune(s.hi, r)
+		} else {
+			lone = true
+		}
+	default:
+		lone = s.hi != 0
+	}
+	s.hi = 0
+
+	return utf8.AppendRune(buf, r), lone
+}
+
+// end is called at the end of a string and returns true if a high surrogate
+// was not followed by a low surrogate.
+func (s *surrogate) end() (lone bool) {
+	lone = s.hi != 0
+	s.hi = 0
+
+	return
+}
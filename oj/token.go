@@ -0,0 +1,186 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package oj
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// TokenKind identifies the kind of a Token.
+type TokenKind byte
+
+const (
+	// NullToken is a JSON null.
+	NullToken TokenKind = iota + 1
+	// BoolToken is a JSON true or false.
+	BoolToken
+	// IntToken is a JSON integer that fits in an int64.
+	IntToken
+	// FloatToken is a JSON decimal that fits in a float64.
+	FloatToken
+	// NumberToken is a JSON number that does not fit in an int64 or float64.
+	NumberToken
+	// StringToken is a JSON string value.
+	StringToken
+	// KeyToken is a JSON object key.
+	KeyToken
+	// ObjectStartToken is a JSON object start '{'.
+	ObjectStartToken
+	// ObjectEndToken is a JSON object end '}'.
+	ObjectEndToken
+	// ArrayStartToken is a JSON array start '['.
+	ArrayStartToken
+	// ArrayEndToken is a JSON array end ']'.
+	ArrayEndToken
+)
+
+// String returns the name of the token kind.
+func (k TokenKind) String() string {
+	switch k {
+	case NullToken:
+		return "null"
+	case BoolToken:
+		return "bool"
+	case IntToken:
+		return "int"
+	case FloatToken:
+		return "float"
+	case NumberToken:
+		return "number"
+	case StringToken:
+		return "string"
+	case KeyToken:
+		return "key"
+	case ObjectStartToken:
+		return "object start"
+	case ObjectEndToken:
+		return "object end"
+	case ArrayStartToken:
+		return "array start"
+	case ArrayEndToken:
+		return "array end"
+	}
+	return "unknown"
+}
+
+// Token is a single JSON token as returned by the Next() function of a
+// Tokenizer. Only the field that matches the Kind is set.
+type Token struct {
+	Kind TokenKind
+
+	// Str is the value for StringToken, KeyToken, and NumberToken kinds.
+	Str string
+
+	// Int is the value for an IntToken.
+	Int int64
+
+	// Float is the value for a FloatToken.
+	Float float64
+
+	// Bool is the value for a BoolToken.
+	Bool bool
+}
+
+// Key returns the key of a KeyToken or an empty string for other kinds.
+func (t Token) Key() string {
+	if t.Kind == KeyToken {
+		return t.Str
+	}
+	return ""
+}
+
+// Value returns the value of a value token as a nil, bool, int64, float64,
+// json.Number, or string. Nil is returned for other kinds.
+func (t Token) Value() any {
+	switch t.Kind {
+	case BoolToken:
+		return t.Bool
+	case IntToken:
+		return t.Int
+	case FloatToken:
+		return t.Float
+	case NumberToken:
+		return json.Number(t.Str)
+	case StringToken:
+		return t.Str
+	}
+	return nil
+}
+
+// String returns a JSON representation of the token.
+func (t Token) String() string {
+	switch t.Kind {
+	case NullToken:
+		return "null"
+	case BoolToken:
+		return strconv.FormatBool(t.Bool)
+	case IntToken:
+		return strconv.FormatInt(t.Int, 10)
+	case FloatToken:
+		return strconv.FormatFloat(t.Float, 'g', -1, 64)
+	case NumberToken:
+		return t.Str
+	case StringToken, KeyToken:
+		return strconv.Quote(t.Str)
+	case ObjectStartToken:
+		return "{"
+	case ObjectEndToken:
+		return "}"
+	case ArrayStartToken:
+		return "["
+	case ArrayEndToken:
+		return "]"
+	}
+	return ""
+}
+
+// tokenQueue is a TokenHandler that collects tokens for the pull API of the
+// Tokenizer.
+type tokenQueue struct {
+	tokens []Token
+}
+
+func (q *tokenQueue) Null() {
+	q.tokens = append(q.tokens, Token{Kind: NullToken})
+}
+
+func (q *tokenQueue) Bool(b bool) {
+	q.tokens = append(q.tokens, Token{Kind: BoolToken, Bool: b})
+}
+
+func (q *tokenQueue) Int(i int64) {
+	q.tokens = append(q.tokens, Token{Kind: IntToken, Int: i})
+}
+
+func (q *tokenQueue) Float(f float64) {
+	q.tokens = append(q.tokens, Token{Kind: FloatToken, Float: f})
+}
+
+func (q *tokenQueue) Number(s string) {
+	q.tokens = append(q.tokens, Token{Kind: NumberToken, Str: s})
+}
+
+func (q *tokenQueue) String(s string) {
+	q.tokens = append(q.tokens, Token{Kind: StringToken, Str: s})
+}
+
+func (q *tokenQueue) ObjectStart() {
+	q.tokens = append(q.tokens, Token{Kind: ObjectStartToken})
+}
+
+func (q *tokenQueue) ObjectEnd() {
+	q.tokens = append(q.tokens, Token{Kind: ObjectEndToken})
+}
+
+func (q *tokenQueue) Key(s string) {
+	q.tokens = append(q.tokens, Token{Kind: KeyToken, Str: s})
+}
+
+func (q *tokenQueue) ArrayStart() {
+	q.tokens = append(q.tokens, Token{Kind: ArrayStartToken})
+}
+
+func (q *tokenQueue) ArrayEnd() {
+	q.tokens = append(q.tokens, Token{Kind: ArrayEndToken})
+}
@@ -32,6 +32,16 @@ type Tokenizer struct {
 	rn        rune
 	mode      string
 	nextMode  string
+
+	// Used when pulling tokens with Next().
+	r     io.Reader
+	buf   []byte
+	queue tokenQueue
+	pos   int
+	depth int
+	err   error
+	eof   bool
+	first bool
 }
 
 // TokenizeString the provided JSON and call the handler functions for each
@@ -139,6 +149,203 @@ func (t *Tokenizer) Load(r io.Reader, handler TokenHandler) (err error) {
 	return
 }
 
+// NewTokenizer returns a Tokenizer for pulling tokens from r with Next().
+func NewTokenizer(r io.Reader) *Tokenizer {
+	t := Tokenizer{}
+	t.Reset(r)
+
+	return &t
+}
+
+// Reset prepares the Tokenizer for pulling tokens from r with Next(). Buffers
+// are reused across resets.
+func (t *Tokenizer) Reset(r io.Reader) {
+	t.handler = &t.queue
+	if t.starts == nil {
+		t.tmp = make([]byte, 0, tmpInitSize)
+		t.starts = make([]byte, 0, 16)
+		t.buf = make([]byte, 0, readBufSize)
+	} else {
+		t.tmp = t.tmp[:0]
+		t.starts = t.starts[:0]
+		if t.buf == nil {
+			t.buf = make([]byte, 0, readBufSize)
+		}
+	}
+	t.noff = -1
+	t.line = 1
+	t.mi = 0
+	t.mode = valueMap
+	t.r = r
+	t.queue.tokens = t.queue.tokens[:0]
+	t.pos = 0
+	t.depth = 0
+	t.err = nil
+	t.eof = false
+	t.first = true
+}
+
+// Next returns the next token read from the io.Reader provided to
+// NewTokenizer() or Reset(). The io.EOF error is returned when there are no
+// more tokens. Input is read and tokenized a buffer at a time as tokens are
+// requested.
+func (t *Tokenizer) Next() (tok Token, err error) {
+	for len(t.queue.tokens) <= t.pos {
+		if err = t.fill(); err != nil {
+			return
+		}
+	}
+	tok = t.queue.tokens[t.pos]
+	t.pos++
+	switch tok.Kind {
+	case ObjectStartToken, ArrayStartToken:
+		t.depth++
+	case ObjectEndToken, ArrayEndToken:
+		t.depth--
+	}
+	return
+}
+
+// Skip skips over the rest of the current object or array including the
+// closing token. Called immediately after Next() returns an
+// ObjectStartToken or ArrayStartToken the whole object or array is
+// skipped. Skip does nothing if not in an object or array. Input that has
+// not been tokenized yet is scanned only for strings and the closing
+// bracket so no tokens are created for the skipped values and they are not
+// validated.
+func (t *Tokenizer) Skip() error {
+	if t.depth <= 0 {
+		return nil
+	}
+	target := t.depth - 1
+	for ; t.pos < len(t.queue.tokens); t.pos++ {
+		switch t.queue.tokens[t.pos].Kind {
+		case ObjectStartToken, ArrayStartToken:
+			t.depth++
+		case ObjectEndToken, ArrayEndToken:
+			t.depth--
+			if t.depth == target {
+				t.pos++
+				return nil
+			}
+		}
+	}
+	// The queue is empty so the tokenizer state is at the end of the last
+	// buffer read which may be in the middle of a string.
+	inStr := t.mode == stringMap || t.mode == escMap || t.mode == uMap
+	esc := t.mode == escMap
+	for {
+		buf, err := t.read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		for off, b := range buf {
+			switch {
+			case esc:
+				esc = false
+			case inStr:
+				switch b {
+				case '\\':
+					esc = true
+				case '"':
+					inStr = false
+				}
+			case b == '"':
+				inStr = true
+			case b == '{' || b == '[':
+				t.depth++
+			case b == '}' || b == ']':
+				t.depth--
+				if t.depth == target {
+					t.starts = t.starts[:target]
+					t.tmp = t.tmp[:0]
+					t.mode = afterMap
+					if target == 0 {
+						t.mi = 0
+						if t.OnlyOne {
+							t.mode = spaceMap
+						} else {
+							t.mode = valueMap
+						}
+					}
+					// Offsets in the rest of the buffer start after the
+					// close.
+					t.noff -= off + 1
+					return t.tokenize(buf[off+1:])
+				}
+			case b == '\n':
+				t.line++
+				t.noff = off
+			}
+		}
+		t.noff -= len(buf)
+	}
+}
+
+// fill reads the next buffer and tokenizes it into the token queue.
+func (t *Tokenizer) fill() error {
+	buf, err := t.read()
+	if err != nil {
+		return err
+	}
+	return t.tokenize(buf)
+}
+
+// read reads the next buffer from the reader skipping the BOM if present at
+// the start.
+func (t *Tokenizer) read() ([]byte, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	if t.r == nil {
+		t.err = fmt.Errorf("no reader to read tokens from")
+		return nil, t.err
+	}
+	if t.eof {
+		t.err = io.EOF
+		return nil, t.err
+	}
+	t.queue.tokens = t.queue.tokens[:0]
+	t.pos = 0
+	buf := t.buf[:cap(t.buf)]
+	cnt, err := t.r.Read(buf)
+	buf = buf[:cnt]
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			t.err = err
+			return nil, err
+		}
+		t.eof = true
+	}
+	if t.first {
+		// Skip BOM if present.
+		if 3 < len(buf) && buf[0] == 0xEF && buf[1] == 0xBB && buf[2] == 0xBF {
+			buf = buf[3:]
+		}
+		t.first = false
+	}
+	return buf, nil
+}
+
+// tokenize the buffer into the token queue.
+func (t *Tokenizer) tokenize(buf []byte) error {
+	t.queue.tokens = t.queue.tokens[:0]
+	t.pos = 0
+	if err := t.tokenizeBuffer(buf, t.eof); err != nil {
+		// Tokens before the error are returned before the error.
+		t.err = err
+		if len(t.queue.tokens) == 0 {
+			return err
+		}
+	}
+	t.noff -= len(buf)
+
+	return nil
+}
+
 func (t *Tokenizer) tokenizeBuffer(buf []byte, last bool) error {
 	var b byte
 	var i int
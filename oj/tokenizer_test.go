@@ -7,6 +7,7 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/ohler55/ojg/oj"
 	"github.com/ohler55/ojg/tt"
@@ -286,3 +287,129 @@ func TestTokenizerNesting(t *testing.T) {
 	tt.Nil(t, err)
 	tt.Equal(t, "[ { a: [ 1 2 ] } ] ", string(h.buf))
 }
+
+func pullAll(toker interface{ Next() (oj.Token, error) }) (string, error) {
+	var b strings.Builder
+	for {
+		tok, err := toker.Next()
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return b.String(), err
+		}
+		b.WriteString(tok.String())
+		b.WriteByte(' ')
+	}
+}
+
+func TestTokenizerNext(t *testing.T) {
+	src := `[true,null,123,-12.3,"aé"]{"x":12345678901234567890,"y":{}} 7`
+	toker := oj.NewTokenizer(strings.NewReader(src))
+	out, err := pullAll(toker)
+	tt.Nil(t, err)
+	tt.Equal(t, `[ true null 123 -12.3 "aé" ] { "x" 12345678901234567890 "y" { } } 7 `, out)
+
+	toker.Reset(iotest.OneByteReader(strings.NewReader(src)))
+	out, err = pullAll(toker)
+	tt.Nil(t, err)
+	tt.Equal(t, `[ true null 123 -12.3 "aé" ] { "x" 12345678901234567890 "y" { } } 7 `, out)
+
+	toker.Reset(strings.NewReader(`{"k":"v","n":1.5,"b":false}`))
+	var values []any
+	var keys []string
+	for {
+		tok, err := toker.Next()
+		if err != nil {
+			tt.Equal(t, io.EOF, err)
+			break
+		}
+		switch tok.Kind {
+		case oj.KeyToken:
+			keys = append(keys, tok.Key())
+		case oj.ObjectStartToken, oj.ObjectEndToken:
+			tt.Nil(t, tok.Value())
+		default:
+			values = append(values, tok.Value())
+		}
+	}
+	tt.Equal(t, []string{"k", "n", "b"}, keys)
+	tt.Equal(t, []any{"v", 1.5, false}, values)
+
+	// EOF is sticky.
+	_, err = toker.Next()
+	tt.Equal(t, io.EOF, err)
+}
+
+func TestTokenizerNextError(t *testing.T) {
+	toker := oj.NewTokenizer(strings.NewReader(`[1,2,}`))
+	out, err := pullAll(toker)
+	tt.NotNil(t, err)
+	tt.Equal(t, "unexpected character '}' at 1:6", err.Error())
+	tt.Equal(t, "[ 1 2 ", out)
+
+	toker = oj.NewTokenizer(iotest.ErrReader(fmt.Errorf("failed")))
+	_, err = toker.Next()
+	tt.Equal(t, "failed", err.Error())
+
+	toker = &oj.Tokenizer{}
+	_, err = toker.Next()
+	tt.NotNil(t, err)
+}
+
+func TestTokenizerSkip(t *testing.T) {
+	src := `{"skip":{"a":[1,{"b":2}],"c":"d]}\\\"{[","e":"\u005d"},"keep":[3,[4,5],6],"last":true}`
+	for _, r := range []io.Reader{strings.NewReader(src), iotest.OneByteReader(strings.NewReader(src))} {
+		toker := oj.NewTokenizer(r)
+		var b strings.Builder
+		for {
+			tok, err := toker.Next()
+			if err != nil {
+				tt.Equal(t, io.EOF, err)
+				break
+			}
+			b.WriteString(tok.String())
+			b.WriteByte(' ')
+			switch {
+			case tok.Kind == oj.KeyToken && tok.Key() == "skip":
+				tok, err = toker.Next()
+				tt.Nil(t, err)
+				tt.Equal(t, oj.ObjectStartToken, tok.Kind)
+				tt.Nil(t, toker.Skip())
+			case tok.Kind == oj.IntToken && tok.Int == 4:
+				// Skip the rest of the inner array.
+				tt.Nil(t, toker.Skip())
+			}
+		}
+		tt.Equal(t, `{ "skip" "keep" [ 3 [ 4 6 ] "last" true } `, b.String())
+	}
+	// Skip at the top level does nothing.
+	toker := oj.NewTokenizer(strings.NewReader("1 2"))
+	tt.Nil(t, toker.Skip())
+	tok, err := toker.Next()
+	tt.Nil(t, err)
+	tt.Equal(t, int64(1), tok.Value())
+
+	toker = oj.NewTokenizer(strings.NewReader(`[1,[2,3`))
+	_, _ = toker.Next()
+	tt.NotNil(t, toker.Skip())
+
+	// Lines are still counted when skipped values are not tokenized.
+	toker = oj.NewTokenizer(iotest.OneByteReader(strings.NewReader("[[1,\n2],\n x]")))
+	_, _ = toker.Next()
+	_, _ = toker.Next()
+	tt.Nil(t, toker.Skip())
+	_, err = toker.Next()
+	tt.NotNil(t, err)
+	tt.Equal(t, "unexpected character 'x' at 3:2", err.Error())
+}
+
+func TestTokenKind(t *testing.T) {
+	for k := oj.NullToken; k <= oj.ArrayEndToken; k++ {
+		tt.NotEqual(t, "unknown", k.String())
+	}
+	tt.Equal(t, "unknown", oj.TokenKind(0).String())
+	tt.Equal(t, "", oj.Token{}.String())
+	tt.Equal(t, "", oj.Token{Kind: oj.StringToken, Str: "x"}.Key())
+	tt.Equal(t, "x", oj.Token{Kind: oj.NumberToken, Str: "x"}.String())
+}
@@ -8,6 +8,10 @@ type tracker struct {
 	line int
 	noff int // Offset of last newline from start of buf. Can be negative when using a reader.
 
+	// Line and column of the most recent key when checking for duplicate keys.
+	kline int
+	kcol  int
+
 	// OnlyOne returns an error if more than one JSON is in the string or stream.
 	OnlyOne bool
 }
@@ -50,8 +54,24 @@ func (t *tracker) byteError(off int, mode string, b byte, r rune) error {
 		err.Message = fmt.Sprintf("invalid JSON unicode character '%c'", r)
 	case spaceMap:
 		err.Message = fmt.Sprintf("extra characters after close, '%c'", r)
+		err.Err = ErrExtraData
 	default:
 		err.Message = fmt.Sprintf("unexpected character '%c'", r)
 	}
 	return err
 }
+
+// dupKey reports a duplicate key at the position of the most recent key
+// either by calling onDup or, if onDup is nil, by returning a *ParseError.
+func (t *tracker) dupKey(key string, onDup func(key string, line, column int)) error {
+	if onDup != nil {
+		onDup(key, t.kline, t.kcol)
+		return nil
+	}
+	return &ParseError{
+		Message: fmt.Sprintf("duplicate key '%s'", key),
+		Line:    t.kline,
+		Column:  t.kcol,
+		Err:     ErrDuplicateKey,
+	}
+}
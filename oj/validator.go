@@ -26,10 +26,25 @@ type Validator struct {
 	ri       int    // read index for null, false, and true
 	mode     string
 	nextMode string
+	tmp      []byte // key being checked when a key spans buffers or has escapes
+	rn       rune
+	sur      surrogate
+	keys     []map[string]bool // keys for each open object when checking for duplicates
+	dupKeys  bool
+	inKey    bool // true while collecting the bytes of a key in tmp
 
 	// OnlyOne returns an error if more than one JSON is in the string or
 	// stream.
 	OnlyOne bool
+
+	// CheckDupKeys if true causes a duplicate key in an object to be
+	// reported as a *ParseError at the line and column of the duplicate key.
+	CheckDupKeys bool
+
+	// OnDupKey if not nil is called for each duplicate key encountered
+	// instead of returning an error. The line and column are those of the
+	// duplicate key. Setting OnDupKey also turns on duplicate key detection.
+	OnDupKey func(key string, line, column int)
 }
 
 // Validate a JSON encoded byte slice.
@@ -39,6 +54,9 @@ func (p *Validator) Validate(buf []byte) (err error) {
 	} else {
 		p.stack = p.stack[:0]
 	}
+	p.keys = p.keys[:0]
+	p.dupKeys = p.CheckDupKeys || p.OnDupKey != nil
+	p.inKey = false
 	p.noff = -1
 	p.line = 1
 	p.mode = valueMap
@@ -62,6 +80,9 @@ func (p *Validator) ValidateReader(r io.Reader) error {
 	} else {
 		p.stack = p.stack[:0]
 	}
+	p.keys = p.keys[:0]
+	p.dupKeys = p.CheckDupKeys || p.OnDupKey != nil
+	p.inKey = false
 	p.noff = -1
 	p.line = 1
 	p.mode = valueMap
@@ -131,8 +152,17 @@ func (p *Validator) validateBuffer(buf []byte, last bool) error {
 		case skipChar:
 			continue
 		case strOk:
+			if p.inKey {
+				p.tmp = append(p.tmp, b)
+			}
 			continue
 		case keyQuote:
+			if p.dupKeys {
+				p.kline = p.line
+				p.kcol = off - p.noff
+				p.tmp = p.tmp[:0]
+			}
+			start := off + 1
 			i = 0
 			for i, b = range buf[off+1:] {
 				if stringMap[b] != strOk {
@@ -143,7 +173,17 @@ func (p *Validator) validateBuffer(buf []byte, last bool) error {
 			if b == '"' && 0 < i {
 				off++
 				p.mode = colonMap
+				if p.dupKeys {
+					if err := p.checkKey(buf[start:off]); err != nil {
+						return err
+					}
+				}
 			} else {
+				if p.dupKeys {
+					p.tmp = append(p.tmp, buf[start:off+1]...)
+					p.inKey = true
+					p.sur.hi = 0
+				}
 				p.mode = stringMap
 				p.nextMode = colonMap
 			}
@@ -181,12 +221,18 @@ func (p *Validator) validateBuffer(buf []byte, last bool) error {
 			p.mode = escMap
 			continue
 		case escOk:
+			if p.inKey {
+				p.tmp = append(p.tmp, escByteMap[b])
+			}
 			p.mode = stringMap
 			continue
 		case openObject:
 			p.stack = append(p.stack, '{')
 			p.mode = key1Map
 			depth++
+			if p.dupKeys {
+				p.pushKeys()
+			}
 			continue
 		case closeObject:
 			depth--
@@ -195,6 +241,9 @@ func (p *Validator) validateBuffer(buf []byte, last bool) error {
 			}
 			p.stack = p.stack[0:depth]
 			p.mode = afterMap
+			if p.dupKeys {
+				p.keys = p.keys[:len(p.keys)-1]
+			}
 		case val0:
 			p.mode = zeroMap
 			continue
@@ -206,6 +255,7 @@ func (p *Validator) validateBuffer(buf []byte, last bool) error {
 			continue
 		case escU:
 			p.mode = uMap
+			p.rn = 0
 			p.ri = 0
 			continue
 		case openArray:
@@ -254,6 +304,13 @@ func (p *Validator) validateBuffer(buf []byte, last bool) error {
 			continue
 		case strQuote:
 			p.mode = p.nextMode
+			if p.inKey {
+				p.inKey = false
+				p.sur.end()
+				if err := p.checkKey(p.tmp); err != nil {
+					return err
+				}
+			}
 		case numZero:
 			p.mode = zeroMap
 		case numDigit:
@@ -280,7 +337,20 @@ func (p *Validator) validateBuffer(buf []byte, last bool) error {
 			p.mode = expMap
 		case uOk:
 			p.ri++
+			if p.inKey {
+				switch b {
+				case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+					p.rn = p.rn<<4 | rune(b-'0')
+				case 'a', 'b', 'c', 'd', 'e', 'f':
+					p.rn = p.rn<<4 | rune(b-'a'+10)
+				case 'A', 'B', 'C', 'D', 'E', 'F':
+					p.rn = p.rn<<4 | rune(b-'A'+10)
+				}
+			}
 			if p.ri == 4 {
+				if p.inKey {
+					p.tmp, _ = p.sur.appendRune(p.tmp, p.rn)
+				}
 				p.mode = stringMap
 			}
 			continue
@@ -328,3 +398,31 @@ func (p *Validator) validateBuffer(buf []byte, last bool) error {
 	}
 	return nil
 }
+
+func (p *Validator) pushKeys() {
+	if len(p.keys) < cap(p.keys) {
+		p.keys = p.keys[:len(p.keys)+1]
+		m := p.keys[len(p.keys)-1]
+		if m == nil {
+			p.keys[len(p.keys)-1] = map[string]bool{}
+		} else {
+			for k := range m {
+				delete(m, k)
+			}
+		}
+		return
+	}
+	p.keys = append(p.keys, map[string]bool{})
+}
+
+// checkKey checks the key against the keys already seen in the current
+// object.
+func (p *Validator) checkKey(key []byte) error {
+	m := p.keys[len(p.keys)-1]
+	if m[string(key)] {
+		return p.dupKey(string(key), p.OnDupKey)
+	}
+	m[string(key)] = true
+
+	return nil
+}
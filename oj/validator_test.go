@@ -4,6 +4,7 @@ package oj_test
 
 import (
 	"bytes"
+	"fmt"
 	"strings"
 	"testing"
 	"testing/iotest"
@@ -212,3 +213,53 @@ func TestValidatorValidateReaderErr(t *testing.T) {
 	err = v.ValidateReader(&r)
 	tt.NotNil(t, err)
 }
+
+func TestValidatorDupKeys(t *testing.T) {
+	v := oj.Validator{CheckDupKeys: true}
+	for i, d := range []data{
+		{src: `{"a":1,"b":2}`},
+		{src: `{"a":{"a":1},"b":[{"a":2},{"a":3}],"c":{}}`},
+		{src: `[{"a":1},{"a":2}]`},
+		{src: `{"a":1,"a":2}`, expect: "duplicate key 'a' at 1:8"},
+		{src: "{\n  \"a\": 1,\n  \"b\": {\"x\": true, \"x\": false}}", expect: "duplicate key 'x' at 3:20"},
+		{src: `{"a\tb":1,"a\u0009b":2}`, expect: "duplicate key 'a\tb' at 1:11"},
+		{src: `{"xé":1,"x\u00e9":2}`, expect: "duplicate key 'xé' at 1:10"},
+		{src: `{"":1,"":2}`, expect: "duplicate key '' at 1:7"},
+		{src: `{"a":{"b":1},"a":2}`, expect: "duplicate key 'a' at 1:14"},
+		{src: `{"\ud83d\ude00":1,"\ud83d\ude01":2}`},
+		{src: `{"😀":1,"\ud83d\ude00":2}`, expect: "duplicate key '😀' at 1:11"},
+		{src: `{"s":["a\tb","c\u0041"],"a\u0041":1,"aA":2}`, expect: "duplicate key 'aA' at 1:37"},
+	} {
+		err := v.Validate([]byte(d.src))
+		if 0 < len(d.expect) {
+			tt.NotNil(t, err, d.src)
+			tt.Equal(t, d.expect, err.Error(), i, ": ", d.src)
+		} else {
+			tt.Nil(t, err, i, ": ", d.src)
+		}
+		r := strings.NewReader(strings.Repeat(" ", 4093) + d.src)
+		if err = v.ValidateReader(r); 0 < len(d.expect) {
+			tt.NotNil(t, err, d.src)
+		} else {
+			tt.Nil(t, err, d.src)
+		}
+	}
+}
+
+func TestValidatorDupKeysCallback(t *testing.T) {
+	var dups []string
+	v := oj.Validator{
+		OnDupKey: func(key string, line, column int) {
+			dups = append(dups, fmt.Sprintf("%s@%d:%d", key, line, column))
+		},
+	}
+	err := v.Validate([]byte("{\"a\":1,\"a\":2,\n\"b\":{\"c\":3,\"c\":4},\"a\":5}"))
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"a@1:8", "c@2:12", "a@2:19"}, dups)
+
+	dups = dups[:0]
+	src := strings.Repeat(" ", 4090) + `{"abc":1,"abc":2}`
+	err = v.ValidateReader(strings.NewReader(src))
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"abc@1:4100"}, dups)
+}
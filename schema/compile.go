@@ -0,0 +1,594 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package schema
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ohler55/ojg"
+)
+
+// Draft identifies a JSON Schema specification version.
+type Draft int
+
+const (
+	// Draft2020 is JSON Schema draft 2020-12.
+	Draft2020 Draft = iota
+	// Draft7 is JSON Schema draft-07.
+	Draft7
+)
+
+// Compiler compiles JSON Schema documents into a Schema. The zero value is
+// ready to use.
+type Compiler struct {
+	// Draft is the draft used for schemas that do not have a $schema
+	// keyword.
+	Draft Draft
+
+	// AssertFormat if true validates the format keyword instead of
+	// treating it as an annotation only.
+	AssertFormat bool
+
+	// Formats adds to or replaces the format checks used when AssertFormat
+	// is true.
+	Formats map[string]func(s string) bool
+
+	// Loader is called to load schema documents for $ref URIs that have
+	// not been added with AddResource(). The returned document is compiled
+	// the same way as the document passed to Compile().
+	Loader func(uri string) (any, error)
+
+	docs    map[string]any
+	nodes   map[string]*node
+	pending []*ref
+}
+
+// Schema is a compiled JSON Schema that can be used to validate data. A
+// Schema is safe for concurrent use.
+type Schema struct {
+	root         *node
+	assertFormat bool
+	formats      map[string]func(s string) bool
+}
+
+type ref struct {
+	key    string
+	uri    string
+	target *node
+}
+
+type patternNode struct {
+	src string
+	rx  *regexp.Regexp
+	n   *node
+}
+
+type keyNode struct {
+	key string
+	n   *node
+}
+
+type keyList struct {
+	key  string
+	list []string
+}
+
+type node struct {
+	isBool bool
+	allow  bool
+	draft  Draft
+
+	refs []*ref
+
+	types    []string
+	enum     []any
+	hasEnum  bool
+	constant any
+	hasConst bool
+
+	multipleOf       *float64
+	maximum          *float64
+	exclusiveMaximum *float64
+	minimum          *float64
+	exclusiveMinimum *float64
+
+	maxLength *int
+	minLength *int
+	pattern   *regexp.Regexp
+	format    string
+
+	tupleKey    string
+	prefixItems []*node
+	itemsKey    string
+	items       *node
+	contains    *node
+	maxContains *int
+	minContains *int
+	maxItems    *int
+	minItems    *int
+	uniqueItems bool
+
+	props        []*keyNode
+	patternProps []*patternNode
+	addProps     *node
+	propNames    *node
+	maxProps     *int
+	minProps     *int
+	required     []string
+	depReqKey    string
+	depRequired  []*keyList
+	depSchemaKey string
+	depSchemas   []*keyNode
+
+	allOf  []*node
+	anyOf  []*node
+	oneOf  []*node
+	not    *node
+	ifNode *node
+	then   *node
+	orElse *node
+
+	unevalProps *node
+	unevalItems *node
+}
+
+// Compile a schema document using a Compiler with default settings.
+func Compile(doc any) (*Schema, error) {
+	var c Compiler
+	return c.Compile(doc)
+}
+
+// MustCompile is the same as Compile except it panics on an error.
+func MustCompile(doc any) *Schema {
+	s, err := Compile(doc)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// AddResource makes a schema document available to references to the
+// uri. The document is compiled when it is first referenced.
+func (c *Compiler) AddResource(uri string, doc any) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+	u.Fragment = ""
+	c.init()
+	c.docs[u.String()] = normalize(doc)
+
+	return nil
+}
+
+// Compile a schema document. The document should be a map[string]any, a
+// bool, or a gen.Node equivalent.
+func (c *Compiler) Compile(doc any) (s *Schema, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s = nil
+			err = ojg.NewError(r)
+		}
+	}()
+	c.init()
+	// Nodes from a previous document without an $id are not reusable.
+	for k := range c.nodes {
+		if strings.HasPrefix(k, "#") {
+			delete(c.nodes, k)
+		}
+	}
+	doc = normalize(doc)
+	c.docs[""] = doc
+	root := c.compile(doc, "", "", c.Draft)
+	c.resolve()
+	checkCycles(root, "", map[*node]int{})
+
+	s = &Schema{root: root, assertFormat: c.AssertFormat, formats: formats}
+	if 0 < len(c.Formats) {
+		s.formats = make(map[string]func(s string) bool, len(formats)+len(c.Formats))
+		for k, f := range formats {
+			s.formats[k] = f
+		}
+		for k, f := range c.Formats {
+			s.formats[k] = f
+		}
+	}
+	return
+}
+
+// MustCompile is the same as Compile except it panics on an error.
+func (c *Compiler) MustCompile(doc any) *Schema {
+	s, err := c.Compile(doc)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (c *Compiler) init() {
+	if c.docs == nil {
+		c.docs = map[string]any{}
+		c.nodes = map[string]*node{}
+	}
+}
+
+func (c *Compiler) compile(v any, base, ptr string, draft Draft) *node {
+	var obj map[string]any
+	switch tv := v.(type) {
+	case bool:
+		return &node{isBool: true, allow: tv}
+	case map[string]any:
+		obj = tv
+	default:
+		panic(fmt.Sprintf("schema at %s#%s must be an object or boolean, not a %T", base, ptr, v))
+	}
+	if s, ok := obj["$schema"].(string); ok {
+		switch strings.TrimSuffix(s, "#") {
+		case "http://json-schema.org/draft-07/schema", "https://json-schema.org/draft-07/schema":
+			draft = Draft7
+		case "https://json-schema.org/draft/2020-12/schema":
+			draft = Draft2020
+		}
+	}
+	n := &node{draft: draft}
+	if id, ok := obj["$id"].(string); ok {
+		if draft == Draft7 && strings.HasPrefix(id, "#") {
+			c.nodes[base+id] = n
+		} else {
+			u := resolveURI(base, id)
+			u.Fragment = ""
+			base = u.String()
+			ptr = ""
+			if _, has := c.docs[base]; !has {
+				c.docs[base] = obj
+			}
+		}
+	}
+	c.nodes[base+"#"+ptr] = n
+	for _, key := range []string{"$anchor", "$dynamicAnchor"} {
+		if a, ok := obj[key].(string); ok {
+			c.nodes[base+"#"+a] = n
+		}
+	}
+	for _, key := range []string{"$ref", "$dynamicRef"} {
+		if s, ok := obj[key].(string); ok {
+			r := ref{key: key, uri: resolveURI(base, s).String()}
+			n.refs = append(n.refs, &r)
+			c.pending = append(c.pending, &r)
+		}
+	}
+	sub := func(key string) *node {
+		if x, has := obj[key]; has {
+			return c.compile(x, base, ptr+"/"+escapePointer(key), draft)
+		}
+		return nil
+	}
+	subList := func(key string) (list []*node) {
+		if x, has := obj[key]; has {
+			a, ok := x.([]any)
+			if !ok {
+				panic(fmt.Sprintf("%s at %s#%s must be an array", key, base, ptr))
+			}
+			for i, s := range a {
+				list = append(list, c.compile(s, base, fmt.Sprintf("%s/%s/%d", ptr, key, i), draft))
+			}
+		}
+		return
+	}
+	subMap := func(key string) (list []*keyNode) {
+		if x, has := obj[key]; has {
+			m := asObject(x, key, base, ptr)
+			for _, k := range sortedKeys(m) {
+				kp := ptr + "/" + escapePointer(key) + "/" + escapePointer(k)
+				list = append(list, &keyNode{key: k, n: c.compile(m[k], base, kp, draft)})
+			}
+		}
+		return
+	}
+	subMap("$defs")
+	subMap("definitions")
+
+	if len(n.refs) == 0 || draft != Draft7 {
+		c.compileAssertions(n, obj, base, ptr)
+
+		n.tupleKey = "prefixItems"
+		n.itemsKey = "items"
+		if draft == Draft7 {
+			if _, ok := obj["items"].([]any); ok {
+				n.tupleKey = "items"
+				n.itemsKey = "additionalItems"
+			}
+		}
+		n.prefixItems = subList(n.tupleKey)
+		n.items = sub(n.itemsKey)
+		n.contains = sub("contains")
+
+		n.props = subMap("properties")
+		for _, kn := range subMap("patternProperties") {
+			rx, err := regexp.Compile(kn.key)
+			if err != nil {
+				panic(fmt.Sprintf("invalid patternProperties %q at %s#%s", kn.key, base, ptr))
+			}
+			n.patternProps = append(n.patternProps, &patternNode{src: kn.key, rx: rx, n: kn.n})
+		}
+		n.addProps = sub("additionalProperties")
+		n.propNames = sub("propertyNames")
+		n.depSchemaKey = "dependentSchemas"
+		n.depReqKey = "dependentRequired"
+		if draft == Draft7 {
+			c.compileDependencies(n, obj, base, ptr)
+		} else {
+			n.depSchemas = subMap("dependentSchemas")
+			if x, has := obj["dependentRequired"]; has {
+				m := asObject(x, "dependentRequired", base, ptr)
+				for _, k := range sortedKeys(m) {
+					n.depRequired = append(n.depRequired, &keyList{key: k, list: asStrings(m[k], "dependentRequired", base, ptr)})
+				}
+			}
+		}
+		n.allOf = subList("allOf")
+		n.anyOf = subList("anyOf")
+		n.oneOf = subList("oneOf")
+		n.not = sub("not")
+		n.ifNode = sub("if")
+		n.then = sub("then")
+		n.orElse = sub("else")
+		if draft != Draft7 {
+			n.unevalProps = sub("unevaluatedProperties")
+			n.unevalItems = sub("unevaluatedItems")
+		}
+	}
+	return n
+}
+
+func (c *Compiler) compileAssertions(n *node, obj map[string]any, base, ptr string) {
+	switch tv := obj["type"].(type) {
+	case nil:
+	case string:
+		n.types = []string{tv}
+	default:
+		n.types = asStrings(tv, "type", base, ptr)
+	}
+	if x, has := obj["enum"]; has {
+		a, ok := x.([]any)
+		if !ok {
+			panic(fmt.Sprintf("enum at %s#%s must be an array", base, ptr))
+		}
+		n.enum = a
+		n.hasEnum = true
+	}
+	n.constant, n.hasConst = obj["const"]
+
+	n.multipleOf = asNumberLimit(obj, "multipleOf", base, ptr)
+	n.maximum = asNumberLimit(obj, "maximum", base, ptr)
+	n.exclusiveMaximum = asNumberLimit(obj, "exclusiveMaximum", base, ptr)
+	n.minimum = asNumberLimit(obj, "minimum", base, ptr)
+	n.exclusiveMinimum = asNumberLimit(obj, "exclusiveMinimum", base, ptr)
+	if n.multipleOf != nil && *n.multipleOf <= 0.0 {
+		panic(fmt.Sprintf("multipleOf at %s#%s must be greater than zero", base, ptr))
+	}
+	n.maxLength = asCountLimit(obj, "maxLength", base, ptr)
+	n.minLength = asCountLimit(obj, "minLength", base, ptr)
+	if s, ok := obj["pattern"].(string); ok {
+		var err error
+		if n.pattern, err = regexp.Compile(s); err != nil {
+			panic(fmt.Sprintf("invalid pattern %q at %s#%s", s, base, ptr))
+		}
+	}
+	n.format, _ = obj["format"].(string)
+
+	n.maxContains = asCountLimit(obj, "maxContains", base, ptr)
+	n.minContains = asCountLimit(obj, "minContains", base, ptr)
+	n.maxItems = asCountLimit(obj, "maxItems", base, ptr)
+	n.minItems = asCountLimit(obj, "minItems", base, ptr)
+	n.uniqueItems, _ = obj["uniqueItems"].(bool)
+
+	n.maxProps = asCountLimit(obj, "maxProperties", base, ptr)
+	n.minProps = asCountLimit(obj, "minProperties", base, ptr)
+	if x, has := obj["required"]; has {
+		n.required = asStrings(x, "required", base, ptr)
+	}
+}
+
+func (c *Compiler) compileDependencies(n *node, obj map[string]any, base, ptr string) {
+	x, has := obj["dependencies"]
+	if !has {
+		return
+	}
+	n.depSchemaKey = "dependencies"
+	n.depReqKey = "dependencies"
+	m := asObject(x, "dependencies", base, ptr)
+	for _, k := range sortedKeys(m) {
+		if list, ok := m[k].([]any); ok {
+			n.depRequired = append(n.depRequired, &keyList{key: k, list: asStrings(list, "dependencies", base, ptr)})
+		} else {
+			kp := ptr + "/dependencies/" + escapePointer(k)
+			n.depSchemas = append(n.depSchemas, &keyNode{key: k, n: c.compile(m[k], base, kp, n.draft)})
+		}
+	}
+}
+
+// resolve all the pending references. Resolving may compile additional
+// resources which can add more pending references.
+func (c *Compiler) resolve() {
+	for 0 < len(c.pending) {
+		r := c.pending[len(c.pending)-1]
+		c.pending = c.pending[:len(c.pending)-1]
+		r.target = c.lookup(r.uri)
+	}
+}
+
+// checkCycles panics if a schema applies itself to the same instance
+// location through references since validating would never end. The via
+// argument is the last reference followed to reach n. The state of each
+// node is 1 while its in place subschemas are being checked and 2 when done.
+func checkCycles(n *node, via string, state map[*node]int) {
+	switch state[n] {
+	case 1:
+		if !strings.Contains(via, "#") {
+			via += "#"
+		}
+		panic(fmt.Sprintf("reference cycle through %s", via))
+	case 2:
+		return
+	}
+	state[n] = 1
+	for _, r := range n.refs {
+		checkCycles(r.target, r.uri, state)
+	}
+	if n.draft != Draft7 || len(n.refs) == 0 {
+		for _, list := range [][]*node{n.allOf, n.anyOf, n.oneOf} {
+			for _, sn := range list {
+				checkCycles(sn, via, state)
+			}
+		}
+		for _, sn := range []*node{n.not, n.ifNode, n.then, n.orElse} {
+			if sn != nil {
+				checkCycles(sn, via, state)
+			}
+		}
+		for _, kn := range n.depSchemas {
+			checkCycles(kn.n, via, state)
+		}
+	}
+	state[n] = 2
+}
+
+func (c *Compiler) lookup(uri string) *node {
+	u, err := url.Parse(uri)
+	if err != nil {
+		panic(err)
+	}
+	frag := u.Fragment
+	u.Fragment = ""
+	res := u.String()
+	key := res + "#" + frag
+	if n := c.nodes[key]; n != nil {
+		return n
+	}
+	if c.nodes[res+"#"] == nil {
+		doc, has := c.docs[res]
+		if !has {
+			if c.Loader == nil {
+				panic(fmt.Sprintf("can not resolve %s", uri))
+			}
+			if doc, err = c.Loader(res); err != nil {
+				panic(err)
+			}
+			doc = normalize(doc)
+			c.docs[res] = doc
+		}
+		c.nodes[res+"#"] = c.compile(doc, res, "", c.Draft)
+		if n := c.nodes[key]; n != nil {
+			return n
+		}
+	}
+	// The fragment may point to a location that is not one of the
+	// subschema keywords so look for it directly in the document.
+	if strings.HasPrefix(frag, "/") {
+		v := c.docs[res]
+		for _, token := range strings.Split(frag[1:], "/") {
+			token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+			switch tv := v.(type) {
+			case map[string]any:
+				v = tv[token]
+			case []any:
+				var i int
+				if _, err := fmt.Sscanf(token, "%d", &i); err != nil || i < 0 || len(tv) <= i {
+					v = nil
+				} else {
+					v = tv[i]
+				}
+			default:
+				v = nil
+			}
+		}
+		if v != nil {
+			n := c.compile(v, res, frag, c.Draft)
+			c.nodes[key] = n
+			return n
+		}
+	}
+	panic(fmt.Sprintf("can not resolve %s", uri))
+}
+
+func resolveURI(base, s string) *url.URL {
+	u, err := url.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	if len(base) == 0 {
+		return u
+	}
+	b, err := url.Parse(base)
+	if err != nil {
+		panic(err)
+	}
+	return b.ResolveReference(u)
+}
+
+func escapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func asObject(v any, key, base, ptr string) map[string]any {
+	m, ok := v.(map[string]any)
+	if !ok {
+		panic(fmt.Sprintf("%s at %s#%s must be an object", key, base, ptr))
+	}
+	return m
+}
+
+func asStrings(v any, key, base, ptr string) []string {
+	a, ok := v.([]any)
+	if !ok {
+		panic(fmt.Sprintf("%s at %s#%s must be an array of strings", key, base, ptr))
+	}
+	list := make([]string, len(a))
+	for i, x := range a {
+		s, ok := x.(string)
+		if !ok {
+			panic(fmt.Sprintf("%s at %s#%s must be an array of strings", key, base, ptr))
+		}
+		list[i] = s
+	}
+	return list
+}
+
+func asNumberLimit(obj map[string]any, key, base, ptr string) *float64 {
+	x, has := obj[key]
+	if !has {
+		return nil
+	}
+	f, ok := asNumber(x)
+	if !ok {
+		panic(fmt.Sprintf("%s at %s#%s must be a number", key, base, ptr))
+	}
+	return &f
+}
+
+func asCountLimit(obj map[string]any, key, base, ptr string) *int {
+	x, has := obj[key]
+	if !has {
+		return nil
+	}
+	f, ok := asNumber(x)
+	if !ok || f < 0 || f != float64(int(f)) {
+		panic(fmt.Sprintf("%s at %s#%s must be a non-negative integer", key, base, ptr))
+	}
+	i := int(f)
+	return &i
+}
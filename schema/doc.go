@@ -0,0 +1,26 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+/*
+Package schema compiles and validates JSON Schema documents. Both draft
+2020-12 and draft-07 schemas are supported. Schemas are expected to be
+parsed with the oj or sen packages or to be gen.Node trees.
+
+	s, err := schema.Compile(sen.MustParse([]byte(`{type: object required: [name]}`)))
+	if err == nil {
+		err = s.Validate(map[string]any{"age": 3})
+	}
+
+Data to be validated can be simple data as returned by the oj parser,
+gen.Node trees, or Go types such as structs which are decomposed with
+alt.Decompose() before validation.
+
+Validation errors are returned as a *ValidationError which contains an
+*Error for each failure. Each *Error identifies the location of the invalid
+value in the data and the path to the schema keyword that failed as
+jp.Expr values.
+
+Local references are resolved against the schema document while references
+to other documents are resolved against resources added with
+AddResource() or returned by the Compiler Loader function.
+*/
+package schema
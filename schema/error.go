@@ -0,0 +1,48 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ohler55/ojg/jp"
+)
+
+// Error describes a single validation failure.
+type Error struct {
+	// Location of the invalid value in the validated data.
+	Location jp.Expr
+
+	// Keyword is the path to the schema keyword that failed. References
+	// that are followed appear as $ref elements in the path.
+	Keyword jp.Expr
+
+	// Message describes the failure.
+	Message string
+}
+
+// Error returns a string representation of the error.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s %s (%s)", e.Location, e.Message, e.Keyword)
+}
+
+// ValidationError is returned from Validate() when the data does not
+// conform to the schema.
+type ValidationError struct {
+	// Errors are the individual failures in the order they were detected.
+	Errors []*Error
+}
+
+// Error returns a string representation of the error with one line for
+// each failure.
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	for i, err := range e.Errors {
+		if 0 < i {
+			b.WriteByte('\n')
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package schema
+
+import (
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ohler55/ojg"
+)
+
+var (
+	durationRx = regexp.MustCompile(`^P(\d+W|(\d+Y)?(\d+M)?(\d+D)?(T(\d+H)?(\d+M)?(\d+S)?)?)$`)
+	uuidRx     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+	formats = map[string]func(s string) bool{
+		"date-time":     isDateTime,
+		"date":          isDate,
+		"time":          isTime,
+		"duration":      isDuration,
+		"email":         isEmail,
+		"hostname":      isHostname,
+		"ipv4":          isIPv4,
+		"ipv6":          isIPv6,
+		"uri":           isURI,
+		"uri-reference": isURIReference,
+		"uuid":          uuidRx.MatchString,
+		"regex":         isRegex,
+		"json-pointer":  isJSONPointer,
+	}
+)
+
+func isDateTime(s string) bool {
+	if len(s) <= 10 {
+		return false
+	}
+	_, ok := ojg.TimeRFC3339Converter.Convert(strings.ToUpper(s)).(time.Time)
+	return ok
+}
+
+func isDate(s string) bool {
+	if len(s) != 10 {
+		return false
+	}
+	_, ok := ojg.TimeRFC3339Converter.Convert(s).(time.Time)
+	return ok
+}
+
+func isTime(s string) bool {
+	s = strings.ToUpper(s)
+	for _, layout := range []string{"15:04:05Z07:00", "15:04:05.999999999Z07:00"} {
+		if _, err := time.Parse(layout, s); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func isDuration(s string) bool {
+	return durationRx.MatchString(s) && s != "P" && !strings.HasSuffix(s, "T")
+}
+
+func isEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if len(s) == 0 || 253 < len(s) {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if len(label) == 0 || 63 < len(label) || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, b := range []byte(label) {
+			if !('a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9' || b == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isIPv4(s string) bool {
+	return !strings.Contains(s, ":") && net.ParseIP(s) != nil
+}
+
+func isIPv6(s string) bool {
+	return strings.Contains(s, ":") && net.ParseIP(s) != nil
+}
+
+func isURI(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.IsAbs()
+}
+
+func isURIReference(s string) bool {
+	_, err := url.Parse(s)
+	return err == nil
+}
+
+func isRegex(s string) bool {
+	_, err := regexp.Compile(s)
+	return err == nil
+}
+
+func isJSONPointer(s string) bool {
+	if len(s) == 0 {
+		return true
+	}
+	if s[0] != '/' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] == '~' && (len(s) <= i+1 || (s[i+1] != '0' && s[i+1] != '1')) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,335 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package schema_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ohler55/ojg/gen"
+	"github.com/ohler55/ojg/schema"
+	"github.com/ohler55/ojg/sen"
+	"github.com/ohler55/ojg/tt"
+)
+
+type validCase struct {
+	schema string
+	data   string
+	valid  bool
+}
+
+func checkCases(t *testing.T, c *schema.Compiler, cases []validCase) {
+	t.Helper()
+	for i, d := range cases {
+		s, err := c.Compile(sen.MustParse([]byte(d.schema)))
+		tt.Nil(t, err, "%d: %s", i, d.schema)
+		err = s.Validate(sen.MustParse([]byte(d.data)))
+		tt.Equal(t, d.valid, err == nil, "%d: %s with %s - %v", i, d.schema, d.data, err)
+	}
+}
+
+func TestValidateAssertions(t *testing.T) {
+	checkCases(t, &schema.Compiler{}, []validCase{
+		{schema: `true`, data: `1`, valid: true},
+		{schema: `false`, data: `1`, valid: false},
+		{schema: `{type: integer}`, data: `3`, valid: true},
+		{schema: `{type: integer}`, data: `3.0`, valid: true},
+		{schema: `{type: integer}`, data: `3.5`, valid: false},
+		{schema: `{type: [string "null"]}`, data: `null`, valid: true},
+		{schema: `{type: [string "null"]}`, data: `true`, valid: false},
+		{schema: `{enum: [1 "a" {b: [1]}]}`, data: `{b: [1.0]}`, valid: true},
+		{schema: `{enum: [1 "a"]}`, data: `true`, valid: false},
+		{schema: `{const: null}`, data: `null`, valid: true},
+		{schema: `{const: false}`, data: `0`, valid: false},
+		{schema: `{multipleOf: 0.01}`, data: `0.07`, valid: true},
+		{schema: `{multipleOf: 3}`, data: `10`, valid: false},
+		{schema: `{maximum: 3 exclusiveMinimum: 1}`, data: `3`, valid: true},
+		{schema: `{maximum: 3 exclusiveMinimum: 1}`, data: `1`, valid: false},
+		{schema: `{exclusiveMaximum: 3 minimum: 1}`, data: `3`, valid: false},
+		{schema: `{minLength: 2 maxLength: 3}`, data: `"日本語"`, valid: true},
+		{schema: `{minLength: 2 maxLength: 3}`, data: `"abcd"`, valid: false},
+		{schema: `{pattern: "^a+$"}`, data: `"aaa"`, valid: true},
+		{schema: `{pattern: "^a+$"}`, data: `"ab"`, valid: false},
+		{schema: `{minItems: 1 maxItems: 2 uniqueItems: true}`, data: `[1 2]`, valid: true},
+		{schema: `{uniqueItems: true}`, data: `[1 {a: 2} {a: 2.0}]`, valid: false},
+		{schema: `{maxItems: 2}`, data: `[1 2 3]`, valid: false},
+		{schema: `{required: [a b] minProperties: 2 maxProperties: 3}`, data: `{a: 1 b: 2}`, valid: true},
+		{schema: `{required: [a b]}`, data: `{a: 1}`, valid: false},
+		{schema: `{dependentRequired: {a: [b]}}`, data: `{a: 1}`, valid: false},
+		{schema: `{dependentRequired: {a: [b]}}`, data: `{b: 1}`, valid: true},
+	})
+}
+
+func TestValidateApplicators(t *testing.T) {
+	checkCases(t, &schema.Compiler{}, []validCase{
+		{schema: `{prefixItems: [{type: string}] items: {type: integer}}`, data: `[a 1 2]`, valid: true},
+		{schema: `{prefixItems: [{type: string}] items: {type: integer}}`, data: `[a b]`, valid: false},
+		{schema: `{prefixItems: [{type: string}] items: false}`, data: `[a]`, valid: true},
+		{schema: `{contains: {const: 3}}`, data: `[1 3]`, valid: true},
+		{schema: `{contains: {const: 3}}`, data: `[1 2]`, valid: false},
+		{schema: `{contains: {const: 3} minContains: 2 maxContains: 3}`, data: `[3 1 3]`, valid: true},
+		{schema: `{contains: {const: 3} minContains: 2}`, data: `[3 1]`, valid: false},
+		{schema: `{contains: {const: 3} maxContains: 1}`, data: `[3 3]`, valid: false},
+		{schema: `{properties: {a: {type: string}} additionalProperties: false}`, data: `{a: x}`, valid: true},
+		{schema: `{properties: {a: {type: string}} additionalProperties: false}`, data: `{a: x b: 1}`, valid: false},
+		{schema: `{patternProperties: {"^x": {type: integer}} additionalProperties: false}`, data: `{x1: 1 x2: 2}`, valid: true},
+		{schema: `{patternProperties: {"^x": {type: integer}}}`, data: `{x1: y}`, valid: false},
+		{schema: `{propertyNames: {maxLength: 2}}`, data: `{abc: 1}`, valid: false},
+		{schema: `{dependentSchemas: {a: {required: [b]}}}`, data: `{a: 1}`, valid: false},
+		{schema: `{allOf: [{type: integer} {minimum: 2}]}`, data: `1`, valid: false},
+		{schema: `{anyOf: [{type: integer} {type: string}]}`, data: `x`, valid: true},
+		{schema: `{anyOf: [{type: integer} {type: string}]}`, data: `true`, valid: false},
+		{schema: `{oneOf: [{type: integer} {minimum: 2}]}`, data: `1`, valid: true},
+		{schema: `{oneOf: [{type: integer} {minimum: 2}]}`, data: `3`, valid: false},
+		{schema: `{not: {type: integer}}`, data: `3`, valid: false},
+		{schema: `{if: {type: integer} then: {minimum: 2} else: {type: string}}`, data: `3`, valid: true},
+		{schema: `{if: {type: integer} then: {minimum: 2} else: {type: string}}`, data: `1`, valid: false},
+		{schema: `{if: {type: integer} then: {minimum: 2} else: {type: string}}`, data: `true`, valid: false},
+		{
+			schema: `{properties: {a: true} allOf: [{properties: {b: true}}] unevaluatedProperties: false}`,
+			data:   `{a: 1 b: 2}`,
+			valid:  true,
+		},
+		{
+			schema: `{properties: {a: true} anyOf: [{properties: {b: true} required: [b]} {properties: {c: true} required: [c]}] unevaluatedProperties: false}`,
+			data:   `{a: 1 c: 2 b: 3}`,
+			valid:  true,
+		},
+		{
+			schema: `{properties: {a: true} anyOf: [{properties: {b: true} required: [b]} {properties: {c: true} required: [c]}] unevaluatedProperties: false}`,
+			data:   `{a: 1 d: 2 b: 3}`,
+			valid:  false,
+		},
+		{schema: `{prefixItems: [true] unevaluatedItems: false}`, data: `[1]`, valid: true},
+		{schema: `{prefixItems: [true] unevaluatedItems: false}`, data: `[1 2]`, valid: false},
+		{schema: `{contains: {type: string} unevaluatedItems: {type: integer}}`, data: `[a 1]`, valid: true},
+		{schema: `{contains: {type: string} unevaluatedItems: {type: integer}}`, data: `[a true]`, valid: false},
+	})
+}
+
+func TestValidateRef(t *testing.T) {
+	checkCases(t, &schema.Compiler{}, []validCase{
+		{schema: `{$defs: {pos: {minimum: 0}} items: {$ref: "#/$defs/pos"}}`, data: `[1 2]`, valid: true},
+		{schema: `{$defs: {pos: {minimum: 0}} items: {$ref: "#/$defs/pos"}}`, data: `[1 -2]`, valid: false},
+		{schema: `{$defs: {pos: {$anchor: pos minimum: 0}} $ref: "#pos"}`, data: `-1`, valid: false},
+		{schema: `{$defs: {"a/b": {type: string}} $ref: "#/$defs/a~1b"}`, data: `1`, valid: false},
+		{schema: `{$defs: {a: {$ref: "#/$defs/b"} b: {type: integer}} $ref: "#/$defs/a"}`, data: `x`, valid: false},
+		{schema: `{$ref: "#/$defs/pos" maximum: 3 $defs: {pos: {minimum: 0}}}`, data: `4`, valid: false},
+		{schema: `{x: {y: {type: string}} $ref: "#/x/y"}`, data: `1`, valid: false},
+		{
+			schema: `{properties: {name: {type: string} kids: {type: array items: {$ref: "#"}}}}`,
+			data:   `{name: a kids: [{name: b kids: []} {name: 3}]}`,
+			valid:  false,
+		},
+		{
+			schema: `{$id: "http://example.com/root.json" items: {$ref: "item.json"} $defs: {item: {$id: "item.json" type: integer}}}`,
+			data:   `[1 2]`,
+			valid:  true,
+		},
+		{
+			schema: `{$id: "http://example.com/root.json" items: {$ref: "item.json"} $defs: {item: {$id: "item.json" type: integer}}}`,
+			data:   `[1 x]`,
+			valid:  false,
+		},
+	})
+}
+
+func TestValidateDraft7(t *testing.T) {
+	checkCases(t, &schema.Compiler{Draft: schema.Draft7}, []validCase{
+		{schema: `{items: [{type: string}] additionalItems: {type: integer}}`, data: `[a 1]`, valid: true},
+		{schema: `{items: [{type: string}] additionalItems: {type: integer}}`, data: `[a b]`, valid: false},
+		{schema: `{items: {type: string}}`, data: `[a b]`, valid: true},
+		{schema: `{dependencies: {a: [b] c: {required: [d]}}}`, data: `{a: 1 b: 2}`, valid: true},
+		{schema: `{dependencies: {a: [b] c: {required: [d]}}}`, data: `{a: 1}`, valid: false},
+		{schema: `{dependencies: {a: [b] c: {required: [d]}}}`, data: `{c: 1}`, valid: false},
+		// Keywords beside $ref are ignored in draft-07.
+		{schema: `{definitions: {a: {type: integer}} $ref: "#/definitions/a" maximum: 3}`, data: `4`, valid: true},
+		{schema: `{definitions: {a: {$id: "#foo" type: integer}} $ref: "#foo"}`, data: `x`, valid: false},
+	})
+	checkCases(t, &schema.Compiler{}, []validCase{
+		{
+			schema: `{$schema: "http://json-schema.org/draft-07/schema#" items: [{type: string}] additionalItems: false}`,
+			data:   `[a 1]`,
+			valid:  false,
+		},
+	})
+}
+
+func TestValidateFormat(t *testing.T) {
+	var cases []validCase
+	for _, d := range []struct {
+		format string
+		value  string
+		valid  bool
+	}{
+		{format: "date-time", value: "2026-01-02T03:04:05Z", valid: true},
+		{format: "date-time", value: "2026-01-02t03:04:05.123+01:00", valid: true},
+		{format: "date-time", value: "2026-01-02", valid: false},
+		{format: "date", value: "2026-01-02", valid: true},
+		{format: "date", value: "2026-13-02", valid: false},
+		{format: "time", value: "03:04:05Z", valid: true},
+		{format: "time", value: "03:04", valid: false},
+		{format: "duration", value: "P1DT2H", valid: true},
+		{format: "duration", value: "P1DT", valid: false},
+		{format: "email", value: "joe@example.com", valid: true},
+		{format: "email", value: "joe", valid: false},
+		{format: "hostname", value: "www.example.com", valid: true},
+		{format: "hostname", value: "-bad.com", valid: false},
+		{format: "ipv4", value: "192.168.1.2", valid: true},
+		{format: "ipv4", value: "::1", valid: false},
+		{format: "ipv6", value: "::1", valid: true},
+		{format: "ipv6", value: "1.2.3.4", valid: false},
+		{format: "uri", value: "http://example.com/x", valid: true},
+		{format: "uri", value: "x/y", valid: false},
+		{format: "uri-reference", value: "x/y", valid: true},
+		{format: "uuid", value: "123e4567-e89b-12d3-a456-426614174000", valid: true},
+		{format: "uuid", value: "123e4567", valid: false},
+		{format: "regex", value: "^a+$", valid: true},
+		{format: "regex", value: "(", valid: false},
+		{format: "json-pointer", value: "/a/~0b", valid: true},
+		{format: "json-pointer", value: "/a~2", valid: false},
+		{format: "unknown", value: "anything", valid: true},
+	} {
+		cases = append(cases, validCase{
+			schema: fmt.Sprintf(`{format: %q}`, d.format),
+			data:   fmt.Sprintf("%q", d.value),
+			valid:  d.valid,
+		})
+	}
+	checkCases(t, &schema.Compiler{AssertFormat: true}, cases)
+
+	// Formats are annotations only by default.
+	checkCases(t, &schema.Compiler{}, []validCase{{schema: `{format: date}`, data: `"not a date"`, valid: true}})
+
+	c := schema.Compiler{
+		AssertFormat: true,
+		Formats:      map[string]func(s string) bool{"upper": func(s string) bool { return strings.ToUpper(s) == s }},
+	}
+	checkCases(t, &c, []validCase{
+		{schema: `{format: upper}`, data: `"ABC"`, valid: true},
+		{schema: `{format: upper}`, data: `"abc"`, valid: false},
+	})
+}
+
+func TestValidateErrors(t *testing.T) {
+	s := schema.MustCompile(sen.MustParse([]byte(`{
+  $defs: {name: {type: string minLength: 1}}
+  type: object
+  required: [name]
+  properties: {
+    name: {$ref: "#/$defs/name"}
+    tags: {type: array items: {$ref: "#/$defs/name"}}
+  }
+}`)))
+	err := s.Validate(sen.MustParse([]byte(`{tags: [a "" 3]}`)))
+	tt.NotNil(t, err)
+	ve, ok := err.(*schema.ValidationError)
+	tt.Equal(t, true, ok)
+	tt.Equal(t, 3, len(ve.Errors))
+
+	tt.Equal(t, "$", ve.Errors[0].Location.String())
+	tt.Equal(t, "$.required", ve.Errors[0].Keyword.String())
+	tt.Equal(t, `is missing required property "name"`, ve.Errors[0].Message)
+
+	tt.Equal(t, "$.tags[1]", ve.Errors[1].Location.String())
+	tt.Equal(t, "$.properties.tags.items['$ref'].minLength", ve.Errors[1].Keyword.String())
+
+	tt.Equal(t, "$.tags[2]", ve.Errors[2].Location.String())
+	tt.Equal(t, "$.properties.tags.items['$ref'].type", ve.Errors[2].Keyword.String())
+	tt.Equal(t, "$.tags[2] must be of type string ($.properties.tags.items['$ref'].type)", ve.Errors[2].Error())
+	tt.Equal(t, 3, len(strings.Split(err.Error(), "\n")))
+
+	tt.Nil(t, s.Validate(map[string]any{"name": "x", "tags": []any{"y"}}))
+}
+
+func TestValidateGenAndStruct(t *testing.T) {
+	s := schema.MustCompile(gen.Object{
+		"type":     gen.String("object"),
+		"required": gen.Array{gen.String("name"), gen.String("age")},
+		"properties": gen.Object{
+			"name": gen.Object{"type": gen.String("string")},
+			"age":  gen.Object{"type": gen.String("integer"), "minimum": gen.Int(0)},
+		},
+	})
+	tt.Nil(t, s.Validate(gen.Object{"name": gen.String("Fred"), "age": gen.Int(7)}))
+	tt.NotNil(t, s.Validate(gen.Object{"name": gen.String("Fred"), "age": gen.Float(7.5)}))
+
+	type person struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	tt.Nil(t, s.Validate(&person{Name: "Fred", Age: 7}))
+	err := s.Validate([]any{&person{Name: "Fred", Age: -1}})
+	tt.NotNil(t, err)
+
+	err = s.Validate(&person{Name: "Fred", Age: -1})
+	tt.NotNil(t, err)
+	tt.Equal(t, "$.age", err.(*schema.ValidationError).Errors[0].Location.String())
+}
+
+func TestCompileResources(t *testing.T) {
+	var c schema.Compiler
+	err := c.AddResource("http://example.com/name.json", sen.MustParse([]byte(`{$defs: {short: {maxLength: 3}} type: string}`)))
+	tt.Nil(t, err)
+	loaded := []string{}
+	c.Loader = func(uri string) (any, error) {
+		loaded = append(loaded, uri)
+		if uri == "http://example.com/age.json" {
+			return map[string]any{"type": "integer"}, nil
+		}
+		return nil, fmt.Errorf("%s not found", uri)
+	}
+	s, err := c.Compile(sen.MustParse([]byte(`{
+  $id: "http://example.com/person.json"
+  properties: {
+    name: {allOf: [{$ref: "name.json"} {$ref: "name.json#/$defs/short"}]}
+    age: {$ref: "age.json"}
+  }
+}`)))
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"http://example.com/age.json"}, loaded)
+	tt.Nil(t, s.Validate(map[string]any{"name": "Bob", "age": 3}))
+	tt.NotNil(t, s.Validate(map[string]any{"name": "Robert"}))
+	tt.NotNil(t, s.Validate(map[string]any{"age": "3"}))
+
+	_, err = c.Compile(map[string]any{"$ref": "http://example.com/missing.json"})
+	tt.NotNil(t, err)
+	tt.Equal(t, true, strings.Contains(err.Error(), "missing.json not found"))
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, src := range []string{
+		`3`,
+		`{$ref: "#/$defs/missing"}`,
+		`{$ref: "other.json"}`,
+		`{pattern: "("}`,
+		`{patternProperties: {"(": true}}`,
+		`{minLength: -1}`,
+		`{multipleOf: 0}`,
+		`{maximum: x}`,
+		`{required: [1]}`,
+		`{enum: 1}`,
+		`{allOf: {}}`,
+		`{properties: []}`,
+		`{items: 3}`,
+	} {
+		_, err := schema.Compile(sen.MustParse([]byte(src)))
+		tt.NotNil(t, err, src)
+	}
+	for _, d := range []struct {
+		src    string
+		expect string
+	}{
+		{src: `{$defs: {a: {$ref: "#/$defs/a"}} $ref: "#/$defs/a"}`, expect: "reference cycle through #/$defs/a"},
+		{src: `{$defs: {a: {allOf: [{$ref: "#/$defs/b"}]} b: {not: {$ref: "#/$defs/a"}}} $ref: "#/$defs/a"}`, expect: "reference cycle through #/$defs/a"},
+		{src: `{anyOf: [{$ref: "#"}]}`, expect: "reference cycle through #"},
+		{src: `{dependentSchemas: {x: {$ref: "#"}}}`, expect: "reference cycle through #"},
+	} {
+		_, err := schema.Compile(sen.MustParse([]byte(d.src)))
+		tt.NotNil(t, err, d.src)
+		tt.Equal(t, d.expect, err.Error(), d.src)
+	}
+	tt.Panic(t, func() { schema.MustCompile(3) })
+	tt.Panic(t, func() { var c schema.Compiler; c.MustCompile(3) })
+}
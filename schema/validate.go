@@ -0,0 +1,513 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ohler55/ojg"
+	"github.com/ohler55/ojg/alt"
+	"github.com/ohler55/ojg/gen"
+	"github.com/ohler55/ojg/jp"
+)
+
+// Validate data against the schema. The data can be simple data, a
+// gen.Node, or Go values such as structs that are converted with
+// alt.Decompose(). If the data is not valid a *ValidationError is returned.
+func (s *Schema) Validate(data any) error {
+	v := validator{schema: s}
+	v.validate(s.root, normalize(data), jp.R(), jp.R())
+	if 0 < len(v.errs) {
+		return &ValidationError{Errors: v.errs}
+	}
+	return nil
+}
+
+// evaluated tracks the properties and items that have been evaluated
+// successfully for the unevaluatedProperties and unevaluatedItems keywords.
+type evaluated struct {
+	props    map[string]bool
+	items    map[int]bool
+	allProps bool
+	allItems bool
+}
+
+func (ev *evaluated) prop(key string) {
+	if ev.props == nil {
+		ev.props = map[string]bool{}
+	}
+	ev.props[key] = true
+}
+
+func (ev *evaluated) item(i int) {
+	if ev.items == nil {
+		ev.items = map[int]bool{}
+	}
+	ev.items[i] = true
+}
+
+func (ev *evaluated) merge(other *evaluated) {
+	for k := range other.props {
+		ev.prop(k)
+	}
+	for i := range other.items {
+		ev.item(i)
+	}
+	ev.allProps = ev.allProps || other.allProps
+	ev.allItems = ev.allItems || other.allItems
+}
+
+type validator struct {
+	schema *Schema
+	errs   []*Error
+}
+
+func (v *validator) fail(loc, kw jp.Expr, format string, args ...any) {
+	v.errs = append(v.errs, &Error{Location: loc, Keyword: kw, Message: fmt.Sprintf(format, args...)})
+}
+
+// check validates without recording errors and reports whether the data
+// was valid.
+func (v *validator) check(n *node, data any, loc, kw jp.Expr) (*evaluated, bool) {
+	sv := validator{schema: v.schema}
+	ev := sv.validate(n, data, loc, kw)
+
+	return ev, len(sv.errs) == 0
+}
+
+func (v *validator) validate(n *node, data any, loc, kw jp.Expr) *evaluated {
+	ev := &evaluated{}
+	if n.isBool {
+		if !n.allow {
+			v.fail(loc, kw, "is not allowed by a false schema")
+		}
+		return ev
+	}
+	for _, r := range n.refs {
+		ev.merge(v.validate(r.target, data, loc, with(kw, r.key)))
+	}
+	if n.draft == Draft7 && 0 < len(n.refs) {
+		return ev
+	}
+	if 0 < len(n.types) {
+		match := false
+		for _, t := range n.types {
+			if hasType(data, t) {
+				match = true
+				break
+			}
+		}
+		if !match {
+			v.fail(loc, with(kw, "type"), "must be of type %s", strings.Join(n.types, " or "))
+		}
+	}
+	if n.hasEnum {
+		match := false
+		for _, e := range n.enum {
+			if equal(data, e) {
+				match = true
+				break
+			}
+		}
+		if !match {
+			v.fail(loc, with(kw, "enum"), "must be one of the enum values")
+		}
+	}
+	if n.hasConst && !equal(data, n.constant) {
+		v.fail(loc, with(kw, "const"), "must be equal to the const value")
+	}
+	switch td := data.(type) {
+	case string:
+		v.validateString(n, td, loc, kw)
+	case []any:
+		v.validateArray(n, td, loc, kw, ev)
+	case map[string]any:
+		v.validateObject(n, td, loc, kw, ev)
+	default:
+		if f, ok := asNumber(data); ok {
+			v.validateNumber(n, f, loc, kw)
+		}
+	}
+	for i, sn := range n.allOf {
+		ev.merge(v.validate(sn, data, loc, with(kw, "allOf", i)))
+	}
+	if 0 < len(n.anyOf) {
+		cnt := 0
+		for i, sn := range n.anyOf {
+			if sev, ok := v.check(sn, data, loc, with(kw, "anyOf", i)); ok {
+				ev.merge(sev)
+				cnt++
+			}
+		}
+		if cnt == 0 {
+			v.fail(loc, with(kw, "anyOf"), "must match at least one schema in anyOf")
+		}
+	}
+	if 0 < len(n.oneOf) {
+		cnt := 0
+		for i, sn := range n.oneOf {
+			if sev, ok := v.check(sn, data, loc, with(kw, "oneOf", i)); ok {
+				ev.merge(sev)
+				cnt++
+			}
+		}
+		if cnt != 1 {
+			v.fail(loc, with(kw, "oneOf"), "must match exactly one schema in oneOf but matched %d", cnt)
+		}
+	}
+	if n.not != nil {
+		if _, ok := v.check(n.not, data, loc, with(kw, "not")); ok {
+			v.fail(loc, with(kw, "not"), "must not match the not schema")
+		}
+	}
+	if n.ifNode != nil {
+		if sev, ok := v.check(n.ifNode, data, loc, with(kw, "if")); ok {
+			ev.merge(sev)
+			if n.then != nil {
+				ev.merge(v.validate(n.then, data, loc, with(kw, "then")))
+			}
+		} else if n.orElse != nil {
+			ev.merge(v.validate(n.orElse, data, loc, with(kw, "else")))
+		}
+	}
+	if n.unevalProps != nil && !ev.allProps {
+		if obj, ok := data.(map[string]any); ok {
+			for _, k := range sortedKeys(obj) {
+				if !ev.props[k] {
+					v.validate(n.unevalProps, obj[k], with(loc, k), with(kw, "unevaluatedProperties"))
+				}
+			}
+			ev.allProps = true
+		}
+	}
+	if n.unevalItems != nil && !ev.allItems {
+		if list, ok := data.([]any); ok {
+			for i, item := range list {
+				if !ev.items[i] {
+					v.validate(n.unevalItems, item, with(loc, i), with(kw, "unevaluatedItems"))
+				}
+			}
+			ev.allItems = true
+		}
+	}
+	return ev
+}
+
+func (v *validator) validateNumber(n *node, f float64, loc, kw jp.Expr) {
+	if n.multipleOf != nil && !isMultiple(f, *n.multipleOf) {
+		v.fail(loc, with(kw, "multipleOf"), "must be a multiple of %v", *n.multipleOf)
+	}
+	if n.maximum != nil && *n.maximum < f {
+		v.fail(loc, with(kw, "maximum"), "must be less than or equal to %v", *n.maximum)
+	}
+	if n.exclusiveMaximum != nil && *n.exclusiveMaximum <= f {
+		v.fail(loc, with(kw, "exclusiveMaximum"), "must be less than %v", *n.exclusiveMaximum)
+	}
+	if n.minimum != nil && f < *n.minimum {
+		v.fail(loc, with(kw, "minimum"), "must be greater than or equal to %v", *n.minimum)
+	}
+	if n.exclusiveMinimum != nil && f <= *n.exclusiveMinimum {
+		v.fail(loc, with(kw, "exclusiveMinimum"), "must be greater than %v", *n.exclusiveMinimum)
+	}
+}
+
+func (v *validator) validateString(n *node, s string, loc, kw jp.Expr) {
+	if n.maxLength != nil || n.minLength != nil {
+		cnt := utf8.RuneCountInString(s)
+		if n.maxLength != nil && *n.maxLength < cnt {
+			v.fail(loc, with(kw, "maxLength"), "must be no longer than %d characters", *n.maxLength)
+		}
+		if n.minLength != nil && cnt < *n.minLength {
+			v.fail(loc, with(kw, "minLength"), "must be at least %d characters long", *n.minLength)
+		}
+	}
+	if n.pattern != nil && !n.pattern.MatchString(s) {
+		v.fail(loc, with(kw, "pattern"), "must match the pattern %q", n.pattern.String())
+	}
+	if 0 < len(n.format) && v.schema.assertFormat {
+		if f := v.schema.formats[n.format]; f != nil && !f(s) {
+			v.fail(loc, with(kw, "format"), "must be a valid %s", n.format)
+		}
+	}
+}
+
+func (v *validator) validateArray(n *node, list []any, loc, kw jp.Expr, ev *evaluated) {
+	if n.maxItems != nil && *n.maxItems < len(list) {
+		v.fail(loc, with(kw, "maxItems"), "must have no more than %d items", *n.maxItems)
+	}
+	if n.minItems != nil && len(list) < *n.minItems {
+		v.fail(loc, with(kw, "minItems"), "must have at least %d items", *n.minItems)
+	}
+	if n.uniqueItems {
+	unique:
+		for i := 1; i < len(list); i++ {
+			for j := 0; j < i; j++ {
+				if equal(list[i], list[j]) {
+					v.fail(loc, with(kw, "uniqueItems"), "must have unique items but %d and %d are equal", j, i)
+					break unique
+				}
+			}
+		}
+	}
+	for i, sn := range n.prefixItems {
+		if len(list) <= i {
+			break
+		}
+		v.validate(sn, list[i], with(loc, i), with(kw, n.tupleKey, i))
+		ev.item(i)
+	}
+	if n.items != nil {
+		for i := len(n.prefixItems); i < len(list); i++ {
+			v.validate(n.items, list[i], with(loc, i), with(kw, n.itemsKey))
+		}
+		ev.allItems = true
+	}
+	if n.contains != nil {
+		cnt := 0
+		for i, item := range list {
+			if _, ok := v.check(n.contains, item, with(loc, i), with(kw, "contains")); ok {
+				ev.item(i)
+				cnt++
+			}
+		}
+		least := 1
+		if n.minContains != nil {
+			least = *n.minContains
+		}
+		if cnt < least {
+			if n.minContains != nil {
+				v.fail(loc, with(kw, "minContains"), "must contain at least %d matching items", least)
+			} else {
+				v.fail(loc, with(kw, "contains"), "must contain a matching item")
+			}
+		}
+		if n.maxContains != nil && *n.maxContains < cnt {
+			v.fail(loc, with(kw, "maxContains"), "must contain no more than %d matching items", *n.maxContains)
+		}
+	}
+}
+
+func (v *validator) validateObject(n *node, obj map[string]any, loc, kw jp.Expr, ev *evaluated) {
+	if n.maxProps != nil && *n.maxProps < len(obj) {
+		v.fail(loc, with(kw, "maxProperties"), "must have no more than %d properties", *n.maxProps)
+	}
+	if n.minProps != nil && len(obj) < *n.minProps {
+		v.fail(loc, with(kw, "minProperties"), "must have at least %d properties", *n.minProps)
+	}
+	for _, k := range n.required {
+		if _, has := obj[k]; !has {
+			v.fail(loc, with(kw, "required"), "is missing required property %q", k)
+		}
+	}
+	for _, kl := range n.depRequired {
+		if _, has := obj[kl.key]; has {
+			for _, k := range kl.list {
+				if _, has := obj[k]; !has {
+					v.fail(loc, with(kw, n.depReqKey, kl.key), "is missing property %q required by %q", k, kl.key)
+				}
+			}
+		}
+	}
+	for _, kn := range n.depSchemas {
+		if _, has := obj[kn.key]; has {
+			ev.merge(v.validate(kn.n, obj, loc, with(kw, n.depSchemaKey, kn.key)))
+		}
+	}
+	if len(n.props) == 0 && len(n.patternProps) == 0 && n.addProps == nil && n.propNames == nil {
+		return
+	}
+	for _, k := range sortedKeys(obj) {
+		val := obj[k]
+		if n.propNames != nil {
+			v.validate(n.propNames, k, with(loc, k), with(kw, "propertyNames"))
+		}
+		matched := false
+		for _, kn := range n.props {
+			if kn.key == k {
+				v.validate(kn.n, val, with(loc, k), with(kw, "properties", k))
+				matched = true
+				break
+			}
+		}
+		for _, pp := range n.patternProps {
+			if pp.rx.MatchString(k) {
+				v.validate(pp.n, val, with(loc, k), with(kw, "patternProperties", pp.src))
+				matched = true
+			}
+		}
+		if !matched && n.addProps != nil {
+			v.validate(n.addProps, val, with(loc, k), with(kw, "additionalProperties"))
+			matched = true
+		}
+		if matched {
+			ev.prop(k)
+		}
+	}
+}
+
+// with returns a copy of x with the keys and indexes appended.
+func with(x jp.Expr, frags ...any) jp.Expr {
+	nx := make(jp.Expr, len(x), len(x)+len(frags))
+	copy(nx, x)
+	for _, f := range frags {
+		switch tf := f.(type) {
+		case string:
+			nx = append(nx, jp.Child(tf))
+		case int:
+			nx = append(nx, jp.Nth(tf))
+		}
+	}
+	return nx
+}
+
+func isMultiple(f, m float64) bool {
+	if f == math.Trunc(f) && m == math.Trunc(m) && math.Abs(f) < 1<<53 {
+		return math.Mod(f, m) == 0.0
+	}
+	q := f / m
+	if math.IsInf(q, 0) || math.IsNaN(q) {
+		return false
+	}
+	return math.Abs(q-math.Round(q)) < 1e-9
+}
+
+func hasType(v any, t string) bool {
+	switch t {
+	case "null":
+		return v == nil
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "array":
+		_, ok := v.([]any)
+		return ok
+	case "object":
+		_, ok := v.(map[string]any)
+		return ok
+	case "number":
+		_, ok := asNumber(v)
+		return ok
+	case "integer":
+		f, ok := asNumber(v)
+		return ok && f == math.Trunc(f) && !math.IsInf(f, 0)
+	}
+	return false
+}
+
+func asNumber(v any) (float64, bool) {
+	switch tv := v.(type) {
+	case int64:
+		return float64(tv), true
+	case float64:
+		return tv, true
+	case int:
+		return float64(tv), true
+	case int8:
+		return float64(tv), true
+	case int16:
+		return float64(tv), true
+	case int32:
+		return float64(tv), true
+	case uint:
+		return float64(tv), true
+	case uint8:
+		return float64(tv), true
+	case uint16:
+		return float64(tv), true
+	case uint32:
+		return float64(tv), true
+	case uint64:
+		return float64(tv), true
+	case float32:
+		return float64(tv), true
+	case json.Number:
+		f, err := tv.Float64()
+		return f, err == nil
+	}
+	return 0.0, false
+}
+
+// equal compares JSON values. Numbers are equal if they have the same
+// value regardless of type.
+func equal(a, b any) bool {
+	if fa, ok := asNumber(a); ok {
+		fb, ok := asNumber(b)
+		return ok && fa == fb
+	}
+	switch ta := a.(type) {
+	case nil:
+		return b == nil
+	case bool:
+		tb, ok := b.(bool)
+		return ok && ta == tb
+	case string:
+		tb, ok := b.(string)
+		return ok && ta == tb
+	case []any:
+		tb, ok := b.([]any)
+		if !ok || len(ta) != len(tb) {
+			return false
+		}
+		for i, x := range ta {
+			if !equal(x, tb[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		tb, ok := b.(map[string]any)
+		if !ok || len(ta) != len(tb) {
+			return false
+		}
+		for k, x := range ta {
+			y, has := tb[k]
+			if !has || !equal(x, y) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// normalize converts gen.Node values and Go types that are not simple
+// types to simple types.
+func normalize(v any) any {
+	if n, ok := v.(gen.Node); ok {
+		return n.Simplify()
+	}
+	if isSimple(v) {
+		return v
+	}
+	return alt.Decompose(v, &ojg.GoOptions)
+}
+
+func isSimple(v any) bool {
+	switch tv := v.(type) {
+	case nil, bool, string, int64, float64, int, int8, int16, int32,
+		uint, uint8, uint16, uint32, uint64, float32, json.Number:
+		return true
+	case []any:
+		for _, x := range tv {
+			if !isSimple(x) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		for _, x := range tv {
+			if !isSimple(x) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -53,6 +53,9 @@ type Parser struct {
 	lastStrKey gen.Key
 	tokenFuncs map[string]TokenFunc
 	quoteDelim byte
+	kline      int // line of the most recent string or token start
+	kcol       int // column of the most recent string or token start
+	dupKeys    bool
 
 	// Reuse maps. Previously returned maps will no longer be valid or rather
 	// could be modified during parsing.
@@ -61,6 +64,17 @@ type Parser struct {
 	// OnlyOne returns an error if more than one JSON is in the string or stream.
 	OnlyOne bool
 
+	// CheckDupKeys if true causes a duplicate key in an object to be
+	// reported as a *oj.ParseError at the line and column of the duplicate
+	// key.
+	CheckDupKeys bool
+
+	// OnDupKey if not nil is called for each duplicate key encountered
+	// instead of returning an error. The line and column are those of the
+	// duplicate key. Parsing continues and the last value wins. Setting
+	// OnDupKey also turns on duplicate key detection.
+	OnDupKey func(key string, line, column int)
+
 	plus bool
 }
 
@@ -130,6 +144,7 @@ func (p *Parser) Parse(buf []byte, args ...any) (any, error) {
 	p.result = nil
 	p.noff = -1
 	p.line = 1
+	p.dupKeys = p.CheckDupKeys || p.OnDupKey != nil
 	p.mode = valueMap
 	p.mi = 0
 	var err error
@@ -198,6 +213,7 @@ func (p *Parser) ParseReader(r io.Reader, args ...any) (data any, err error) {
 	p.result = nil
 	p.noff = -1
 	p.line = 1
+	p.dupKeys = p.CheckDupKeys || p.OnDupKey != nil
 	p.mi = 0
 	buf := make([]byte, readBufSize)
 	eof := false
@@ -231,6 +247,7 @@ func (p *Parser) ParseReader(r io.Reader, args ...any) (data any, err error) {
 
 			return
 		}
+		p.noff -= len(buf) - skip
 		skip = 0
 		if eof {
 			break
@@ -281,6 +298,10 @@ func (p *Parser) parseBuffer(buf []byte, last bool) (err error) {
 			continue
 
 		case tokenStart:
+			if p.dupKeys {
+				p.kline = p.line
+				p.kcol = off - p.noff
+			}
 			start := off
 			for i, b = range buf[off:] {
 				if tokenMap[b] != tokenOk {
@@ -307,7 +328,9 @@ func (p *Parser) parseBuffer(buf []byte, last bool) (err error) {
 				p.mode = valueMap
 				continue
 			}
-			p.addTokenWith(string(buf[start:off]), off)
+			if err = p.addTokenWith(string(buf[start:off]), off); err != nil {
+				return
+			}
 			off--
 		case strOk:
 			p.tmp = append(p.tmp, b)
@@ -327,7 +350,9 @@ func (p *Parser) parseBuffer(buf []byte, last bool) (err error) {
 						return
 					}
 				case 't':
-					p.addToken(off)
+					if err = p.addToken(off); err != nil {
+						return
+					}
 				}
 			}
 			p.starts = append(p.starts, -1)
@@ -361,7 +386,9 @@ func (p *Parser) parseBuffer(buf []byte, last bool) (err error) {
 						return
 					}
 				case 't':
-					p.addToken(off)
+					if err = p.addToken(off); err != nil {
+						return
+					}
 				}
 			}
 			p.starts = p.starts[0:depth]
@@ -391,6 +418,10 @@ func (p *Parser) parseBuffer(buf []byte, last bool) (err error) {
 			}
 			off += i
 		case valQuote:
+			if p.dupKeys {
+				p.kline = p.line
+				p.kcol = off - p.noff
+			}
 			p.quoteDelim = b
 			start := off + 1
 			if len(buf) <= start {
@@ -406,7 +437,9 @@ func (p *Parser) parseBuffer(buf []byte, last bool) (err error) {
 			off += i
 			if b == p.quoteDelim {
 				off++
-				p.addString(string(buf[start:off]), off)
+				if err = p.addString(string(buf[start:off]), off); err != nil {
+					return
+				}
 			} else {
 				p.tmp = p.tmp[:0]
 				p.tmp = append(p.tmp, buf[start:off+1]...)
@@ -445,7 +478,9 @@ func (p *Parser) parseBuffer(buf []byte, last bool) (err error) {
 						return
 					}
 				case 't':
-					p.addToken(off)
+					if err = p.addToken(off); err != nil {
+						return
+					}
 				}
 			}
 			p.starts = append(p.starts, len(p.stack))
@@ -465,7 +500,7 @@ func (p *Parser) parseBuffer(buf []byte, last bool) (err error) {
 				// can not fail appending to an array
 				_ = p.add(p.num.AsNum(), off)
 			case 't':
-				p.addToken(off)
+				_ = p.addToken(off)
 			}
 			start := p.starts[len(p.starts)-1] + 1
 			p.starts = p.starts[:len(p.starts)-1]
@@ -511,12 +546,18 @@ func (p *Parser) parseBuffer(buf []byte, last bool) (err error) {
 		case tokenOk:
 			p.tmp = append(p.tmp, b)
 		case tokenSpc:
-			p.addToken(off)
+			if err = p.addToken(off); err != nil {
+				return
+			}
 		case tokenColon:
-			p.addToken(off)
+			if err = p.addToken(off); err != nil {
+				return
+			}
 			p.mode = valueMap
 		case tokenNlColon:
-			p.addToken(off)
+			if err = p.addToken(off); err != nil {
+				return
+			}
 			p.line++
 			p.noff = off
 			for i, b = range buf[off+1:] {
@@ -534,7 +575,9 @@ func (p *Parser) parseBuffer(buf []byte, last bool) (err error) {
 			p.lastStrKey = p.lastKey
 		case strQuote:
 			if b == p.quoteDelim {
-				p.addString(string(p.tmp), off)
+				if err = p.addString(string(p.tmp), off); err != nil {
+					return
+				}
 			} else {
 				p.tmp = append(p.tmp, b)
 			}
@@ -594,7 +637,9 @@ func (p *Parser) parseBuffer(buf []byte, last bool) (err error) {
 						return
 					}
 				case 't':
-					p.addToken(off)
+					if err = p.addToken(off); err != nil {
+						return
+					}
 				}
 			}
 			p.mode = commentStartMap
@@ -631,7 +676,7 @@ func (p *Parser) parseBuffer(buf []byte, last bool) (err error) {
 				// can not fail appending to a function argument set
 				_ = p.add(p.num.AsNum(), off)
 			case 't':
-				p.addToken(off)
+				_ = p.addToken(off)
 			}
 			start := p.starts[len(p.starts)-1] + 1
 			p.starts = p.starts[:len(p.starts)-1]
@@ -687,7 +732,9 @@ func (p *Parser) parseBuffer(buf []byte, last bool) (err error) {
 				}
 			}
 		case 't': // token
-			p.addToken(off)
+			if err = p.addToken(off); err != nil {
+				return
+			}
 			if p.cb == nil && p.resultChan == nil {
 				p.result = p.stack[0]
 			} else {
@@ -725,7 +772,7 @@ func (p *Parser) add(n any, off int) error {
 	return nil
 }
 
-func (p *Parser) addToken(off int) {
+func (p *Parser) addToken(off int) error {
 	s := string(p.tmp)
 	p.mode = valueMap
 	if 0 < len(p.starts) {
@@ -745,10 +792,9 @@ func (p *Parser) addToken(off int) {
 				p.lastKey = k
 				p.stack = p.stack[0 : len(p.stack)-1]
 			} else {
-				p.stack = append(p.stack, gen.Key(s))
-				p.mode = colonMap
+				return p.pushKey(gen.Key(s))
 			}
-			return
+			return nil
 		}
 	}
 	// Array or just a value
@@ -762,9 +808,10 @@ func (p *Parser) addToken(off int) {
 	default:
 		p.stack = append(p.stack, s)
 	}
+	return nil
 }
 
-func (p *Parser) addTokenWith(s string, off int) {
+func (p *Parser) addTokenWith(s string, off int) error {
 	p.mode = valueMap
 	if 0 < len(p.starts) {
 		if p.starts[len(p.starts)-1] == -1 { // object
@@ -783,10 +830,9 @@ func (p *Parser) addTokenWith(s string, off int) {
 				p.lastKey = k
 				p.stack = p.stack[0 : len(p.stack)-1]
 			} else {
-				p.stack = append(p.stack, gen.Key(s))
-				p.mode = colonMap
+				return p.pushKey(gen.Key(s))
 			}
-			return
+			return nil
 		}
 	}
 	// Array or just a value
@@ -800,9 +846,10 @@ func (p *Parser) addTokenWith(s string, off int) {
 	default:
 		p.stack = append(p.stack, s)
 	}
+	return nil
 }
 
-func (p *Parser) addString(s string, off int) {
+func (p *Parser) addString(s string, off int) error {
 	p.mode = valueMap
 	if 0 < len(p.starts) && p.starts[len(p.starts)-1] == -1 { // object
 		if p.plus {
@@ -811,19 +858,16 @@ func (p *Parser) addString(s string, off int) {
 			obj[string(p.lastStrKey)] = prev + s
 			p.lastStrKey = emptyKey
 			p.plus = false
-			return
+			return nil
 		}
 		if k, ok := p.stack[len(p.stack)-1].(gen.Key); ok {
 			obj, _ := p.stack[len(p.stack)-2].(map[string]any)
 			obj[string(k)] = s
 			p.lastKey = k
 			p.stack = p.stack[0 : len(p.stack)-1]
-			return
+			return nil
 		}
-		p.stack = append(p.stack, gen.Key(s))
-		p.mode = colonMap
-
-		return
+		return p.pushKey(gen.Key(s))
 	}
 	if p.plus {
 		if 0 < len(p.stack) {
@@ -831,12 +875,38 @@ func (p *Parser) addString(s string, off int) {
 			p.stack[len(p.stack)-1] = prev + s
 		}
 		p.plus = false
-		return
+		return nil
 	}
 	// TBD if time option for @ and length is over a certain size try as time
 
 	// Array or just a value
 	p.stack = append(p.stack, s)
+
+	return nil
+}
+
+// pushKey pushes a key onto the stack after checking for a duplicate if
+// duplicate key detection is turned on. The object the key belongs to is
+// expected to be on the top of the stack.
+func (p *Parser) pushKey(k gen.Key) error {
+	if p.dupKeys {
+		obj, _ := p.stack[len(p.stack)-1].(map[string]any)
+		if _, has := obj[string(k)]; has {
+			if p.OnDupKey == nil {
+				return &oj.ParseError{
+					Message: fmt.Sprintf("duplicate key '%s'", k),
+					Line:    p.kline,
+					Column:  p.kcol,
+					Err:     oj.ErrDuplicateKey,
+				}
+			}
+			p.OnDupKey(string(k), p.kline, p.kcol)
+		}
+	}
+	p.stack = append(p.stack, k)
+	p.mode = colonMap
+
+	return nil
 }
 
 func (p *Parser) newError(off int, format string, args ...any) error {
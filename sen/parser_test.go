@@ -215,10 +215,10 @@ func TestParserParseReader(t *testing.T) {
 		{src: strings.Repeat(" ", 4093) + "[abc// comment\n]", value: []any{"abc"}},
 		{src: strings.Repeat(" ", 4093) + "[abc{x:1}]", value: []any{"abc", map[string]any{"x": 1}}},
 
-		{src: strings.Repeat(" ", 4094) + "abc#", expect: "unexpected character '#' at 1:2"},
+		{src: strings.Repeat(" ", 4094) + "abc#", expect: "unexpected character '#' at 1:4098"},
 		{src: strings.Repeat(" ", 4094) + "hello\n #", expect: "extra characters after close, '#' at 2:2"},
-		{src: strings.Repeat(" ", 4094) + "hello]", expect: "unexpected array close at 1:4"},
-		{src: strings.Repeat(" ", 4094) + "hello}", expect: "unexpected object close at 1:4"},
+		{src: strings.Repeat(" ", 4094) + "hello]", expect: "unexpected array close at 1:4100"},
+		{src: strings.Repeat(" ", 4094) + "hello}", expect: "unexpected object close at 1:4100"},
 		{src: strings.Repeat(" ", 4095) + `"x"`, value: "x"},
 	} {
 		if testing.Verbose() {
@@ -553,3 +553,52 @@ func TestParserCComment(t *testing.T) {
 	v = sen.MustParse([]byte(src))
 	tt.Equal(t, []any{"abc", "ghi"}, v)
 }
+
+func TestParserDupKeys(t *testing.T) {
+	p := sen.Parser{CheckDupKeys: true}
+	for i, d := range []rdata{
+		{src: `{a:1 b:2}`, value: map[string]any{"a": 1, "b": 2}},
+		{src: `{a:{a:1} b:[{a:2}{a:3}]}`,
+			value: map[string]any{"a": map[string]any{"a": 1}, "b": []any{map[string]any{"a": 2}, map[string]any{"a": 3}}}},
+		{src: `{a:1 a:2}`, expect: "duplicate key 'a' at 1:6"},
+		{src: `{a:1 "a":2}`, expect: "duplicate key 'a' at 1:6"},
+		{src: `{"a":1, 'a':2}`, expect: "duplicate key 'a' at 1:9"},
+		{src: "{\n  a: 1\n  b: {x: true x: false}}", expect: "duplicate key 'x' at 3:15"},
+		{src: `{a:1 b:2 a}`, expect: "duplicate key 'a' at 1:10"},
+	} {
+		v, err := p.Parse([]byte(d.src))
+		if 0 < len(d.expect) {
+			tt.NotNil(t, err, d.src)
+			tt.Equal(t, d.expect, err.Error(), i, ": ", d.src)
+		} else {
+			tt.Nil(t, err, d.src)
+			tt.Equal(t, d.value, v, i, ": ", d.src)
+		}
+		r := strings.NewReader(strings.Repeat(" ", 4093) + d.src)
+		if _, err = p.ParseReader(r); 0 < len(d.expect) {
+			tt.NotNil(t, err, d.src)
+		} else {
+			tt.Nil(t, err, d.src)
+		}
+	}
+}
+
+func TestParserDupKeysCallback(t *testing.T) {
+	var dups []string
+	p := sen.Parser{
+		OnDupKey: func(key string, line, column int) {
+			dups = append(dups, fmt.Sprintf("%s@%d:%d", key, line, column))
+		},
+	}
+	v, err := p.Parse([]byte("{a:1 a:2\nb:{c:3 'c':4} a:5}"))
+	tt.Nil(t, err)
+	tt.Equal(t, map[string]any{"a": 5, "b": map[string]any{"c": 4}}, v)
+	tt.Equal(t, []string{"a@1:6", "c@2:8", "a@2:15"}, dups)
+
+	dups = dups[:0]
+	src := strings.Repeat(" ", 4090) + `{abc:1 abc:2}`
+	v, err = p.ParseReader(strings.NewReader(src))
+	tt.Nil(t, err)
+	tt.Equal(t, map[string]any{"abc": 2}, v)
+	tt.Equal(t, []string{"abc@1:4098"}, dups)
+}
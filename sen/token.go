@@ -0,0 +1,55 @@
+// Copyright (c) 2026, Peter Ohler, All rights reserved.
+
+package sen
+
+import "github.com/ohler55/ojg/oj"
+
+// tokenQueue is a TokenHandler that collects tokens for the pull API of the
+// Tokenizer.
+type tokenQueue struct {
+	tokens []oj.Token
+}
+
+func (q *tokenQueue) Null() {
+	q.tokens = append(q.tokens, oj.Token{Kind: oj.NullToken})
+}
+
+func (q *tokenQueue) Bool(b bool) {
+	q.tokens = append(q.tokens, oj.Token{Kind: oj.BoolToken, Bool: b})
+}
+
+func (q *tokenQueue) Int(i int64) {
+	q.tokens = append(q.tokens, oj.Token{Kind: oj.IntToken, Int: i})
+}
+
+func (q *tokenQueue) Float(f float64) {
+	q.tokens = append(q.tokens, oj.Token{Kind: oj.FloatToken, Float: f})
+}
+
+func (q *tokenQueue) Number(s string) {
+	q.tokens = append(q.tokens, oj.Token{Kind: oj.NumberToken, Str: s})
+}
+
+func (q *tokenQueue) String(s string) {
+	q.tokens = append(q.tokens, oj.Token{Kind: oj.StringToken, Str: s})
+}
+
+func (q *tokenQueue) ObjectStart() {
+	q.tokens = append(q.tokens, oj.Token{Kind: oj.ObjectStartToken})
+}
+
+func (q *tokenQueue) ObjectEnd() {
+	q.tokens = append(q.tokens, oj.Token{Kind: oj.ObjectEndToken})
+}
+
+func (q *tokenQueue) Key(s string) {
+	q.tokens = append(q.tokens, oj.Token{Kind: oj.KeyToken, Str: s})
+}
+
+func (q *tokenQueue) ArrayStart() {
+	q.tokens = append(q.tokens, oj.Token{Kind: oj.ArrayStartToken})
+}
+
+func (q *tokenQueue) ArrayEnd() {
+	q.tokens = append(q.tokens, oj.Token{Kind: oj.ArrayEndToken})
+}
@@ -38,6 +38,16 @@ type Tokenizer struct {
 
 	// OnlyOne returns an error if more than one JSON is in the string or stream.
 	OnlyOne bool
+
+	// Used when pulling tokens with Next().
+	r     io.Reader
+	buf   []byte
+	queue tokenQueue
+	pos   int
+	depth int
+	err   error
+	eof   bool
+	first bool
 }
 
 // TokenizeString the provided JSON and call the handler functions for each
@@ -151,6 +161,218 @@ func (t *Tokenizer) Load(r io.Reader, handler oj.TokenHandler) (err error) {
 	return
 }
 
+// NewTokenizer returns a Tokenizer for pulling tokens from r with Next().
+func NewTokenizer(r io.Reader) *Tokenizer {
+	t := Tokenizer{}
+	t.Reset(r)
+
+	return &t
+}
+
+// Reset prepares the Tokenizer for pulling tokens from r with Next(). Buffers
+// are reused across resets.
+func (t *Tokenizer) Reset(r io.Reader) {
+	t.handler = &t.queue
+	if t.starts == nil {
+		t.tmp = make([]byte, 0, tmpInitSize)
+		t.starts = make([]byte, 0, 16)
+		t.buf = make([]byte, 0, readBufSize)
+	} else {
+		t.tmp = t.tmp[:0]
+		t.starts = t.starts[:0]
+		if t.buf == nil {
+			t.buf = make([]byte, 0, readBufSize)
+		}
+	}
+	t.noff = -1
+	t.line = 1
+	t.mi = 0
+	t.mode = valueMap
+	t.exkey = false
+	t.r = r
+	t.queue.tokens = t.queue.tokens[:0]
+	t.pos = 0
+	t.depth = 0
+	t.err = nil
+	t.eof = false
+	t.first = true
+}
+
+// Next returns the next token read from the io.Reader provided to
+// NewTokenizer() or Reset(). The io.EOF error is returned when there are no
+// more tokens. Input is read and tokenized a buffer at a time as tokens are
+// requested.
+func (t *Tokenizer) Next() (tok oj.Token, err error) {
+	for len(t.queue.tokens) <= t.pos {
+		if err = t.fill(); err != nil {
+			return
+		}
+	}
+	tok = t.queue.tokens[t.pos]
+	t.pos++
+	switch tok.Kind {
+	case oj.ObjectStartToken, oj.ArrayStartToken:
+		t.depth++
+	case oj.ObjectEndToken, oj.ArrayEndToken:
+		t.depth--
+	}
+	return
+}
+
+// Skip skips over the rest of the current object or array including the
+// closing token. Called immediately after Next() returns an
+// ObjectStartToken or ArrayStartToken the whole object or array is
+// skipped. Skip does nothing if not in an object or array. Input that has
+// not been tokenized yet is scanned only for strings, comments, and the
+// closing bracket so no tokens are created for the skipped values and they
+// are not validated.
+func (t *Tokenizer) Skip() error {
+	if t.depth <= 0 {
+		return nil
+	}
+	target := t.depth - 1
+	for ; t.pos < len(t.queue.tokens); t.pos++ {
+		switch t.queue.tokens[t.pos].Kind {
+		case oj.ObjectStartToken, oj.ArrayStartToken:
+			t.depth++
+		case oj.ObjectEndToken, oj.ArrayEndToken:
+			t.depth--
+			if t.depth == target {
+				t.pos++
+				return nil
+			}
+		}
+	}
+	// The queue is empty so the tokenizer state is at the end of the last
+	// buffer read which may be in the middle of a string or comment.
+	inStr := t.mode == stringMap || t.mode == escMap || t.mode == uMap
+	esc := t.mode == escMap
+	inComment := t.mode == commentMap
+	slash := t.mode == commentStartMap
+	for {
+		buf, err := t.read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		for off, b := range buf {
+			if b == '\n' {
+				t.line++
+				t.noff = off
+			}
+			switch {
+			case esc:
+				esc = false
+			case inStr:
+				switch b {
+				case '\\':
+					esc = true
+				case '"', '\'':
+					inStr = false
+				}
+			case inComment:
+				inComment = b != '\n'
+			case slash:
+				slash = false
+				inComment = b == '/'
+			case b == '"' || b == '\'':
+				inStr = true
+			case b == '/':
+				slash = true
+			case b == '{' || b == '[':
+				t.depth++
+			case b == '}' || b == ']':
+				t.depth--
+				if t.depth == target {
+					t.starts = t.starts[:target]
+					t.tmp = t.tmp[:0]
+					t.mode = valueMap
+					t.exkey = 0 < target && t.starts[target-1] == objectStart
+					if target == 0 {
+						t.mi = 0
+						if t.OnlyOne {
+							t.mode = spaceMap
+						}
+					}
+					// Offsets in the rest of the buffer start after the
+					// close.
+					t.noff -= off + 1
+					return t.tokenize(buf[off+1:])
+				}
+			}
+		}
+		t.noff -= len(buf)
+	}
+}
+
+// fill reads the next buffer and tokenizes it into the token queue.
+func (t *Tokenizer) fill() error {
+	buf, err := t.read()
+	if err != nil {
+		return err
+	}
+	return t.tokenize(buf)
+}
+
+// read reads the next buffer from the reader skipping the BOM if present at
+// the start.
+func (t *Tokenizer) read() ([]byte, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	if t.r == nil {
+		t.err = fmt.Errorf("no reader to read tokens from")
+		return nil, t.err
+	}
+	if t.eof {
+		t.err = io.EOF
+		return nil, t.err
+	}
+	t.queue.tokens = t.queue.tokens[:0]
+	t.pos = 0
+	buf := t.buf[:cap(t.buf)]
+	cnt, err := t.r.Read(buf)
+	buf = buf[:cnt]
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			t.err = err
+			return nil, err
+		}
+		t.eof = true
+	}
+	if t.first {
+		// Skip BOM if present.
+		if 3 < len(buf) && buf[0] == 0xEF && buf[1] == 0xBB && buf[2] == 0xBF {
+			buf = buf[3:]
+		}
+		t.first = false
+	}
+	return buf, nil
+}
+
+// tokenize the buffer into the token queue.
+func (t *Tokenizer) tokenize(buf []byte) (err error) {
+	t.queue.tokens = t.queue.tokens[:0]
+	t.pos = 0
+	defer func() {
+		if r := recover(); r != nil {
+			if t.err, _ = r.(error); t.err == nil {
+				t.err = ojg.NewError(r)
+			}
+			// Tokens before the error are returned before the error.
+			if len(t.queue.tokens) == 0 {
+				err = t.err
+			}
+		}
+	}()
+	t.tokenizeBuffer(buf, t.eof)
+	t.noff -= len(buf)
+
+	return
+}
+
 func (t *Tokenizer) tokenizeBuffer(buf []byte, last bool) {
 	var b byte
 	var i int
@@ -7,6 +7,7 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/ohler55/ojg/oj"
 	"github.com/ohler55/ojg/sen"
@@ -304,3 +305,111 @@ func TestTokenizerMany(t *testing.T) {
 		}
 	}
 }
+
+func pullAll(toker *sen.Tokenizer) (string, error) {
+	var b strings.Builder
+	for {
+		tok, err := toker.Next()
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return b.String(), err
+		}
+		b.WriteString(tok.String())
+		b.WriteByte(' ')
+	}
+}
+
+func TestTokenizerNext(t *testing.T) {
+	src := `[true null 123 -12.3 abc "aé"]{x:12345678901234567890 y:{}} // comment
+7`
+	toker := sen.NewTokenizer(strings.NewReader(src))
+	out, err := pullAll(toker)
+	tt.Nil(t, err)
+	tt.Equal(t, `[ true null 123 -12.3 "abc" "aé" ] { "x" 12345678901234567890 "y" { } } 7 `, out)
+
+	toker.Reset(iotest.OneByteReader(strings.NewReader(src)))
+	out, err = pullAll(toker)
+	tt.Nil(t, err)
+	tt.Equal(t, `[ true null 123 -12.3 "abc" "aé" ] { "x" 12345678901234567890 "y" { } } 7 `, out)
+
+	toker.Reset(strings.NewReader(`{k:v n:1.5}`))
+	tok, err := toker.Next()
+	tt.Nil(t, err)
+	tt.Equal(t, oj.ObjectStartToken, tok.Kind)
+	tok, err = toker.Next()
+	tt.Nil(t, err)
+	tt.Equal(t, "k", tok.Key())
+	tok, err = toker.Next()
+	tt.Nil(t, err)
+	tt.Equal(t, "v", tok.Value())
+
+	tt.Nil(t, toker.Skip())
+	_, err = toker.Next()
+	tt.Equal(t, io.EOF, err)
+	// EOF is sticky.
+	_, err = toker.Next()
+	tt.Equal(t, io.EOF, err)
+}
+
+func TestTokenizerNextError(t *testing.T) {
+	toker := sen.NewTokenizer(strings.NewReader(`[1 2 }`))
+	out, err := pullAll(toker)
+	tt.NotNil(t, err)
+	tt.Equal(t, "unexpected object close at 1:6", err.Error())
+	tt.Equal(t, "[ 1 2 ", out)
+
+	toker = sen.NewTokenizer(strings.NewReader(`[1 "x"`))
+	out, err = pullAll(toker)
+	tt.NotNil(t, err)
+	tt.Equal(t, `[ 1 "x" `, out)
+
+	toker = sen.NewTokenizer(iotest.ErrReader(fmt.Errorf("failed")))
+	_, err = toker.Next()
+	tt.Equal(t, "failed", err.Error())
+
+	toker = &sen.Tokenizer{}
+	_, err = toker.Next()
+	tt.NotNil(t, err)
+}
+
+func TestTokenizerSkip(t *testing.T) {
+	src := `{skip:{a:[1 {b:2}] c:d e:"]}\\\"{[" f:'}' // ]}
+} keep:[3 [4 5] 6] last:true}`
+	for _, r := range []io.Reader{strings.NewReader(src), iotest.OneByteReader(strings.NewReader(src))} {
+		toker := sen.NewTokenizer(r)
+		var b strings.Builder
+		for {
+			tok, err := toker.Next()
+			if err != nil {
+				tt.Equal(t, io.EOF, err)
+				break
+			}
+			b.WriteString(tok.String())
+			b.WriteByte(' ')
+			switch {
+			case tok.Kind == oj.KeyToken && tok.Key() == "skip":
+				tok, err = toker.Next()
+				tt.Nil(t, err)
+				tt.Equal(t, oj.ObjectStartToken, tok.Kind)
+				tt.Nil(t, toker.Skip())
+			case tok.Kind == oj.IntToken && tok.Int == 4:
+				tt.Nil(t, toker.Skip())
+			}
+		}
+		tt.Equal(t, `{ "skip" "keep" [ 3 [ 4 6 ] "last" true } `, b.String())
+	}
+	toker := sen.NewTokenizer(strings.NewReader(`[1 [2 3`))
+	_, _ = toker.Next()
+	tt.NotNil(t, toker.Skip())
+
+	// Lines are still counted when skipped values are not tokenized.
+	toker = sen.NewTokenizer(iotest.OneByteReader(strings.NewReader("[[1\n2]\n }]")))
+	_, _ = toker.Next()
+	_, _ = toker.Next()
+	tt.Nil(t, toker.Skip())
+	_, err := toker.Next()
+	tt.NotNil(t, err)
+	tt.Equal(t, "unexpected object close at 3:2", err.Error())
+}